@@ -5,6 +5,7 @@ package api
 
 import (
 	"context"
+	"encoding/hex"
 
 	"github.com/absmach/magistrala/bootstrap"
 	api "github.com/absmach/supermq/api/http"
@@ -29,7 +30,7 @@ func addEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 
 		channels := []bootstrap.Channel{}
 		for _, c := range req.Channels {
-			channels = append(channels, bootstrap.Channel{ID: c})
+			channels = append(channels, bootstrap.Channel{ID: c.ID, Role: c.Role})
 		}
 
 		config := bootstrap.Config{
@@ -86,6 +87,33 @@ func updateCertEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	}
 }
 
+func issueCertEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(issueCertReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		cfg, err := svc.IssueCert(ctx, session, req.token, req.clientID, req.TTL)
+		if err != nil {
+			return nil, err
+		}
+
+		res := updateConfigRes{
+			ClientID:   cfg.ClientID,
+			ClientCert: cfg.ClientCert,
+			CACert:     cfg.CACert,
+		}
+
+		return res, nil
+	}
+}
+
 func viewEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(entityReq)
@@ -109,18 +137,21 @@ func viewEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 				ID:       ch.ID,
 				Name:     ch.Name,
 				Metadata: ch.Metadata,
+				Role:     ch.Role,
 			})
 		}
 
 		res := viewRes{
-			ClientID:     config.ClientID,
-			CLientSecret: config.ClientSecret,
-			Channels:     channels,
-			ExternalID:   config.ExternalID,
-			ExternalKey:  config.ExternalKey,
-			Name:         config.Name,
-			Content:      config.Content,
-			State:        config.State,
+			ClientID:       config.ClientID,
+			CLientSecret:   config.ClientSecret,
+			Channels:       channels,
+			ExternalID:     config.ExternalID,
+			ExternalKey:    config.ExternalKey,
+			Name:           config.Name,
+			Content:        config.Content,
+			State:          config.State,
+			Version:        config.Version,
+			FetchedVersion: config.FetchedVersion,
 		}
 
 		return res, nil
@@ -213,18 +244,21 @@ func listEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 					ID:       ch.ID,
 					Name:     ch.Name,
 					Metadata: ch.Metadata,
+					Role:     ch.Role,
 				})
 			}
 
 			view := viewRes{
-				ClientID:     cfg.ClientID,
-				CLientSecret: cfg.ClientSecret,
-				Channels:     channels,
-				ExternalID:   cfg.ExternalID,
-				ExternalKey:  cfg.ExternalKey,
-				Name:         cfg.Name,
-				Content:      cfg.Content,
-				State:        cfg.State,
+				ClientID:       cfg.ClientID,
+				CLientSecret:   cfg.ClientSecret,
+				Channels:       channels,
+				ExternalID:     cfg.ExternalID,
+				ExternalKey:    cfg.ExternalKey,
+				Name:           cfg.Name,
+				Content:        cfg.Content,
+				State:          cfg.State,
+				Version:        cfg.Version,
+				FetchedVersion: cfg.FetchedVersion,
 			}
 			res.Configs = append(res.Configs, view)
 		}
@@ -235,7 +269,7 @@ func listEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 
 func removeEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(entityReq)
+		req := request.(removeReq)
 		if err := req.validate(); err != nil {
 			return removeRes{}, errors.Wrap(apiutil.ErrValidation, err)
 		}
@@ -245,7 +279,7 @@ func removeEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			return nil, svcerr.ErrAuthorization
 		}
 
-		if err := svc.Remove(ctx, session, req.id); err != nil {
+		if err := svc.Remove(ctx, session, req.token, req.id); err != nil {
 			return nil, err
 		}
 
@@ -281,10 +315,168 @@ func stateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			return nil, svcerr.ErrAuthorization
 		}
 
-		if err := svc.ChangeState(ctx, session, req.token, req.id, req.State); err != nil {
+		conns, err := svc.ChangeState(ctx, session, req.token, req.id, req.State)
+		if err != nil {
+			return nil, err
+		}
+
+		return stateRes{Connections: conns}, nil
+	}
+}
+
+func exportEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		// Key is validated to be hex encoded.
+		key, _ := hex.DecodeString(req.Key)
+		bundle, err := svc.ExportConfigs(ctx, session, req.filter, key)
+		if err != nil {
+			return nil, err
+		}
+
+		return exportRes{Bundle: bundle}, nil
+	}
+}
+
+func importEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(importReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		// Key is validated to be hex encoded.
+		key, _ := hex.DecodeString(req.Key)
+		summary, err := svc.ImportConfigs(ctx, session, req.token, req.Bundle, key, req.Strategy)
+		if err != nil {
+			return nil, err
+		}
+
+		return importRes{ImportSummary: summary}, nil
+	}
+}
+
+func reconcileEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reconcileReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		report, err := svc.Reconcile(ctx, session, req.token, req.repair)
+		if err != nil {
+			return nil, err
+		}
+
+		return reconcileRes{ReconcileReport: report}, nil
+	}
+}
+
+func createRolloutEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createRolloutReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		rollout := bootstrap.Rollout{
+			Content:    req.Content,
+			ClientIDs:  req.ClientIDs,
+			Percentage: req.Percentage,
+			Cohort:     req.Cohort,
+		}
+		saved, err := svc.CreateRollout(ctx, session, rollout)
+		if err != nil {
+			return nil, err
+		}
+
+		return rolloutRes{Rollout: saved, created: true}, nil
+	}
+}
+
+func viewRolloutEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rolloutReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		rollout, err := svc.ViewRollout(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return rolloutRes{Rollout: rollout}, nil
+	}
+}
+
+func promoteRolloutEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rolloutReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		rollout, err := svc.PromoteRollout(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return rolloutRes{Rollout: rollout}, nil
+	}
+}
+
+func rollBackRolloutEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rolloutReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		rollout, err := svc.RollBackRollout(ctx, session, req.id)
+		if err != nil {
 			return nil, err
 		}
 
-		return stateRes{}, nil
+		return rolloutRes{Rollout: rollout}, nil
 	}
 }
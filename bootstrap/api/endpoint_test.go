@@ -22,6 +22,7 @@ import (
 	bsapi "github.com/absmach/magistrala/bootstrap/api"
 	"github.com/absmach/magistrala/bootstrap/mocks"
 	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/ratelimit"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
@@ -180,7 +181,7 @@ func newBootstrapServer() (*httptest.Server, *mocks.Service, *authnmocks.Authent
 	logger := smqlog.NewMock()
 	svc := new(mocks.Service)
 	authn := new(authnmocks.Authentication)
-	mux := bsapi.MakeHandler(svc, authn, bootstrap.NewConfigReader(encKey), logger, instanceID)
+	mux := bsapi.MakeHandler(svc, authn, ratelimit.Middleware(nil, ratelimit.Config{}, logger), bootstrap.NewConfigReader(encKey), logger, instanceID)
 	return httptest.NewServer(mux), svc, authn
 }
 
@@ -671,6 +672,119 @@ func TestUpdateCert(t *testing.T) {
 	}
 }
 
+func TestIssueCert(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+	c := newConfig()
+
+	cases := []struct {
+		desc            string
+		req             string
+		id              string
+		token           string
+		session         smqauthn.Session
+		contentType     string
+		status          int
+		authenticateErr error
+		err             error
+	}{
+		{
+			desc:            "issue cert with invalid token",
+			req:             `{"ttl":"24h"}`,
+			id:              c.ClientID,
+			token:           invalidToken,
+			contentType:     contentType,
+			status:          http.StatusUnauthorized,
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "issue cert with an empty token",
+			req:         `{"ttl":"24h"}`,
+			id:          c.ClientID,
+			token:       "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "issue cert for a valid config",
+			req:         `{"ttl":"24h"}`,
+			id:          c.ClientID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:   "issue cert for a valid config without request body",
+			id:     c.ClientID,
+			token:  validToken,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:        "issue cert with invalid TTL",
+			req:         `{"ttl":"-1h"}`,
+			id:          c.ClientID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "issue cert with wrong content type",
+			req:         `{"ttl":"24h"}`,
+			id:          c.ClientID,
+			token:       validToken,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "issue cert for a non-existing config",
+			req:         `{"ttl":"24h"}`,
+			id:          wrongID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:        "issue cert with invalid request format",
+			req:         "}",
+			id:          c.ClientID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("IssueCert", mock.Anything, mock.Anything, tc.token, tc.id, mock.Anything).Return(c, tc.err)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/clients/configs/certs/%s", bs.URL, domainID, tc.id),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func TestUpdateConnections(t *testing.T) {
 	bs, svc, auth := newBootstrapServer()
 	defer bs.Close()
@@ -832,8 +946,8 @@ func TestList(t *testing.T) {
 		if i%2 == 0 {
 			state = bootstrap.Inactive
 		}
-		svcCall := svc.On("ChangeState", context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-		err := svc.ChangeState(context.Background(), smqauthn.Session{}, validToken, list[i].ClientID, state)
+		svcCall := svc.On("ChangeState", context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]bootstrap.Connection{}, nil)
+		_, err := svc.ChangeState(context.Background(), smqauthn.Session{}, validToken, list[i].ClientID, state)
 		assert.Nil(t, err, fmt.Sprintf("Changing state expected to succeed: %s.\n", err))
 
 		svcCall.Unset()
@@ -1126,7 +1240,7 @@ func TestRemove(t *testing.T) {
 				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
 			}
 			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
-			svcCall := svc.On("Remove", mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+			svcCall := svc.On("Remove", mock.Anything, mock.Anything, tc.token, mock.Anything).Return(tc.err)
 			req := testRequest{
 				client: bs.Client(),
 				method: http.MethodDelete,
@@ -1375,7 +1489,7 @@ func TestChangeState(t *testing.T) {
 				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
 			}
 			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
-			svcCall := svc.On("ChangeState", mock.Anything, tc.session, tc.token, mock.Anything, mock.Anything).Return(tc.err)
+			svcCall := svc.On("ChangeState", mock.Anything, tc.session, tc.token, mock.Anything, mock.Anything).Return([]bootstrap.Connection{}, tc.err)
 			req := testRequest{
 				client:      bs.Client(),
 				method:      http.MethodPut,
@@ -1393,6 +1507,241 @@ func TestChangeState(t *testing.T) {
 	}
 }
 
+func TestCreateRollout(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	rollout := bootstrap.Rollout{
+		ID:         testsutil.GenerateUUID(t),
+		DomainID:   domainID,
+		Content:    addContent,
+		Percentage: 50,
+		State:      bootstrap.RolloutStaged,
+		Targets:    []bootstrap.RolloutTarget{{ClientID: addClientID, Staged: true, Applied: true, Version: 1}},
+	}
+	valid := toJSON(map[string]interface{}{"content": addContent, "percentage": 50})
+
+	cases := []struct {
+		desc            string
+		req             string
+		contentType     string
+		token           string
+		session         smqauthn.Session
+		status          int
+		location        string
+		authenticateErr error
+		svcErr          error
+	}{
+		{
+			desc:        "create rollout",
+			req:         valid,
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusCreated,
+			location:    "/clients/rollouts/" + rollout.ID,
+		},
+		{
+			desc:            "create rollout with invalid token",
+			req:             valid,
+			contentType:     contentType,
+			token:           invalidToken,
+			status:          http.StatusUnauthorized,
+			authenticateErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "create rollout with invalid content type",
+			req:         valid,
+			contentType: "",
+			token:       validToken,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create rollout with malformed body",
+			req:         "{",
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rollout with both percentage and cohort",
+			req:         toJSON(map[string]interface{}{"content": addContent, "percentage": 50, "cohort": []string{addClientID}}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rollout without percentage and cohort",
+			req:         toJSON(map[string]interface{}{"content": addContent}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rollout with invalid percentage",
+			req:         toJSON(map[string]interface{}{"content": addContent, "percentage": 101}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rollout with empty client ID",
+			req:         toJSON(map[string]interface{}{"content": addContent, "percentage": 50, "client_ids": []string{""}}),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rollout with failed service",
+			req:         valid,
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("CreateRollout", mock.Anything, tc.session, mock.Anything).Return(rollout, tc.svcErr)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/clients/rollouts/", bs.URL, domainID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			location := res.Header.Get("Location")
+			assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+			if tc.status == http.StatusCreated {
+				var body bootstrap.Rollout
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, rollout.Targets, body.Targets, fmt.Sprintf("%s: expected %v got %v", tc.desc, rollout.Targets, body.Targets))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRolloutActions(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	id := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc            string
+		method          string
+		svcMethod       string
+		path            string
+		token           string
+		session         smqauthn.Session
+		status          int
+		authenticateErr error
+		svcErr          error
+	}{
+		{
+			desc:      "view rollout",
+			method:    http.MethodGet,
+			svcMethod: "ViewRollout",
+			path:      id,
+			token:     validToken,
+			status:    http.StatusOK,
+		},
+		{
+			desc:      "view non-existing rollout",
+			method:    http.MethodGet,
+			svcMethod: "ViewRollout",
+			path:      id,
+			token:     validToken,
+			status:    http.StatusNotFound,
+			svcErr:    svcerr.ErrNotFound,
+		},
+		{
+			desc:            "view rollout with invalid token",
+			method:          http.MethodGet,
+			svcMethod:       "ViewRollout",
+			path:            id,
+			token:           invalidToken,
+			status:          http.StatusUnauthorized,
+			authenticateErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:      "promote rollout",
+			method:    http.MethodPost,
+			svcMethod: "PromoteRollout",
+			path:      id + "/promote",
+			token:     validToken,
+			status:    http.StatusOK,
+		},
+		{
+			desc:      "promote promoted rollout",
+			method:    http.MethodPost,
+			svcMethod: "PromoteRollout",
+			path:      id + "/promote",
+			token:     validToken,
+			status:    http.StatusConflict,
+			svcErr:    svcerr.ErrConflict,
+		},
+		{
+			desc:      "roll back rollout",
+			method:    http.MethodPost,
+			svcMethod: "RollBackRollout",
+			path:      id + "/rollback",
+			token:     validToken,
+			status:    http.StatusOK,
+		},
+		{
+			desc:      "roll back rolled back rollout",
+			method:    http.MethodPost,
+			svcMethod: "RollBackRollout",
+			path:      id + "/rollback",
+			token:     validToken,
+			status:    http.StatusConflict,
+			svcErr:    svcerr.ErrConflict,
+		},
+		{
+			desc:            "roll back rollout with invalid token",
+			method:          http.MethodPost,
+			svcMethod:       "RollBackRollout",
+			path:            id + "/rollback",
+			token:           invalidToken,
+			status:          http.StatusUnauthorized,
+			authenticateErr: svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On(tc.svcMethod, mock.Anything, tc.session, id).Return(bootstrap.Rollout{ID: id}, tc.svcErr)
+			req := testRequest{
+				client: bs.Client(),
+				method: tc.method,
+				url:    fmt.Sprintf("%s/%s/clients/rollouts/%s", bs.URL, domainID, tc.path),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 type channel struct {
 	ID       string      `json:"id"`
 	Name     string      `json:"name,omitempty"`
@@ -4,23 +4,61 @@
 package api
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"time"
+
 	"github.com/absmach/magistrala/bootstrap"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
 )
 
 const maxLimitSize = 100
 
+var (
+	errBundleKey      = errors.New("bundle key must be hex encoded 16, 24 or 32 bytes long AES key")
+	errBundleStrategy = errors.New("conflict strategy must be either skip or merge")
+	errCertTTL        = errors.New("certificate TTL must be a positive duration")
+	errRolloutStage   = errors.New("rollout must have either percentage between 1 and 100 or cohort")
+)
+
 type addReq struct {
 	token       string
-	ClientID    string   `json:"client_id"`
-	ExternalID  string   `json:"external_id"`
-	ExternalKey string   `json:"external_key"`
-	Channels    []string `json:"channels"`
-	Name        string   `json:"name"`
-	Content     string   `json:"content"`
-	ClientCert  string   `json:"client_cert"`
-	ClientKey   string   `json:"client_key"`
-	CACert      string   `json:"ca_cert"`
+	ClientID    string       `json:"client_id"`
+	ExternalID  string       `json:"external_id"`
+	ExternalKey string       `json:"external_key"`
+	Channels    []channelReq `json:"channels"`
+	Name        string       `json:"name"`
+	Content     string       `json:"content"`
+	ClientCert  string       `json:"client_cert"`
+	ClientKey   string       `json:"client_key"`
+	CACert      string       `json:"ca_cert"`
+}
+
+// channelReq is a Config Channel, given either as the Channel ID or as the
+// object with the Channel ID and role. Channels are connected in the order
+// they are listed in.
+type channelReq struct {
+	ID   string `json:"id"`
+	Role string `json:"role,omitempty"`
+}
+
+func (req *channelReq) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		req.ID = id
+		return nil
+	}
+
+	type channel channelReq
+	var ch channel
+	if err := json.Unmarshal(data, &ch); err != nil {
+		return err
+	}
+	*req = channelReq(ch)
+
+	return nil
 }
 
 func (req addReq) validate() error {
@@ -41,7 +79,7 @@ func (req addReq) validate() error {
 	}
 
 	for _, channel := range req.Channels {
-		if channel == "" {
+		if channel.ID == "" {
 			return apiutil.ErrMissingID
 		}
 	}
@@ -61,6 +99,23 @@ func (req entityReq) validate() error {
 	return nil
 }
 
+type removeReq struct {
+	token string
+	id    string
+}
+
+func (req removeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type updateReq struct {
 	id      string
 	Name    string `json:"name"`
@@ -90,6 +145,31 @@ func (req updateCertReq) validate() error {
 	return nil
 }
 
+type issueCertReq struct {
+	token    string
+	clientID string
+	TTL      string `json:"ttl,omitempty"`
+}
+
+func (req issueCertReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.clientID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.TTL != "" {
+		ttl, err := time.ParseDuration(req.TTL)
+		if err != nil || ttl <= 0 {
+			return errors.Wrap(errors.ErrMalformedEntity, errCertTTL)
+		}
+	}
+
+	return nil
+}
+
 type updateConnReq struct {
 	token    string
 	id       string
@@ -161,3 +241,106 @@ func (req changeStateReq) validate() error {
 
 	return nil
 }
+
+type exportReq struct {
+	filter bootstrap.Filter
+	Key    string `json:"key"`
+}
+
+func (req exportReq) validate() error {
+	if req.Key == "" {
+		return errBundleKey
+	}
+
+	return validateBundleKey(req.Key)
+}
+
+type importReq struct {
+	token    string
+	Key      string                     `json:"key"`
+	Strategy bootstrap.ConflictStrategy `json:"strategy"`
+	Bundle   bootstrap.Bundle           `json:"bundle"`
+}
+
+func (req importReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if err := validateBundleKey(req.Key); err != nil {
+		return err
+	}
+
+	if req.Strategy != bootstrap.SkipConflicts && req.Strategy != bootstrap.MergeConflicts {
+		return errBundleStrategy
+	}
+
+	if len(req.Bundle.Configs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type reconcileReq struct {
+	token  string
+	repair bool
+}
+
+func (req reconcileReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type createRolloutReq struct {
+	ClientIDs  []string `json:"client_ids"`
+	Percentage uint     `json:"percentage"`
+	Cohort     []string `json:"cohort"`
+	Content    string   `json:"content"`
+}
+
+func (req createRolloutReq) validate() error {
+	if (req.Percentage == 0) == (len(req.Cohort) == 0) || req.Percentage > 100 {
+		return errors.Wrap(errors.ErrMalformedEntity, errRolloutStage)
+	}
+
+	for _, id := range append(slices.Clone(req.ClientIDs), req.Cohort...) {
+		if id == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
+type rolloutReq struct {
+	id string
+}
+
+func (req rolloutReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+func validateBundleKey(key string) error {
+	if key == "" {
+		return nil
+	}
+
+	k, err := hex.DecodeString(key)
+	if err != nil {
+		return errBundleKey
+	}
+	switch len(k) {
+	case 16, 24, 32:
+		return nil
+	default:
+		return errBundleKey
+	}
+}
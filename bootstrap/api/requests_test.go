@@ -4,12 +4,14 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/absmach/magistrala/bootstrap"
 	"github.com/absmach/magistrala/internal/testsutil"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -86,11 +88,15 @@ func TestAddReqValidation(t *testing.T) {
 	}
 
 	for _, tc := range cases {
+		var channels []channelReq
+		for _, ch := range tc.channels {
+			channels = append(channels, channelReq{ID: ch})
+		}
 		req := addReq{
 			token:       tc.token,
 			ExternalID:  tc.externalID,
 			ExternalKey: tc.externalKey,
-			Channels:    tc.channels,
+			Channels:    channels,
 		}
 
 		err := req.validate()
@@ -98,6 +104,45 @@ func TestAddReqValidation(t *testing.T) {
 	}
 }
 
+func TestChannelReqUnmarshal(t *testing.T) {
+	cases := []struct {
+		desc     string
+		data     string
+		channels []channelReq
+		err      bool
+	}{
+		{
+			desc:     "unmarshal channel IDs",
+			data:     fmt.Sprintf(`["%s", "%s"]`, channel1, channel2),
+			channels: []channelReq{{ID: channel1}, {ID: channel2}},
+		},
+		{
+			desc:     "unmarshal channels with roles",
+			data:     fmt.Sprintf(`[{"id": "%s", "role": "control"}, {"id": "%s", "role": "data"}]`, channel1, channel2),
+			channels: []channelReq{{ID: channel1, Role: "control"}, {ID: channel2, Role: "data"}},
+		},
+		{
+			desc:     "unmarshal mixed channels",
+			data:     fmt.Sprintf(`[{"id": "%s", "role": "control"}, "%s"]`, channel1, channel2),
+			channels: []channelReq{{ID: channel1, Role: "control"}, {ID: channel2}},
+		},
+		{
+			desc: "unmarshal invalid channel",
+			data: `[1]`,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		var channels []channelReq
+		err := json.Unmarshal([]byte(tc.data), &channels)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		if !tc.err {
+			assert.Equal(t, tc.channels, channels, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.channels, channels))
+		}
+	}
+}
+
 func TestEntityReqValidation(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -172,6 +217,67 @@ func TestUpdateCertReqValidation(t *testing.T) {
 	}
 }
 
+func TestIssueCertReqValidation(t *testing.T) {
+	cases := []struct {
+		desc     string
+		token    string
+		clientID string
+		ttl      string
+		err      error
+	}{
+		{
+			desc:     "valid request",
+			token:    "token",
+			clientID: "id",
+			ttl:      "24h",
+			err:      nil,
+		},
+		{
+			desc:     "valid request without TTL",
+			token:    "token",
+			clientID: "id",
+			err:      nil,
+		},
+		{
+			desc:     "empty token",
+			token:    "",
+			clientID: "id",
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "empty client id",
+			token:    "token",
+			clientID: "",
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:     "invalid TTL",
+			token:    "token",
+			clientID: "id",
+			ttl:      "day",
+			err:      errCertTTL,
+		},
+		{
+			desc:     "negative TTL",
+			token:    "token",
+			clientID: "id",
+			ttl:      "-24h",
+			err:      errCertTTL,
+		},
+	}
+
+	for _, tc := range cases {
+		req := issueCertReq{
+			token:    tc.token,
+			clientID: tc.clientID,
+			TTL:      tc.ttl,
+		}
+
+		err := req.validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestUpdateConnReqValidation(t *testing.T) {
 	cases := []struct {
 		desc  string
@@ -311,3 +417,219 @@ func TestChangeStateReqValidation(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestExportReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		key  string
+		err  error
+	}{
+		{
+			desc: "empty key",
+			key:  "",
+			err:  errBundleKey,
+		},
+		{
+			desc: "valid key",
+			key:  "0123456789abcdef0123456789abcdef",
+			err:  nil,
+		},
+		{
+			desc: "key which is not hex encoded",
+			key:  "invalid",
+			err:  errBundleKey,
+		},
+		{
+			desc: "key of invalid length",
+			key:  "0123456789abcdef",
+			err:  errBundleKey,
+		},
+	}
+
+	for _, tc := range cases {
+		req := exportReq{
+			Key: tc.key,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestImportReqValidation(t *testing.T) {
+	bundle := bootstrap.Bundle{
+		Version: bootstrap.BundleVersion,
+		Configs: []bootstrap.Config{{ClientID: "id", ExternalID: "external-id"}},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		key      string
+		strategy bootstrap.ConflictStrategy
+		bundle   bootstrap.Bundle
+		err      error
+	}{
+		{
+			desc:     "valid request",
+			token:    "token",
+			strategy: bootstrap.MergeConflicts,
+			bundle:   bundle,
+			err:      nil,
+		},
+		{
+			desc:     "empty token",
+			strategy: bootstrap.SkipConflicts,
+			bundle:   bundle,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "invalid key",
+			token:    "token",
+			key:      "invalid",
+			strategy: bootstrap.SkipConflicts,
+			bundle:   bundle,
+			err:      errBundleKey,
+		},
+		{
+			desc:     "invalid strategy",
+			token:    "token",
+			strategy: bootstrap.ConflictStrategy("overwrite"),
+			bundle:   bundle,
+			err:      errBundleStrategy,
+		},
+		{
+			desc:     "empty configs",
+			token:    "token",
+			strategy: bootstrap.SkipConflicts,
+			bundle:   bootstrap.Bundle{Version: bootstrap.BundleVersion},
+			err:      apiutil.ErrEmptyList,
+		},
+	}
+
+	for _, tc := range cases {
+		req := importReq{
+			token:    tc.token,
+			Key:      tc.key,
+			Strategy: tc.strategy,
+			Bundle:   tc.bundle,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestReconcileReqValidation(t *testing.T) {
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "valid request",
+			token: "token",
+			err:   nil,
+		},
+		{
+			desc:  "empty token",
+			token: "",
+			err:   apiutil.ErrBearerToken,
+		},
+	}
+
+	for _, tc := range cases {
+		req := reconcileReq{
+			token:  tc.token,
+			repair: true,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCreateRolloutReqValidation(t *testing.T) {
+	cases := []struct {
+		desc       string
+		clientIDs  []string
+		percentage uint
+		cohort     []string
+		err        error
+	}{
+		{
+			desc:       "valid request with percentage",
+			percentage: 10,
+			err:        nil,
+		},
+		{
+			desc:      "valid request with cohort",
+			clientIDs: []string{"id1", "id2"},
+			cohort:    []string{"id1"},
+			err:       nil,
+		},
+		{
+			desc:       "percentage and cohort",
+			percentage: 10,
+			cohort:     []string{"id1"},
+			err:        errRolloutStage,
+		},
+		{
+			desc: "no percentage and cohort",
+			err:  errRolloutStage,
+		},
+		{
+			desc:       "invalid percentage",
+			percentage: 101,
+			err:        errRolloutStage,
+		},
+		{
+			desc:       "empty client id",
+			clientIDs:  []string{""},
+			percentage: 10,
+			err:        apiutil.ErrMissingID,
+		},
+		{
+			desc:   "empty cohort client id",
+			cohort: []string{""},
+			err:    apiutil.ErrMissingID,
+		},
+	}
+
+	for _, tc := range cases {
+		req := createRolloutReq{
+			ClientIDs:  tc.clientIDs,
+			Percentage: tc.percentage,
+			Cohort:     tc.cohort,
+		}
+
+		err := req.validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRolloutReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "valid request",
+			id:   "id",
+			err:  nil,
+		},
+		{
+			desc: "empty id",
+			id:   "",
+			err:  apiutil.ErrMissingID,
+		},
+	}
+
+	for _, tc := range cases {
+		req := rolloutReq{id: tc.id}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
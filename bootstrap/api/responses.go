@@ -17,6 +17,10 @@ var (
 	_ supermq.Response = (*stateRes)(nil)
 	_ supermq.Response = (*viewRes)(nil)
 	_ supermq.Response = (*listRes)(nil)
+	_ supermq.Response = (*exportRes)(nil)
+	_ supermq.Response = (*importRes)(nil)
+	_ supermq.Response = (*reconcileRes)(nil)
+	_ supermq.Response = (*rolloutRes)(nil)
 )
 
 type removeRes struct{}
@@ -64,19 +68,22 @@ type channelRes struct {
 	ID       string      `json:"id"`
 	Name     string      `json:"name,omitempty"`
 	Metadata interface{} `json:"metadata,omitempty"`
+	Role     string      `json:"role,omitempty"`
 }
 
 type viewRes struct {
-	ClientID     string          `json:"client_id,omitempty"`
-	CLientSecret string          `json:"client_secret,omitempty"`
-	Channels     []channelRes    `json:"channels,omitempty"`
-	ExternalID   string          `json:"external_id"`
-	ExternalKey  string          `json:"external_key,omitempty"`
-	Content      string          `json:"content,omitempty"`
-	Name         string          `json:"name,omitempty"`
-	State        bootstrap.State `json:"state"`
-	ClientCert   string          `json:"client_cert,omitempty"`
-	CACert       string          `json:"ca_cert,omitempty"`
+	ClientID       string          `json:"client_id,omitempty"`
+	CLientSecret   string          `json:"client_secret,omitempty"`
+	Channels       []channelRes    `json:"channels,omitempty"`
+	ExternalID     string          `json:"external_id"`
+	ExternalKey    string          `json:"external_key,omitempty"`
+	Content        string          `json:"content,omitempty"`
+	Name           string          `json:"name,omitempty"`
+	State          bootstrap.State `json:"state"`
+	ClientCert     string          `json:"client_cert,omitempty"`
+	CACert         string          `json:"ca_cert,omitempty"`
+	Version        uint64          `json:"version"`
+	FetchedVersion uint64          `json:"fetched_version"`
 }
 
 func (res viewRes) Code() int {
@@ -110,7 +117,9 @@ func (res listRes) Empty() bool {
 	return false
 }
 
-type stateRes struct{}
+type stateRes struct {
+	Connections []bootstrap.Connection `json:"connections"`
+}
 
 func (res stateRes) Code() int {
 	return http.StatusOK
@@ -121,7 +130,7 @@ func (res stateRes) Headers() map[string]string {
 }
 
 func (res stateRes) Empty() bool {
-	return true
+	return false
 }
 
 type updateConfigRes struct {
@@ -142,3 +151,78 @@ func (res updateConfigRes) Headers() map[string]string {
 func (res updateConfigRes) Empty() bool {
 	return false
 }
+
+type exportRes struct {
+	bootstrap.Bundle
+}
+
+func (res exportRes) Code() int {
+	return http.StatusOK
+}
+
+func (res exportRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res exportRes) Empty() bool {
+	return false
+}
+
+type importRes struct {
+	bootstrap.ImportSummary
+}
+
+func (res importRes) Code() int {
+	return http.StatusOK
+}
+
+func (res importRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res importRes) Empty() bool {
+	return false
+}
+
+type reconcileRes struct {
+	bootstrap.ReconcileReport
+}
+
+func (res reconcileRes) Code() int {
+	return http.StatusOK
+}
+
+func (res reconcileRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res reconcileRes) Empty() bool {
+	return false
+}
+
+type rolloutRes struct {
+	bootstrap.Rollout
+	created bool
+}
+
+func (res rolloutRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res rolloutRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/clients/rollouts/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res rolloutRes) Empty() bool {
+	return false
+}
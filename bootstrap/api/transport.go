@@ -28,6 +28,7 @@ const (
 	byteContentType = "application/octet-stream"
 	offsetKey       = "offset"
 	limitKey        = "limit"
+	repairKey       = "repair"
 	defOffset       = 0
 	defLimit        = 10
 )
@@ -39,8 +40,9 @@ var (
 	ErrBootstrap = errors.New("failed to read bootstrap configuration")
 )
 
-// MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc bootstrap.Service, authn smqauthn.Authentication, reader bootstrap.ConfigReader, logger *slog.Logger, instanceID string) http.Handler {
+// MakeHandler returns a HTTP handler for API endpoints. Requests of the
+// authenticated users are passed through the rate limit middleware.
+func MakeHandler(svc bootstrap.Service, authn smqauthn.Authentication, rateLimit func(http.Handler) http.Handler, reader bootstrap.ConfigReader, logger *slog.Logger, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
 	}
@@ -50,6 +52,7 @@ func MakeHandler(svc bootstrap.Service, authn smqauthn.Authentication, reader bo
 	r.Route("/{domainID}/clients", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
 			r.Use(api.AuthenticateMiddleware(authn, true))
+			r.Use(rateLimit)
 
 			r.Route("/configs", func(r chi.Router) {
 				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
@@ -64,6 +67,24 @@ func MakeHandler(svc bootstrap.Service, authn smqauthn.Authentication, reader bo
 					api.EncodeResponse,
 					opts...), "list").ServeHTTP)
 
+				r.Post("/export", otelhttp.NewHandler(kithttp.NewServer(
+					exportEndpoint(svc),
+					decodeExportRequest,
+					api.EncodeResponse,
+					opts...), "export_configs").ServeHTTP)
+
+				r.Post("/import", otelhttp.NewHandler(kithttp.NewServer(
+					importEndpoint(svc),
+					decodeImportRequest,
+					api.EncodeResponse,
+					opts...), "import_configs").ServeHTTP)
+
+				r.Post("/reconcile", otelhttp.NewHandler(kithttp.NewServer(
+					reconcileEndpoint(svc),
+					decodeReconcileRequest,
+					api.EncodeResponse,
+					opts...), "reconcile_configs").ServeHTTP)
+
 				r.Get("/{configID}", otelhttp.NewHandler(kithttp.NewServer(
 					viewEndpoint(svc),
 					decodeEntityRequest,
@@ -78,7 +99,7 @@ func MakeHandler(svc bootstrap.Service, authn smqauthn.Authentication, reader bo
 
 				r.Delete("/{configID}", otelhttp.NewHandler(kithttp.NewServer(
 					removeEndpoint(svc),
-					decodeEntityRequest,
+					decodeRemoveRequest,
 					api.EncodeResponse,
 					opts...), "remove").ServeHTTP)
 
@@ -88,15 +109,47 @@ func MakeHandler(svc bootstrap.Service, authn smqauthn.Authentication, reader bo
 					api.EncodeResponse,
 					opts...), "update_cert").ServeHTTP)
 
+				r.Post("/certs/{certID}", otelhttp.NewHandler(kithttp.NewServer(
+					issueCertEndpoint(svc),
+					decodeIssueCertRequest,
+					api.EncodeResponse,
+					opts...), "issue_cert").ServeHTTP)
+
 				r.Put("/connections/{connID}", otelhttp.NewHandler(kithttp.NewServer(
 					updateConnEndpoint(svc),
 					decodeUpdateConnRequest,
 					api.EncodeResponse,
 					opts...), "update_connections").ServeHTTP)
 			})
+
+			r.Route("/rollouts", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					createRolloutEndpoint(svc),
+					decodeCreateRolloutRequest,
+					api.EncodeResponse,
+					opts...), "create_rollout").ServeHTTP)
+
+				r.Get("/{rolloutID}", otelhttp.NewHandler(kithttp.NewServer(
+					viewRolloutEndpoint(svc),
+					decodeRolloutRequest,
+					api.EncodeResponse,
+					opts...), "view_rollout").ServeHTTP)
+
+				r.Post("/{rolloutID}/promote", otelhttp.NewHandler(kithttp.NewServer(
+					promoteRolloutEndpoint(svc),
+					decodeRolloutRequest,
+					api.EncodeResponse,
+					opts...), "promote_rollout").ServeHTTP)
+
+				r.Post("/{rolloutID}/rollback", otelhttp.NewHandler(kithttp.NewServer(
+					rollBackRolloutEndpoint(svc),
+					decodeRolloutRequest,
+					api.EncodeResponse,
+					opts...), "roll_back_rollout").ServeHTTP)
+			})
 		})
 
-		r.With(api.AuthenticateMiddleware(authn, true)).Put("/state/{clientID}", otelhttp.NewHandler(kithttp.NewServer(
+		r.With(api.AuthenticateMiddleware(authn, true), rateLimit).Put("/state/{clientID}", otelhttp.NewHandler(kithttp.NewServer(
 			stateEndpoint(svc),
 			decodeStateRequest,
 			api.EncodeResponse,
@@ -172,6 +225,26 @@ func decodeUpdateCertRequest(_ context.Context, r *http.Request) (interface{}, e
 	return req, nil
 }
 
+func decodeIssueCertRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := issueCertReq{
+		token:    apiutil.ExtractBearerToken(r),
+		clientID: chi.URLParam(r, "certID"),
+	}
+	if r.ContentLength == 0 {
+		return req, nil
+	}
+
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodeUpdateConnRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -213,6 +286,77 @@ func decodeListRequest(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeExportRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	q, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidQueryParams)
+	}
+
+	req := exportReq{
+		filter: parseFilter(q),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeImportRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := importReq{
+		token:    apiutil.ExtractBearerToken(r),
+		Strategy: bootstrap.SkipConflicts,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeReconcileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	repair, err := apiutil.ReadBoolQuery(r, repairKey, false)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := reconcileReq{
+		token:  apiutil.ExtractBearerToken(r),
+		repair: repair,
+	}
+
+	return req, nil
+}
+
+func decodeCreateRolloutRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req createRolloutReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeRolloutRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := rolloutReq{
+		id: chi.URLParam(r, "rolloutID"),
+	}
+
+	return req, nil
+}
+
 func decodeBootstrapRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := bootstrapReq{
 		id:  chi.URLParam(r, "externalID"),
@@ -238,6 +382,15 @@ func decodeStateRequest(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeRemoveRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := removeReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "configID"),
+	}
+
+	return req, nil
+}
+
 func decodeEntityRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := entityReq{
 		id: chi.URLParam(r, "configID"),
@@ -0,0 +1,37 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import "time"
+
+// BundleVersion is the current version of the Configs bundle format.
+const BundleVersion = 1
+
+// Bundle represents a portable, versioned set of Configs used to back up
+// and migrate bootstrap deployments. If Encrypted is set, Config secrets
+// (client secret, client key and external key) are encrypted with the
+// key provided on export and must be decrypted with the same key on import.
+type Bundle struct {
+	Version   int       `json:"version"`
+	Encrypted bool      `json:"encrypted"`
+	CreatedAt time.Time `json:"created_at"`
+	Configs   []Config  `json:"configs"`
+}
+
+// ConflictStrategy specifies how imported Configs that already exist are handled.
+type ConflictStrategy string
+
+const (
+	// SkipConflicts keeps the existing Config and skips the imported one.
+	SkipConflicts ConflictStrategy = "skip"
+	// MergeConflicts updates the existing Config with the imported one.
+	MergeConflicts ConflictStrategy = "merge"
+)
+
+// ImportSummary contains the IDs of the imported Configs grouped by the import outcome.
+type ImportSummary struct {
+	Created []string `json:"created"`
+	Merged  []string `json:"merged"`
+	Skipped []string `json:"skipped"`
+}
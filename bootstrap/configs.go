@@ -14,20 +14,25 @@ import (
 // as well as info about corresponding SuperMQ entities.
 // MGClient represents corresponding SuperMQ Client ID.
 // MGKey is key of corresponding SuperMQ Client.
-// MGChannels is a list of SuperMQ Channels corresponding SuperMQ Client connects to.
+// MGChannels is a list of SuperMQ Channels corresponding SuperMQ Client connects to,
+// in the order the connections are established.
+// Version is incremented on each change of the Config content, and FetchedVersion
+// is the last version the Client fetched.
 type Config struct {
-	ClientID     string    `json:"client_id"`
-	ClientSecret string    `json:"client_secret"`
-	DomainID     string    `json:"domain_id,omitempty"`
-	Name         string    `json:"name,omitempty"`
-	ClientCert   string    `json:"client_cert,omitempty"`
-	ClientKey    string    `json:"client_key,omitempty"`
-	CACert       string    `json:"ca_cert,omitempty"`
-	Channels     []Channel `json:"channels,omitempty"`
-	ExternalID   string    `json:"external_id"`
-	ExternalKey  string    `json:"external_key"`
-	Content      string    `json:"content,omitempty"`
-	State        State     `json:"state"`
+	ClientID       string    `json:"client_id"`
+	ClientSecret   string    `json:"client_secret"`
+	DomainID       string    `json:"domain_id,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	ClientCert     string    `json:"client_cert,omitempty"`
+	ClientKey      string    `json:"client_key,omitempty"`
+	CACert         string    `json:"ca_cert,omitempty"`
+	Channels       []Channel `json:"channels,omitempty"`
+	ExternalID     string    `json:"external_id"`
+	ExternalKey    string    `json:"external_key"`
+	Content        string    `json:"content,omitempty"`
+	State          State     `json:"state"`
+	Version        uint64    `json:"version"`
+	FetchedVersion uint64    `json:"fetched_version"`
 }
 
 // Channel represents SuperMQ channel corresponding SuperMQ Client is connected to.
@@ -42,6 +47,24 @@ type Channel struct {
 	UpdatedAt   time.Time              `json:"updated_at,omitempty"`
 	UpdatedBy   string                 `json:"updated_by,omitempty"`
 	Status      clients.Status         `json:"status"`
+	Role        string                 `json:"role,omitempty"`
+}
+
+// Connection represents the connection of the Config Client to the Channel
+// having the given role in the Config, e.g. "control" or "data".
+type Connection struct {
+	ChannelID string `json:"channel_id"`
+	Role      string `json:"role,omitempty"`
+	Connected bool   `json:"connected"`
+}
+
+// ImportedConfig represents the Config restored from the bundle together
+// with its connections.
+type ImportedConfig struct {
+	Config      Config
+	Connections []Connection
+	// Merge indicates that the Config replaces the existing one.
+	Merge bool
 }
 
 // Filter is used for the search filters.
@@ -63,9 +86,10 @@ type ConfigsPage struct {
 //
 //go:generate mockery --name ConfigRepository --output=./mocks --filename configs.go --quiet --note "Copyright (c) Abstract Machines"
 type ConfigRepository interface {
-	// Save persists the Config. Successful operation is indicated by non-nil
-	// error response.
-	Save(ctx context.Context, cfg Config, chsConnIDs []string) (string, error)
+	// Save persists the Config and its connections, keeping the order and
+	// the roles of the connected Channels. Successful operation is
+	// indicated by non-nil error response.
+	Save(ctx context.Context, cfg Config, connections []Connection) (string, error)
 
 	// RetrieveByID retrieves the Config having the provided identifier, that is owned
 	// by the specified user.
@@ -87,8 +111,15 @@ type ConfigRepository interface {
 	UpdateCert(ctx context.Context, domainID, clientID, clientCert, clientKey, caCert string) (Config, error)
 
 	// UpdateConnections updates a list of Channels the Config is connected to
-	// adding new Channels if needed.
-	UpdateConnections(ctx context.Context, domainID, id string, channels []Channel, connections []string) error
+	// adding new Channels if needed. Connections are kept in the given order.
+	UpdateConnections(ctx context.Context, domainID, id string, channels []Channel, connections []Connection) error
+
+	// Import saves the new Configs and merges those marked to replace the
+	// existing ones in a single transaction, so a failed import leaves no
+	// changes behind. Channels which are already stored are kept. It returns
+	// the IDs of the new Configs which were not saved since they conflict
+	// with the existing ones, e.g. by external ID.
+	Import(ctx context.Context, configs []ImportedConfig) ([]string, error)
 
 	// Remove removes the Config having the provided identifier, that is owned
 	// by the specified user.
@@ -97,6 +128,9 @@ type ConfigRepository interface {
 	// ChangeState changes of the Config, that is owned by the specific user.
 	ChangeState(ctx context.Context, domainID, id string, state State) error
 
+	// UpdateFetchedVersion records the Config version fetched by the Client.
+	UpdateFetchedVersion(ctx context.Context, domainID, id string, version uint64) error
+
 	// ListExisting retrieves those channels from the given list that exist in DB.
 	ListExisting(ctx context.Context, domainID string, ids []string) ([]Channel, error)
 
@@ -16,6 +16,9 @@ const (
 	configView          = configPrefix + "view"
 	configList          = configPrefix + "list"
 	configHandlerRemove = configPrefix + "remove_handler"
+	configExport        = configPrefix + "export"
+	configImport        = configPrefix + "import"
+	configReconcile     = configPrefix + "reconcile"
 
 	clientPrefix            = "bootstrap.client."
 	clientBootstrap         = clientPrefix + "bootstrap"
@@ -29,6 +32,13 @@ const (
 	channelUpdateHandler = channelPrefix + "update_handler"
 
 	certUpdate = "bootstrap.cert.update"
+	certIssue  = "bootstrap.cert.issue"
+
+	rolloutPrefix   = "bootstrap.rollout."
+	rolloutCreate   = rolloutPrefix + "create"
+	rolloutView     = rolloutPrefix + "view"
+	rolloutPromote  = rolloutPrefix + "promote"
+	rolloutRollBack = rolloutPrefix + "roll_back"
 )
 
 var (
@@ -38,8 +48,13 @@ var (
 	_ events.Event = (*changeStateEvent)(nil)
 	_ events.Event = (*updateConnectionsEvent)(nil)
 	_ events.Event = (*updateCertEvent)(nil)
+	_ events.Event = (*issueCertEvent)(nil)
 	_ events.Event = (*listConfigsEvent)(nil)
 	_ events.Event = (*removeHandlerEvent)(nil)
+	_ events.Event = (*exportConfigsEvent)(nil)
+	_ events.Event = (*importConfigsEvent)(nil)
+	_ events.Event = (*reconcileEvent)(nil)
+	_ events.Event = (*rolloutEvent)(nil)
 )
 
 type configEvent struct {
@@ -124,6 +139,61 @@ func (rce listConfigsEvent) Encode() (map[string]interface{}, error) {
 	return val, nil
 }
 
+type exportConfigsEvent struct {
+	encrypted bool
+	configs   int
+}
+
+func (ece exportConfigsEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"encrypted": ece.encrypted,
+		"configs":   ece.configs,
+		"operation": configExport,
+	}, nil
+}
+
+type importConfigsEvent struct {
+	bootstrap.ImportSummary
+	strategy bootstrap.ConflictStrategy
+}
+
+func (ice importConfigsEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"strategy":  string(ice.strategy),
+		"created":   ice.Created,
+		"merged":    ice.Merged,
+		"skipped":   ice.Skipped,
+		"operation": configImport,
+	}, nil
+}
+
+type reconcileEvent struct {
+	bootstrap.ReconcileReport
+	repair bool
+}
+
+func (re reconcileEvent) Encode() (map[string]interface{}, error) {
+	drifts := make([]map[string]interface{}, len(re.Drifts))
+	for i, d := range re.Drifts {
+		drifts[i] = map[string]interface{}{
+			"type":      string(d.Type),
+			"client_id": d.ClientID,
+			"repaired":  d.Repaired,
+		}
+		if d.ChannelID != "" {
+			drifts[i]["channel_id"] = d.ChannelID
+		}
+	}
+
+	return map[string]interface{}{
+		"repair":     re.repair,
+		"checked":    re.Checked,
+		"drifts":     drifts,
+		"created_at": re.CreatedAt,
+		"operation":  configReconcile,
+	}, nil
+}
+
 type bootstrapEvent struct {
 	bootstrap.Config
 	externalID string
@@ -217,6 +287,19 @@ func (uce updateCertEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type issueCertEvent struct {
+	clientID string
+	ttl      string
+}
+
+func (ice issueCertEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"client_id": ice.clientID,
+		"ttl":       ice.ttl,
+		"operation": certIssue,
+	}, nil
+}
+
 type removeHandlerEvent struct {
 	id        string
 	operation string
@@ -275,3 +358,34 @@ func (dte disconnectClientEvent) Encode() (map[string]interface{}, error) {
 		"operation":  clientDisconnect,
 	}, nil
 }
+
+type rolloutEvent struct {
+	bootstrap.Rollout
+	operation string
+}
+
+func (re rolloutEvent) Encode() (map[string]interface{}, error) {
+	var staged, applied, fetched int
+	for _, t := range re.Targets {
+		if t.Staged {
+			staged++
+		}
+		if t.Applied {
+			applied++
+		}
+		if t.Fetched {
+			fetched++
+		}
+	}
+
+	return map[string]interface{}{
+		"id":        re.ID,
+		"domain_id": re.DomainID,
+		"state":     string(re.State),
+		"targets":   len(re.Targets),
+		"staged":    staged,
+		"applied":   applied,
+		"fetched":   fetched,
+		"operation": re.operation,
+	}, nil
+}
@@ -92,6 +92,24 @@ func (es eventStore) UpdateCert(ctx context.Context, session smqauthn.Session, c
 	return cfg, nil
 }
 
+func (es *eventStore) IssueCert(ctx context.Context, session smqauthn.Session, token, clientID, ttl string) (bootstrap.Config, error) {
+	cfg, err := es.svc.IssueCert(ctx, session, token, clientID, ttl)
+	if err != nil {
+		return cfg, err
+	}
+
+	ev := issueCertEvent{
+		clientID: clientID,
+		ttl:      ttl,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
+
 func (es *eventStore) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error {
 	if err := es.svc.UpdateConnections(ctx, session, token, id, connections); err != nil {
 		return err
@@ -125,8 +143,134 @@ func (es *eventStore) List(ctx context.Context, session smqauthn.Session, filter
 	return bp, nil
 }
 
-func (es *eventStore) Remove(ctx context.Context, session smqauthn.Session, id string) error {
-	if err := es.svc.Remove(ctx, session, id); err != nil {
+func (es *eventStore) ExportConfigs(ctx context.Context, session smqauthn.Session, filter bootstrap.Filter, key []byte) (bootstrap.Bundle, error) {
+	bundle, err := es.svc.ExportConfigs(ctx, session, filter, key)
+	if err != nil {
+		return bundle, err
+	}
+
+	ev := exportConfigsEvent{
+		encrypted: bundle.Encrypted,
+		configs:   len(bundle.Configs),
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return bundle, err
+	}
+
+	return bundle, nil
+}
+
+func (es *eventStore) ImportConfigs(ctx context.Context, session smqauthn.Session, token string, bundle bootstrap.Bundle, key []byte, strategy bootstrap.ConflictStrategy) (bootstrap.ImportSummary, error) {
+	summary, err := es.svc.ImportConfigs(ctx, session, token, bundle, key, strategy)
+	if err != nil {
+		return summary, err
+	}
+
+	ev := importConfigsEvent{
+		ImportSummary: summary,
+		strategy:      strategy,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return summary, err
+	}
+
+	return summary, nil
+}
+
+func (es *eventStore) Reconcile(ctx context.Context, session smqauthn.Session, token string, repair bool) (bootstrap.ReconcileReport, error) {
+	report, err := es.svc.Reconcile(ctx, session, token, repair)
+	if err != nil {
+		return report, err
+	}
+
+	ev := reconcileEvent{
+		ReconcileReport: report,
+		repair:          repair,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+func (es *eventStore) CreateRollout(ctx context.Context, session smqauthn.Session, r bootstrap.Rollout) (bootstrap.Rollout, error) {
+	rollout, err := es.svc.CreateRollout(ctx, session, r)
+	if err != nil {
+		return rollout, err
+	}
+
+	ev := rolloutEvent{
+		Rollout:   rollout,
+		operation: rolloutCreate,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return rollout, err
+	}
+
+	return rollout, nil
+}
+
+func (es *eventStore) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	rollout, err := es.svc.ViewRollout(ctx, session, id)
+	if err != nil {
+		return rollout, err
+	}
+
+	ev := rolloutEvent{
+		Rollout:   rollout,
+		operation: rolloutView,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return rollout, err
+	}
+
+	return rollout, nil
+}
+
+func (es *eventStore) PromoteRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	rollout, err := es.svc.PromoteRollout(ctx, session, id)
+	if err != nil {
+		return rollout, err
+	}
+
+	ev := rolloutEvent{
+		Rollout:   rollout,
+		operation: rolloutPromote,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return rollout, err
+	}
+
+	return rollout, nil
+}
+
+func (es *eventStore) RollBackRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	rollout, err := es.svc.RollBackRollout(ctx, session, id)
+	if err != nil {
+		return rollout, err
+	}
+
+	ev := rolloutEvent{
+		Rollout:   rollout,
+		operation: rolloutRollBack,
+	}
+
+	if err := es.Publish(ctx, ev); err != nil {
+		return rollout, err
+	}
+
+	return rollout, nil
+}
+
+func (es *eventStore) Remove(ctx context.Context, session smqauthn.Session, token, id string) error {
+	if err := es.svc.Remove(ctx, session, token, id); err != nil {
 		return err
 	}
 
@@ -157,9 +301,10 @@ func (es *eventStore) Bootstrap(ctx context.Context, externalKey, externalID str
 	return cfg, err
 }
 
-func (es *eventStore) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) error {
-	if err := es.svc.ChangeState(ctx, session, token, id, state); err != nil {
-		return err
+func (es *eventStore) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) ([]bootstrap.Connection, error) {
+	conns, err := es.svc.ChangeState(ctx, session, token, id, state)
+	if err != nil {
+		return conns, err
 	}
 
 	ev := changeStateEvent{
@@ -167,7 +312,11 @@ func (es *eventStore) ChangeState(ctx context.Context, session smqauthn.Session,
 		state:    state,
 	}
 
-	return es.Publish(ctx, ev)
+	if err := es.Publish(ctx, ev); err != nil {
+		return conns, err
+	}
+
+	return conns, nil
 }
 
 func (es *eventStore) RemoveConfigHandler(ctx context.Context, id string) error {
@@ -60,6 +60,14 @@ func (am *authorizationMiddleware) UpdateCert(ctx context.Context, session smqau
 	return am.svc.UpdateCert(ctx, session, clientID, clientCert, clientKey, caCert)
 }
 
+func (am *authorizationMiddleware) IssueCert(ctx context.Context, session smqauthn.Session, token, clientID, ttl string) (bootstrap.Config, error) {
+	if err := am.authorize(ctx, session.DomainID, policies.UserType, policies.UsersKind, session.DomainUserID, policies.EditPermission, policies.ClientType, clientID); err != nil {
+		return bootstrap.Config{}, err
+	}
+
+	return am.svc.IssueCert(ctx, session, token, clientID, ttl)
+}
+
 func (am *authorizationMiddleware) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error {
 	if err := am.authorize(ctx, session.DomainID, policies.UserType, policies.UsersKind, session.DomainUserID, policies.EditPermission, policies.ClientType, id); err != nil {
 		return err
@@ -79,19 +87,95 @@ func (am *authorizationMiddleware) List(ctx context.Context, session smqauthn.Se
 	return am.svc.List(ctx, session, filter, offset, limit)
 }
 
-func (am *authorizationMiddleware) Remove(ctx context.Context, session smqauthn.Session, id string) error {
+func (am *authorizationMiddleware) ExportConfigs(ctx context.Context, session smqauthn.Session, filter bootstrap.Filter, key []byte) (bootstrap.Bundle, error) {
+	if err := am.checkSuperAdmin(ctx, session.DomainUserID); err == nil {
+		session.SuperAdmin = true
+	}
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err == nil {
+		session.SuperAdmin = true
+	}
+
+	return am.svc.ExportConfigs(ctx, session, filter, key)
+}
+
+func (am *authorizationMiddleware) ImportConfigs(ctx context.Context, session smqauthn.Session, token string, bundle bootstrap.Bundle, key []byte, strategy bootstrap.ConflictStrategy) (bootstrap.ImportSummary, error) {
+	if err := am.checkSuperAdmin(ctx, session.DomainUserID); err != nil {
+		if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+			return bootstrap.ImportSummary{}, err
+		}
+	}
+
+	return am.svc.ImportConfigs(ctx, session, token, bundle, key, strategy)
+}
+
+func (am *authorizationMiddleware) Reconcile(ctx context.Context, session smqauthn.Session, token string, repair bool) (bootstrap.ReconcileReport, error) {
+	if err := am.checkSuperAdmin(ctx, session.DomainUserID); err != nil {
+		if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+			return bootstrap.ReconcileReport{}, err
+		}
+	}
+	session.SuperAdmin = true
+
+	return am.svc.Reconcile(ctx, session, token, repair)
+}
+
+func (am *authorizationMiddleware) CreateRollout(ctx context.Context, session smqauthn.Session, r bootstrap.Rollout) (bootstrap.Rollout, error) {
+	if err := am.checkSuperAdmin(ctx, session.DomainUserID); err != nil {
+		if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+			return bootstrap.Rollout{}, err
+		}
+	}
+	session.SuperAdmin = true
+
+	return am.svc.CreateRollout(ctx, session, r)
+}
+
+func (am *authorizationMiddleware) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	if err := am.checkSuperAdmin(ctx, session.DomainUserID); err != nil {
+		if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+			return bootstrap.Rollout{}, err
+		}
+	}
+	session.SuperAdmin = true
+
+	return am.svc.ViewRollout(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) PromoteRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	if err := am.checkSuperAdmin(ctx, session.DomainUserID); err != nil {
+		if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+			return bootstrap.Rollout{}, err
+		}
+	}
+	session.SuperAdmin = true
+
+	return am.svc.PromoteRollout(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) RollBackRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	if err := am.checkSuperAdmin(ctx, session.DomainUserID); err != nil {
+		if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+			return bootstrap.Rollout{}, err
+		}
+	}
+	session.SuperAdmin = true
+
+	return am.svc.RollBackRollout(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) Remove(ctx context.Context, session smqauthn.Session, token, id string) error {
 	if err := am.authorize(ctx, session.DomainID, policies.UserType, policies.UsersKind, session.DomainUserID, policies.DeletePermission, policies.ClientType, id); err != nil {
 		return err
 	}
 
-	return am.svc.Remove(ctx, session, id)
+	return am.svc.Remove(ctx, session, token, id)
 }
 
 func (am *authorizationMiddleware) Bootstrap(ctx context.Context, externalKey, externalID string, secure bool) (bootstrap.Config, error) {
 	return am.svc.Bootstrap(ctx, externalKey, externalID, secure)
 }
 
-func (am *authorizationMiddleware) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) error {
+func (am *authorizationMiddleware) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) ([]bootstrap.Connection, error) {
 	return am.svc.ChangeState(ctx, session, token, id, state)
 }
 
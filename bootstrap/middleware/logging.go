@@ -105,6 +105,26 @@ func (lm *loggingMiddleware) UpdateCert(ctx context.Context, session smqauthn.Se
 	return lm.svc.UpdateCert(ctx, session, clientID, clientCert, clientKey, caCert)
 }
 
+// IssueCert logs the issue_cert request. It logs client ID, certificate validity and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) IssueCert(ctx context.Context, session smqauthn.Session, token, clientID, ttl string) (cfg bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("client_id", clientID),
+			slog.String("ttl", ttl),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Issue bootstrap config certificate failed", args...)
+			return
+		}
+		lm.logger.Info("Issue bootstrap config certificate completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.IssueCert(ctx, session, token, clientID, ttl)
+}
+
 // UpdateConnections logs the update_connections request. It logs bootstrap ID and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) (err error) {
@@ -149,9 +169,171 @@ func (lm *loggingMiddleware) List(ctx context.Context, session smqauthn.Session,
 	return lm.svc.List(ctx, session, filter, offset, limit)
 }
 
+// ExportConfigs logs the export configs request. It logs the number of exported configs and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ExportConfigs(ctx context.Context, session smqauthn.Session, filter bootstrap.Filter, key []byte) (bundle bootstrap.Bundle, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Any("filter", filter),
+			slog.Bool("encrypted", bundle.Encrypted),
+			slog.Int("configs", len(bundle.Configs)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Export configs failed", args...)
+			return
+		}
+		lm.logger.Info("Export configs completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ExportConfigs(ctx, session, filter, key)
+}
+
+// ImportConfigs logs the import configs request. It logs the number of created, merged and skipped configs
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) ImportConfigs(ctx context.Context, session smqauthn.Session, token string, bundle bootstrap.Bundle, key []byte, strategy bootstrap.ConflictStrategy) (summary bootstrap.ImportSummary, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("version", bundle.Version),
+			slog.String("strategy", string(strategy)),
+			slog.Group("summary",
+				slog.Int("created", len(summary.Created)),
+				slog.Int("merged", len(summary.Merged)),
+				slog.Int("skipped", len(summary.Skipped)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Import configs failed", args...)
+			return
+		}
+		lm.logger.Info("Import configs completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ImportConfigs(ctx, session, token, bundle, key, strategy)
+}
+
+// Reconcile logs the reconcile request. It logs the number of checked configs, the number of drifts
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) Reconcile(ctx context.Context, session smqauthn.Session, token string, repair bool) (report bootstrap.ReconcileReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Bool("repair", repair),
+			slog.Group("report",
+				slog.Uint64("checked", report.Checked),
+				slog.Int("drifts", len(report.Drifts)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Reconcile configs failed", args...)
+			return
+		}
+		lm.logger.Info("Reconcile configs completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Reconcile(ctx, session, token, repair)
+}
+
+// CreateRollout logs the create_rollout request. It logs the rollout ID, state and the number of targets,
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) CreateRollout(ctx context.Context, session smqauthn.Session, r bootstrap.Rollout) (rollout bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("rollout",
+				slog.String("id", rollout.ID),
+				slog.String("state", string(rollout.State)),
+				slog.Int("targets", len(rollout.Targets)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create bootstrap config rollout failed", args...)
+			return
+		}
+		lm.logger.Info("Create bootstrap config rollout completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateRollout(ctx, session, r)
+}
+
+// ViewRollout logs the view_rollout request. It logs the rollout ID, state and the number of targets,
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (rollout bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("rollout",
+				slog.String("id", rollout.ID),
+				slog.String("state", string(rollout.State)),
+				slog.Int("targets", len(rollout.Targets)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View bootstrap config rollout failed", args...)
+			return
+		}
+		lm.logger.Info("View bootstrap config rollout completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewRollout(ctx, session, id)
+}
+
+// PromoteRollout logs the promote_rollout request. It logs the rollout ID, state and the number of targets,
+// and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) PromoteRollout(ctx context.Context, session smqauthn.Session, id string) (rollout bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("rollout",
+				slog.String("id", rollout.ID),
+				slog.String("state", string(rollout.State)),
+				slog.Int("targets", len(rollout.Targets)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Promote bootstrap config rollout failed", args...)
+			return
+		}
+		lm.logger.Info("Promote bootstrap config rollout completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.PromoteRollout(ctx, session, id)
+}
+
+// RollBackRollout logs the roll_back_rollout request. It logs the rollout ID, state, the number of targets
+// and of the skipped targets, and the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) RollBackRollout(ctx context.Context, session smqauthn.Session, id string) (rollout bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("rollout",
+				slog.String("id", rollout.ID),
+				slog.String("state", string(rollout.State)),
+				slog.Int("targets", len(rollout.Targets)),
+				slog.Int("skipped", skippedTargets(rollout)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Roll back bootstrap config rollout failed", args...)
+			return
+		}
+		lm.logger.Info("Roll back bootstrap config rollout completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RollBackRollout(ctx, session, id)
+}
+
 // Remove logs the remove request. It logs bootstrap ID and the time it took to complete the request.
 // If the request fails, it logs the error.
-func (lm *loggingMiddleware) Remove(ctx context.Context, session smqauthn.Session, id string) (err error) {
+func (lm *loggingMiddleware) Remove(ctx context.Context, session smqauthn.Session, token, id string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -165,7 +347,7 @@ func (lm *loggingMiddleware) Remove(ctx context.Context, session smqauthn.Sessio
 		lm.logger.Info("Remove bootstrap config completed successfully", args...)
 	}(time.Now())
 
-	return lm.svc.Remove(ctx, session, id)
+	return lm.svc.Remove(ctx, session, token, id)
 }
 
 func (lm *loggingMiddleware) Bootstrap(ctx context.Context, externalKey, externalID string, secure bool) (cfg bootstrap.Config, err error) {
@@ -185,7 +367,7 @@ func (lm *loggingMiddleware) Bootstrap(ctx context.Context, externalKey, externa
 	return lm.svc.Bootstrap(ctx, externalKey, externalID, secure)
 }
 
-func (lm *loggingMiddleware) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) (err error) {
+func (lm *loggingMiddleware) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) (conns []bootstrap.Connection, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -293,3 +475,14 @@ func (lm *loggingMiddleware) DisconnectClientHandler(ctx context.Context, channe
 
 	return lm.svc.DisconnectClientHandler(ctx, channelID, clientID)
 }
+
+func skippedTargets(r bootstrap.Rollout) int {
+	var n int
+	for _, t := range r.Targets {
+		if t.Skipped {
+			n++
+		}
+	}
+
+	return n
+}
@@ -71,6 +71,16 @@ func (mm *metricsMiddleware) UpdateCert(ctx context.Context, session smqauthn.Se
 	return mm.svc.UpdateCert(ctx, session, clientID, clientCert, clientKey, caCert)
 }
 
+// IssueCert instruments IssueCert method with metrics.
+func (mm *metricsMiddleware) IssueCert(ctx context.Context, session smqauthn.Session, token, clientID, ttl string) (cfg bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "issue_cert").Add(1)
+		mm.latency.With("method", "issue_cert").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.IssueCert(ctx, session, token, clientID, ttl)
+}
+
 // UpdateConnections instruments UpdateConnections method with metrics.
 func (mm *metricsMiddleware) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) (err error) {
 	defer func(begin time.Time) {
@@ -91,14 +101,84 @@ func (mm *metricsMiddleware) List(ctx context.Context, session smqauthn.Session,
 	return mm.svc.List(ctx, session, filter, offset, limit)
 }
 
+// ExportConfigs instruments ExportConfigs method with metrics.
+func (mm *metricsMiddleware) ExportConfigs(ctx context.Context, session smqauthn.Session, filter bootstrap.Filter, key []byte) (bundle bootstrap.Bundle, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "export_configs").Add(1)
+		mm.latency.With("method", "export_configs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ExportConfigs(ctx, session, filter, key)
+}
+
+// ImportConfigs instruments ImportConfigs method with metrics.
+func (mm *metricsMiddleware) ImportConfigs(ctx context.Context, session smqauthn.Session, token string, bundle bootstrap.Bundle, key []byte, strategy bootstrap.ConflictStrategy) (summary bootstrap.ImportSummary, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "import_configs").Add(1)
+		mm.latency.With("method", "import_configs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ImportConfigs(ctx, session, token, bundle, key, strategy)
+}
+
+// Reconcile instruments Reconcile method with metrics.
+func (mm *metricsMiddleware) Reconcile(ctx context.Context, session smqauthn.Session, token string, repair bool) (report bootstrap.ReconcileReport, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "reconcile_configs").Add(1)
+		mm.latency.With("method", "reconcile_configs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Reconcile(ctx, session, token, repair)
+}
+
+// CreateRollout instruments CreateRollout method with metrics.
+func (mm *metricsMiddleware) CreateRollout(ctx context.Context, session smqauthn.Session, r bootstrap.Rollout) (rollout bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_rollout").Add(1)
+		mm.latency.With("method", "create_rollout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateRollout(ctx, session, r)
+}
+
+// ViewRollout instruments ViewRollout method with metrics.
+func (mm *metricsMiddleware) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (rollout bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_rollout").Add(1)
+		mm.latency.With("method", "view_rollout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewRollout(ctx, session, id)
+}
+
+// PromoteRollout instruments PromoteRollout method with metrics.
+func (mm *metricsMiddleware) PromoteRollout(ctx context.Context, session smqauthn.Session, id string) (rollout bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "promote_rollout").Add(1)
+		mm.latency.With("method", "promote_rollout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PromoteRollout(ctx, session, id)
+}
+
+// RollBackRollout instruments RollBackRollout method with metrics.
+func (mm *metricsMiddleware) RollBackRollout(ctx context.Context, session smqauthn.Session, id string) (rollout bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "roll_back_rollout").Add(1)
+		mm.latency.With("method", "roll_back_rollout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RollBackRollout(ctx, session, id)
+}
+
 // Remove instruments Remove method with metrics.
-func (mm *metricsMiddleware) Remove(ctx context.Context, session smqauthn.Session, id string) (err error) {
+func (mm *metricsMiddleware) Remove(ctx context.Context, session smqauthn.Session, token, id string) (err error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "remove").Add(1)
 		mm.latency.With("method", "remove").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Remove(ctx, session, id)
+	return mm.svc.Remove(ctx, session, token, id)
 }
 
 // Bootstrap instruments Bootstrap method with metrics.
@@ -112,7 +192,7 @@ func (mm *metricsMiddleware) Bootstrap(ctx context.Context, externalKey, externa
 }
 
 // ChangeState instruments ChangeState method with metrics.
-func (mm *metricsMiddleware) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) (err error) {
+func (mm *metricsMiddleware) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) (conns []bootstrap.Connection, err error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "change_state").Add(1)
 		mm.latency.With("method", "change_state").Observe(time.Since(begin).Seconds())
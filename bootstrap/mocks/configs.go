@@ -71,6 +71,36 @@ func (_m *ConfigRepository) DisconnectClient(ctx context.Context, channelID stri
 	return r0
 }
 
+// Import provides a mock function with given fields: ctx, configs
+func (_m *ConfigRepository) Import(ctx context.Context, configs []bootstrap.ImportedConfig) ([]string, error) {
+	ret := _m.Called(ctx, configs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []bootstrap.ImportedConfig) ([]string, error)); ok {
+		return rf(ctx, configs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []bootstrap.ImportedConfig) []string); ok {
+		r0 = rf(ctx, configs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []bootstrap.ImportedConfig) error); ok {
+		r1 = rf(ctx, configs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListExisting provides a mock function with given fields: ctx, domainID, ids
 func (_m *ConfigRepository) ListExisting(ctx context.Context, domainID string, ids []string) ([]bootstrap.Channel, error) {
 	ret := _m.Called(ctx, domainID, ids)
@@ -229,9 +259,9 @@ func (_m *ConfigRepository) RetrieveByID(ctx context.Context, domainID string, i
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: ctx, cfg, chsConnIDs
-func (_m *ConfigRepository) Save(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string) (string, error) {
-	ret := _m.Called(ctx, cfg, chsConnIDs)
+// Save provides a mock function with given fields: ctx, cfg, connections
+func (_m *ConfigRepository) Save(ctx context.Context, cfg bootstrap.Config, connections []bootstrap.Connection) (string, error) {
+	ret := _m.Called(ctx, cfg, connections)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
@@ -239,17 +269,17 @@ func (_m *ConfigRepository) Save(ctx context.Context, cfg bootstrap.Config, chsC
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bootstrap.Config, []string) (string, error)); ok {
-		return rf(ctx, cfg, chsConnIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, bootstrap.Config, []bootstrap.Connection) (string, error)); ok {
+		return rf(ctx, cfg, connections)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bootstrap.Config, []string) string); ok {
-		r0 = rf(ctx, cfg, chsConnIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, bootstrap.Config, []bootstrap.Connection) string); ok {
+		r0 = rf(ctx, cfg, connections)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bootstrap.Config, []string) error); ok {
-		r1 = rf(ctx, cfg, chsConnIDs)
+	if rf, ok := ret.Get(1).(func(context.Context, bootstrap.Config, []bootstrap.Connection) error); ok {
+		r1 = rf(ctx, cfg, connections)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -322,7 +352,7 @@ func (_m *ConfigRepository) UpdateChannel(ctx context.Context, c bootstrap.Chann
 }
 
 // UpdateConnections provides a mock function with given fields: ctx, domainID, id, channels, connections
-func (_m *ConfigRepository) UpdateConnections(ctx context.Context, domainID string, id string, channels []bootstrap.Channel, connections []string) error {
+func (_m *ConfigRepository) UpdateConnections(ctx context.Context, domainID string, id string, channels []bootstrap.Channel, connections []bootstrap.Connection) error {
 	ret := _m.Called(ctx, domainID, id, channels, connections)
 
 	if len(ret) == 0 {
@@ -330,7 +360,7 @@ func (_m *ConfigRepository) UpdateConnections(ctx context.Context, domainID stri
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, []bootstrap.Channel, []string) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []bootstrap.Channel, []bootstrap.Connection) error); ok {
 		r0 = rf(ctx, domainID, id, channels, connections)
 	} else {
 		r0 = ret.Error(0)
@@ -339,6 +369,24 @@ func (_m *ConfigRepository) UpdateConnections(ctx context.Context, domainID stri
 	return r0
 }
 
+// UpdateFetchedVersion provides a mock function with given fields: ctx, domainID, id, version
+func (_m *ConfigRepository) UpdateFetchedVersion(ctx context.Context, domainID string, id string, version uint64) error {
+	ret := _m.Called(ctx, domainID, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFetchedVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
+		r0 = rf(ctx, domainID, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewConfigRepository creates a new instance of ConfigRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewConfigRepository(t interface {
@@ -75,21 +75,33 @@ func (_m *Service) Bootstrap(ctx context.Context, externalKey string, externalID
 }
 
 // ChangeState provides a mock function with given fields: ctx, session, token, id, state
-func (_m *Service) ChangeState(ctx context.Context, session authn.Session, token string, id string, state bootstrap.State) error {
+func (_m *Service) ChangeState(ctx context.Context, session authn.Session, token string, id string, state bootstrap.State) ([]bootstrap.Connection, error) {
 	ret := _m.Called(ctx, session, token, id, state)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ChangeState")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string, bootstrap.State) error); ok {
+	var r0 []bootstrap.Connection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string, bootstrap.State) ([]bootstrap.Connection, error)); ok {
+		return rf(ctx, session, token, id, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string, bootstrap.State) []bootstrap.Connection); ok {
 		r0 = rf(ctx, session, token, id, state)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bootstrap.Connection)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, string, bootstrap.State) error); ok {
+		r1 = rf(ctx, session, token, id, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // ConnectClientHandler provides a mock function with given fields: ctx, channelID, clientID
@@ -110,6 +122,34 @@ func (_m *Service) ConnectClientHandler(ctx context.Context, channelID string, c
 	return r0
 }
 
+// CreateRollout provides a mock function with given fields: ctx, session, r
+func (_m *Service) CreateRollout(ctx context.Context, session authn.Session, r bootstrap.Rollout) (bootstrap.Rollout, error) {
+	ret := _m.Called(ctx, session, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRollout")
+	}
+
+	var r0 bootstrap.Rollout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Rollout) (bootstrap.Rollout, error)); ok {
+		return rf(ctx, session, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Rollout) bootstrap.Rollout); ok {
+		r0 = rf(ctx, session, r)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Rollout)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, bootstrap.Rollout) error); ok {
+		r1 = rf(ctx, session, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DisconnectClientHandler provides a mock function with given fields: ctx, channelID, clientID
 func (_m *Service) DisconnectClientHandler(ctx context.Context, channelID string, clientID string) error {
 	ret := _m.Called(ctx, channelID, clientID)
@@ -128,6 +168,90 @@ func (_m *Service) DisconnectClientHandler(ctx context.Context, channelID string
 	return r0
 }
 
+// ExportConfigs provides a mock function with given fields: ctx, session, filter, key
+func (_m *Service) ExportConfigs(ctx context.Context, session authn.Session, filter bootstrap.Filter, key []byte) (bootstrap.Bundle, error) {
+	ret := _m.Called(ctx, session, filter, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportConfigs")
+	}
+
+	var r0 bootstrap.Bundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Filter, []byte) (bootstrap.Bundle, error)); ok {
+		return rf(ctx, session, filter, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Filter, []byte) bootstrap.Bundle); ok {
+		r0 = rf(ctx, session, filter, key)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Bundle)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, bootstrap.Filter, []byte) error); ok {
+		r1 = rf(ctx, session, filter, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportConfigs provides a mock function with given fields: ctx, session, token, bundle, key, strategy
+func (_m *Service) ImportConfigs(ctx context.Context, session authn.Session, token string, bundle bootstrap.Bundle, key []byte, strategy bootstrap.ConflictStrategy) (bootstrap.ImportSummary, error) {
+	ret := _m.Called(ctx, session, token, bundle, key, strategy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportConfigs")
+	}
+
+	var r0 bootstrap.ImportSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bootstrap.Bundle, []byte, bootstrap.ConflictStrategy) (bootstrap.ImportSummary, error)); ok {
+		return rf(ctx, session, token, bundle, key, strategy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bootstrap.Bundle, []byte, bootstrap.ConflictStrategy) bootstrap.ImportSummary); ok {
+		r0 = rf(ctx, session, token, bundle, key, strategy)
+	} else {
+		r0 = ret.Get(0).(bootstrap.ImportSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bootstrap.Bundle, []byte, bootstrap.ConflictStrategy) error); ok {
+		r1 = rf(ctx, session, token, bundle, key, strategy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueCert provides a mock function with given fields: ctx, session, token, clientID, ttl
+func (_m *Service) IssueCert(ctx context.Context, session authn.Session, token string, clientID string, ttl string) (bootstrap.Config, error) {
+	ret := _m.Called(ctx, session, token, clientID, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueCert")
+	}
+
+	var r0 bootstrap.Config
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string, string) (bootstrap.Config, error)); ok {
+		return rf(ctx, session, token, clientID, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string, string) bootstrap.Config); ok {
+		r0 = rf(ctx, session, token, clientID, ttl)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Config)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, string, string) error); ok {
+		r1 = rf(ctx, session, token, clientID, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, session, filter, offset, limit
 func (_m *Service) List(ctx context.Context, session authn.Session, filter bootstrap.Filter, offset uint64, limit uint64) (bootstrap.ConfigsPage, error) {
 	ret := _m.Called(ctx, session, filter, offset, limit)
@@ -156,17 +280,73 @@ func (_m *Service) List(ctx context.Context, session authn.Session, filter boots
 	return r0, r1
 }
 
-// Remove provides a mock function with given fields: ctx, session, id
-func (_m *Service) Remove(ctx context.Context, session authn.Session, id string) error {
+// PromoteRollout provides a mock function with given fields: ctx, session, id
+func (_m *Service) PromoteRollout(ctx context.Context, session authn.Session, id string) (bootstrap.Rollout, error) {
 	ret := _m.Called(ctx, session, id)
 
+	if len(ret) == 0 {
+		panic("no return value specified for PromoteRollout")
+	}
+
+	var r0 bootstrap.Rollout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (bootstrap.Rollout, error)); ok {
+		return rf(ctx, session, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) bootstrap.Rollout); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Rollout)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reconcile provides a mock function with given fields: ctx, session, token, repair
+func (_m *Service) Reconcile(ctx context.Context, session authn.Session, token string, repair bool) (bootstrap.ReconcileReport, error) {
+	ret := _m.Called(ctx, session, token, repair)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reconcile")
+	}
+
+	var r0 bootstrap.ReconcileReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) (bootstrap.ReconcileReport, error)); ok {
+		return rf(ctx, session, token, repair)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, bool) bootstrap.ReconcileReport); ok {
+		r0 = rf(ctx, session, token, repair)
+	} else {
+		r0 = ret.Get(0).(bootstrap.ReconcileReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, bool) error); ok {
+		r1 = rf(ctx, session, token, repair)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: ctx, session, token, id
+func (_m *Service) Remove(ctx context.Context, session authn.Session, token string, id string) error {
+	ret := _m.Called(ctx, session, token, id)
+
 	if len(ret) == 0 {
 		panic("no return value specified for Remove")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
-		r0 = rf(ctx, session, id)
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) error); ok {
+		r0 = rf(ctx, session, token, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -210,6 +390,34 @@ func (_m *Service) RemoveConfigHandler(ctx context.Context, id string) error {
 	return r0
 }
 
+// RollBackRollout provides a mock function with given fields: ctx, session, id
+func (_m *Service) RollBackRollout(ctx context.Context, session authn.Session, id string) (bootstrap.Rollout, error) {
+	ret := _m.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollBackRollout")
+	}
+
+	var r0 bootstrap.Rollout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (bootstrap.Rollout, error)); ok {
+		return rf(ctx, session, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) bootstrap.Rollout); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Rollout)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, session, cfg
 func (_m *Service) Update(ctx context.Context, session authn.Session, cfg bootstrap.Config) error {
 	ret := _m.Called(ctx, session, cfg)
@@ -320,6 +528,34 @@ func (_m *Service) View(ctx context.Context, session authn.Session, id string) (
 	return r0, r1
 }
 
+// ViewRollout provides a mock function with given fields: ctx, session, id
+func (_m *Service) ViewRollout(ctx context.Context, session authn.Session, id string) (bootstrap.Rollout, error) {
+	ret := _m.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRollout")
+	}
+
+	var r0 bootstrap.Rollout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (bootstrap.Rollout, error)); ok {
+		return rf(ctx, session, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) bootstrap.Rollout); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Rollout)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
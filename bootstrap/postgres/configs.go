@@ -48,7 +48,7 @@ func NewConfigRepository(db postgres.Database, log *slog.Logger) bootstrap.Confi
 	return &configRepository{db: db, log: log}
 }
 
-func (cr configRepository) Save(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string) (clientID string, err error) {
+func (cr configRepository) Save(ctx context.Context, cfg bootstrap.Config, connections []bootstrap.Connection) (clientID string, err error) {
 	q := `INSERT INTO configs (magistrala_client, domain_id, name, client_cert, client_key, ca_cert, magistrala_secret, external_id, external_key, content, state)
 	VALUES (:magistrala_client, :domain_id, :name, :client_cert, :client_key, :ca_cert, :magistrala_secret, :external_id, :external_key, :content, :state)`
 
@@ -78,7 +78,7 @@ func (cr configRepository) Save(ctx context.Context, cfg bootstrap.Config, chsCo
 		return "", errors.Wrap(errSaveChannels, err)
 	}
 
-	if err := insertConnections(ctx, cfg, chsConnIDs, tx); err != nil {
+	if err := insertConnections(ctx, cfg, connections, tx); err != nil {
 		return "", errors.Wrap(errSaveConnections, err)
 	}
 
@@ -90,7 +90,7 @@ func (cr configRepository) Save(ctx context.Context, cfg bootstrap.Config, chsCo
 }
 
 func (cr configRepository) RetrieveByID(ctx context.Context, domainID, id string) (bootstrap.Config, error) {
-	q := `SELECT magistrala_client, magistrala_secret, external_id, external_key, name, content, state, client_cert, ca_cert
+	q := `SELECT magistrala_client, magistrala_secret, external_id, external_key, name, content, state, client_cert, client_key, ca_cert, version, fetched_version
 		  FROM configs
 		  WHERE magistrala_client = :magistrala_client AND domain_id = :domain_id`
 
@@ -115,10 +115,11 @@ func (cr configRepository) RetrieveByID(ctx context.Context, domainID, id string
 		return bootstrap.Config{}, err
 	}
 
-	q = `SELECT magistrala_channel, name, metadata FROM channels ch
+	q = `SELECT magistrala_channel, name, metadata, conn.role FROM channels ch
 		 INNER JOIN connections conn
 		 ON ch.magistrala_channel = conn.channel_id AND ch.domain_id = conn.domain_id
-		 WHERE conn.config_id = :magistrala_client AND conn.domain_id = :domain_id`
+		 WHERE conn.config_id = :magistrala_client AND conn.domain_id = :domain_id
+		 ORDER BY conn.position`
 
 	rows, err := cr.db.NamedQueryContext(ctx, q, dbcfg)
 	if err != nil {
@@ -153,7 +154,7 @@ func (cr configRepository) RetrieveAll(ctx context.Context, domainID string, cli
 	search, params := buildRetrieveQueryParams(domainID, clientIDs, filter)
 	n := len(params)
 
-	q := `SELECT magistrala_client, magistrala_secret, external_id, external_key, name, content, state
+	q := `SELECT magistrala_client, magistrala_secret, external_id, external_key, name, content, state, version, fetched_version
 		  FROM configs %s ORDER BY magistrala_client LIMIT $%d OFFSET $%d`
 	q = fmt.Sprintf(q, search, n+1, n+2)
 
@@ -169,7 +170,7 @@ func (cr configRepository) RetrieveAll(ctx context.Context, domainID string, cli
 
 	for rows.Next() {
 		c := bootstrap.Config{DomainID: domainID}
-		if err := rows.Scan(&c.ClientID, &c.ClientSecret, &c.ExternalID, &c.ExternalKey, &name, &content, &c.State); err != nil {
+		if err := rows.Scan(&c.ClientID, &c.ClientSecret, &c.ExternalID, &c.ExternalKey, &name, &content, &c.State, &c.Version, &c.FetchedVersion); err != nil {
 			cr.log.Error(fmt.Sprintf("Failed to read retrieved config due to %s", err))
 			return bootstrap.ConfigsPage{}
 		}
@@ -196,7 +197,7 @@ func (cr configRepository) RetrieveAll(ctx context.Context, domainID string, cli
 }
 
 func (cr configRepository) RetrieveByExternalID(ctx context.Context, externalID string) (bootstrap.Config, error) {
-	q := `SELECT magistrala_client, magistrala_secret, external_key, domain_id, name, client_cert, client_key, ca_cert, content, state
+	q := `SELECT magistrala_client, magistrala_secret, external_key, domain_id, name, client_cert, client_key, ca_cert, content, state, version, fetched_version
 		  FROM configs
 		  WHERE external_id = :external_id`
 	dbcfg := dbConfig{
@@ -219,10 +220,11 @@ func (cr configRepository) RetrieveByExternalID(ctx context.Context, externalID
 		return bootstrap.Config{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
 
-	q = `SELECT magistrala_channel, name, metadata FROM channels ch
+	q = `SELECT magistrala_channel, name, metadata, conn.role FROM channels ch
      INNER JOIN connections conn
      ON ch.magistrala_channel = conn.channel_id AND ch.domain_id = conn.domain_id
-     WHERE conn.config_id = :magistrala_client AND conn.domain_id = :domain_id`
+     WHERE conn.config_id = :magistrala_client AND conn.domain_id = :domain_id
+     ORDER BY conn.position`
 
 	rows, err := cr.db.NamedQueryContext(ctx, q, dbcfg)
 	if err != nil {
@@ -255,7 +257,9 @@ func (cr configRepository) RetrieveByExternalID(ctx context.Context, externalID
 }
 
 func (cr configRepository) Update(ctx context.Context, cfg bootstrap.Config) error {
-	q := `UPDATE configs SET name = :name, content = :content WHERE magistrala_client = :magistrala_client AND domain_id = :domain_id `
+	q := `UPDATE configs SET name = :name, content = :content,
+		  version = CASE WHEN content IS DISTINCT FROM :content THEN version + 1 ELSE version END
+		  WHERE magistrala_client = :magistrala_client AND domain_id = :domain_id `
 
 	dbcfg := dbConfig{
 		Name:     nullString(cfg.Name),
@@ -310,7 +314,7 @@ func (cr configRepository) UpdateCert(ctx context.Context, domainID, clientID, c
 	return toConfig(dbcfg), nil
 }
 
-func (cr configRepository) UpdateConnections(ctx context.Context, domainID, id string, channels []bootstrap.Channel, connections []string) (err error) {
+func (cr configRepository) UpdateConnections(ctx context.Context, domainID, id string, channels []bootstrap.Channel, connections []bootstrap.Connection) (err error) {
 	tx, err := cr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(repoerr.ErrUpdateEntity, err)
@@ -344,6 +348,43 @@ func (cr configRepository) UpdateConnections(ctx context.Context, domainID, id s
 	return nil
 }
 
+func (cr configRepository) Import(ctx context.Context, configs []bootstrap.ImportedConfig) (skipped []string, err error) {
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	defer func() {
+		if err != nil {
+			err = cr.rollback("Import method", err, tx)
+		}
+	}()
+
+	skipped = []string{}
+	for _, ic := range configs {
+		cfg := ic.Config
+		if ic.Merge {
+			if err := mergeConfig(cfg, ic.Connections, tx); err != nil {
+				return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+			}
+			continue
+		}
+		saved, err := importConfig(ctx, cfg, ic.Connections, tx)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrCreateEntity, err)
+		}
+		if !saved {
+			skipped = append(skipped, cfg.ClientID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return skipped, nil
+}
+
 func (cr configRepository) Remove(ctx context.Context, domainID, id string) error {
 	q := `DELETE FROM configs WHERE magistrala_client = :magistrala_client AND domain_id = :domain_id`
 	dbcfg := dbConfig{
@@ -388,6 +429,15 @@ func (cr configRepository) ChangeState(ctx context.Context, domainID, id string,
 	return nil
 }
 
+func (cr configRepository) UpdateFetchedVersion(ctx context.Context, domainID, id string, version uint64) error {
+	q := `UPDATE configs SET fetched_version = $1 WHERE magistrala_client = $2 AND domain_id = $3 AND fetched_version < $1`
+	if _, err := cr.db.ExecContext(ctx, q, version, id, domainID); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
 func (cr configRepository) ListExisting(ctx context.Context, domainID string, ids []string) ([]bootstrap.Channel, error) {
 	var channels []bootstrap.Channel
 	if len(ids) == 0 {
@@ -549,29 +599,98 @@ func insertChannels(domainID string, channels []bootstrap.Channel, tx *sqlx.Tx)
 	return nil
 }
 
-func insertConnections(_ context.Context, cfg bootstrap.Config, connections []string, tx *sqlx.Tx) error {
-	if len(connections) == 0 {
-		return nil
+// importConfig saves the Config unless it conflicts with an existing one,
+// e.g. by external ID, which is reported as false.
+func importConfig(ctx context.Context, cfg bootstrap.Config, connections []bootstrap.Connection, tx *sqlx.Tx) (bool, error) {
+	q := `INSERT INTO configs (magistrala_client, domain_id, name, client_cert, client_key, ca_cert, magistrala_secret, external_id, external_key, content, state)
+	VALUES (:magistrala_client, :domain_id, :name, :client_cert, :client_key, :ca_cert, :magistrala_secret, :external_id, :external_key, :content, :state)
+	ON CONFLICT DO NOTHING`
+
+	res, err := tx.NamedExec(q, toDBConfig(cfg))
+	if err != nil {
+		return false, err
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if cnt == 0 {
+		return false, nil
+	}
+
+	if err := importChannels(cfg.DomainID, cfg.Channels, tx); err != nil {
+		return false, errors.Wrap(errSaveChannels, err)
+	}
+	if err := insertConnections(ctx, cfg, connections, tx); err != nil {
+		return false, errors.Wrap(errSaveConnections, err)
 	}
 
-	q := `INSERT INTO connections (config_id, channel_id, domain_id)
-      VALUES (:config_id, :channel_id, :domain_id)`
+	return true, nil
+}
 
-	conns := []dbConnection{}
-	for _, conn := range connections {
-		dbconn := dbConnection{
-			Config:   cfg.ClientID,
-			Channel:  conn,
-			DomainID: cfg.DomainID,
+// mergeConfig replaces the content, certificates, connections and state of
+// the existing Config.
+func mergeConfig(cfg bootstrap.Config, connections []bootstrap.Connection, tx *sqlx.Tx) error {
+	q := `UPDATE configs SET name = :name, content = :content,
+		  version = CASE WHEN content IS DISTINCT FROM :content THEN version + 1 ELSE version END,
+		  client_cert = :client_cert, client_key = :client_key, ca_cert = :ca_cert, state = :state
+		  WHERE magistrala_client = :magistrala_client AND domain_id = :domain_id`
+
+	res, err := tx.NamedExec(q, toDBConfig(cfg))
+	if err != nil {
+		return err
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	if err := importChannels(cfg.DomainID, cfg.Channels, tx); err != nil {
+		return errors.Wrap(errUpdateChannels, err)
+	}
+
+	return updateConnections(cfg.DomainID, cfg.ClientID, connections, tx)
+}
+
+// importChannels saves the Channels which are not stored yet.
+func importChannels(domainID string, channels []bootstrap.Channel, tx *sqlx.Tx) error {
+	if len(channels) == 0 {
+		return nil
+	}
+
+	var chans []dbChannel
+	for _, ch := range channels {
+		dbch, err := toDBChannel(domainID, ch)
+		if err != nil {
+			return err
 		}
-		conns = append(conns, dbconn)
+		chans = append(chans, dbch)
 	}
-	_, err := tx.NamedExec(q, conns)
+	q := `INSERT INTO channels (magistrala_channel, domain_id, name, metadata, parent_id, description, created_at, updated_at, updated_by, status)
+		  VALUES (:magistrala_channel, :domain_id, :name, :metadata, :parent_id, :description, :created_at, :updated_at, :updated_by, :status)
+		  ON CONFLICT DO NOTHING`
+	_, err := tx.NamedExec(q, chans)
 
 	return err
 }
 
-func updateConnections(domainID, id string, connections []string, tx *sqlx.Tx) error {
+func insertConnections(_ context.Context, cfg bootstrap.Config, connections []bootstrap.Connection, tx *sqlx.Tx) error {
+	if len(connections) == 0 {
+		return nil
+	}
+
+	q := `INSERT INTO connections (config_id, channel_id, domain_id, position, role)
+      VALUES (:config_id, :channel_id, :domain_id, :position, :role)`
+
+	_, err := tx.NamedExec(q, toDBConnections(cfg.DomainID, cfg.ClientID, connections))
+
+	return err
+}
+
+func updateConnections(domainID, id string, connections []bootstrap.Connection, tx *sqlx.Tx) error {
 	if len(connections) == 0 {
 		return nil
 	}
@@ -581,7 +700,7 @@ func updateConnections(domainID, id string, connections []string, tx *sqlx.Tx) e
 		  AND channel_id NOT IN ($3)`
 
 	var conn pgtype.TextArray
-	if err := conn.Set(connections); err != nil {
+	if err := conn.Set(channelIDs(connections)); err != nil {
 		return err
 	}
 
@@ -595,20 +714,10 @@ func updateConnections(domainID, id string, connections []string, tx *sqlx.Tx) e
 		return err
 	}
 
-	q = `INSERT INTO connections (config_id, channel_id, domain_id)
-		 VALUES (:config_id, :channel_id, :domain_id)`
-
-	conns := []dbConnection{}
-	for _, conn := range connections {
-		dbconn := dbConnection{
-			Config:   id,
-			Channel:  conn,
-			DomainID: domainID,
-		}
-		conns = append(conns, dbconn)
-	}
+	q = `INSERT INTO connections (config_id, channel_id, domain_id, position, role)
+		 VALUES (:config_id, :channel_id, :domain_id, :position, :role)`
 
-	if _, err := tx.NamedExec(q, conns); err != nil {
+	if _, err := tx.NamedExec(q, toDBConnections(domainID, id, connections)); err != nil {
 		return err
 	}
 
@@ -644,17 +753,19 @@ func nullTime(t time.Time) sql.NullTime {
 }
 
 type dbConfig struct {
-	DomainID     string          `db:"domain_id"`
-	ClientID     string          `db:"magistrala_client"`
-	ClientSecret string          `db:"magistrala_secret"`
-	Name         sql.NullString  `db:"name"`
-	ClientCert   sql.NullString  `db:"client_cert"`
-	ClientKey    sql.NullString  `db:"client_key"`
-	CaCert       sql.NullString  `db:"ca_cert"`
-	ExternalID   string          `db:"external_id"`
-	ExternalKey  string          `db:"external_key"`
-	Content      sql.NullString  `db:"content"`
-	State        bootstrap.State `db:"state"`
+	DomainID       string          `db:"domain_id"`
+	ClientID       string          `db:"magistrala_client"`
+	ClientSecret   string          `db:"magistrala_secret"`
+	Name           sql.NullString  `db:"name"`
+	ClientCert     sql.NullString  `db:"client_cert"`
+	ClientKey      sql.NullString  `db:"client_key"`
+	CaCert         sql.NullString  `db:"ca_cert"`
+	ExternalID     string          `db:"external_id"`
+	ExternalKey    string          `db:"external_key"`
+	Content        sql.NullString  `db:"content"`
+	State          bootstrap.State `db:"state"`
+	Version        uint64          `db:"version"`
+	FetchedVersion uint64          `db:"fetched_version"`
 }
 
 func toDBConfig(cfg bootstrap.Config) dbConfig {
@@ -675,12 +786,14 @@ func toDBConfig(cfg bootstrap.Config) dbConfig {
 
 func toConfig(dbcfg dbConfig) bootstrap.Config {
 	cfg := bootstrap.Config{
-		ClientID:     dbcfg.ClientID,
-		ClientSecret: dbcfg.ClientSecret,
-		DomainID:     dbcfg.DomainID,
-		ExternalID:   dbcfg.ExternalID,
-		ExternalKey:  dbcfg.ExternalKey,
-		State:        dbcfg.State,
+		ClientID:       dbcfg.ClientID,
+		ClientSecret:   dbcfg.ClientSecret,
+		DomainID:       dbcfg.DomainID,
+		ExternalID:     dbcfg.ExternalID,
+		ExternalKey:    dbcfg.ExternalKey,
+		State:          dbcfg.State,
+		Version:        dbcfg.Version,
+		FetchedVersion: dbcfg.FetchedVersion,
 	}
 
 	if dbcfg.Name.Valid {
@@ -716,6 +829,7 @@ type dbChannel struct {
 	UpdatedAt   sql.NullTime   `db:"updated_at,omitempty"`
 	UpdatedBy   sql.NullString `db:"updated_by,omitempty"`
 	Status      clients.Status `db:"status"`
+	Role        sql.NullString `db:"role"`
 }
 
 func toDBChannel(domainID string, ch bootstrap.Channel) (dbChannel, error) {
@@ -763,6 +877,9 @@ func toChannel(dbch dbChannel) (bootstrap.Channel, error) {
 	if dbch.UpdatedAt.Valid {
 		ch.UpdatedAt = dbch.UpdatedAt.Time
 	}
+	if dbch.Role.Valid {
+		ch.Role = dbch.Role.String
+	}
 
 	if err := json.Unmarshal([]byte(dbch.Metadata), &ch.Metadata); err != nil {
 		return bootstrap.Channel{}, errors.Wrap(errors.ErrMalformedEntity, err)
@@ -772,7 +889,34 @@ func toChannel(dbch dbChannel) (bootstrap.Channel, error) {
 }
 
 type dbConnection struct {
-	Config   string `db:"config_id"`
-	Channel  string `db:"channel_id"`
-	DomainID string `db:"domain_id"`
+	Config   string         `db:"config_id"`
+	Channel  string         `db:"channel_id"`
+	DomainID string         `db:"domain_id"`
+	Position int            `db:"position"`
+	Role     sql.NullString `db:"role"`
+}
+
+// toDBConnections keeps the order of the connections as their position.
+func toDBConnections(domainID, configID string, connections []bootstrap.Connection) []dbConnection {
+	conns := []dbConnection{}
+	for i, conn := range connections {
+		conns = append(conns, dbConnection{
+			Config:   configID,
+			Channel:  conn.ChannelID,
+			DomainID: domainID,
+			Position: i,
+			Role:     nullString(conn.Role),
+		})
+	}
+
+	return conns
+}
+
+func channelIDs(connections []bootstrap.Connection) []string {
+	ids := []string{}
+	for _, conn := range connections {
+		ids = append(ids, conn.ChannelID)
+	}
+
+	return ids
 }
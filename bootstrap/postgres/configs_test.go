@@ -36,7 +36,8 @@ var (
 		State:   bootstrap.Inactive,
 	}
 
-	channels = []string{"1", "2"}
+	channels    = []string{"1", "2"}
+	connections = []bootstrap.Connection{{ChannelID: "1", Role: "control"}, {ChannelID: "2", Role: "data"}}
 )
 
 func TestSave(t *testing.T) {
@@ -64,13 +65,13 @@ func TestSave(t *testing.T) {
 	cases := []struct {
 		desc        string
 		config      bootstrap.Config
-		connections []string
+		connections []bootstrap.Connection
 		err         error
 	}{
 		{
 			desc:        "save a config",
 			config:      config,
-			connections: channels,
+			connections: connections,
 			err:         nil,
 		},
 		{
@@ -88,7 +89,7 @@ func TestSave(t *testing.T) {
 		{
 			desc:        "save config with same Channels",
 			config:      duplicateChannels,
-			connections: channels,
+			connections: connections,
 			err:         repoerr.ErrConflict,
 		},
 	}
@@ -114,7 +115,7 @@ func TestRetrieveByID(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	id, err := repo.Save(context.Background(), c, channels)
+	id, err := repo.Save(context.Background(), c, connections)
 	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	nonexistentConfID, err := uuid.NewV4()
@@ -155,6 +156,14 @@ func TestRetrieveByID(t *testing.T) {
 		_, err := repo.RetrieveByID(context.Background(), tc.domainID, tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
+
+	cfg, err := repo.RetrieveByID(context.Background(), c.DomainID, id)
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	require.Len(t, cfg.Channels, len(connections))
+	for i, conn := range connections {
+		assert.Equal(t, conn.ChannelID, cfg.Channels[i].ID, fmt.Sprintf("expected channel %s at position %d got %s\n", conn.ChannelID, i, cfg.Channels[i].ID))
+		assert.Equal(t, conn.Role, cfg.Channels[i].Role, fmt.Sprintf("expected role %s of channel %s got %s\n", conn.Role, conn.ChannelID, cfg.Channels[i].Role))
+	}
 }
 
 func TestRetrieveAll(t *testing.T) {
@@ -185,7 +194,7 @@ func TestRetrieveAll(t *testing.T) {
 			c.Channels = nil
 		}
 
-		_, err = repo.Save(context.Background(), c, channels)
+		_, err = repo.Save(context.Background(), c, connections)
 		require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 	}
 	cases := []struct {
@@ -285,7 +294,7 @@ func TestRetrieveByExternalID(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	_, err = repo.Save(context.Background(), c, channels)
+	_, err = repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	cases := []struct {
@@ -323,7 +332,7 @@ func TestUpdate(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	_, err = repo.Save(context.Background(), c, channels)
+	_, err = repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	c.Content = "new content"
@@ -368,7 +377,7 @@ func TestUpdateCert(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	_, err = repo.Save(context.Background(), c, channels)
+	_, err = repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	c.Content = "new content"
@@ -417,7 +426,7 @@ func TestUpdateCert(t *testing.T) {
 	for _, tc := range cases {
 		cfg, err := repo.UpdateCert(context.Background(), tc.domainID, tc.clientID, tc.cert, tc.certKey, tc.ca)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.expectedConfig, cfg))
+		assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expectedConfig, cfg))
 	}
 }
 
@@ -434,7 +443,7 @@ func TestUpdateConnections(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	_, err = repo.Save(context.Background(), c, channels)
+	_, err = repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 	// Use UUID to prevent conflicts.
 	uid, err = uuid.NewV4()
@@ -444,7 +453,7 @@ func TestUpdateConnections(t *testing.T) {
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
 	c.Channels = []bootstrap.Channel{}
-	c2, err := repo.Save(context.Background(), c, []string{channels[0]})
+	c2, err := repo.Save(context.Background(), c, connections[:1])
 	assert.Nil(t, err, fmt.Sprintf("Saving a config expected to succeed: %s.\n", err))
 
 	cases := []struct {
@@ -452,7 +461,7 @@ func TestUpdateConnections(t *testing.T) {
 		domainID    string
 		id          string
 		channels    []bootstrap.Channel
-		connections []string
+		connections []bootstrap.Connection
 		err         error
 	}{
 		{
@@ -460,7 +469,7 @@ func TestUpdateConnections(t *testing.T) {
 			domainID:    config.DomainID,
 			id:          "unknown",
 			channels:    nil,
-			connections: []string{channels[1]},
+			connections: connections[1:],
 			err:         repoerr.ErrNotFound,
 		},
 		{
@@ -468,7 +477,7 @@ func TestUpdateConnections(t *testing.T) {
 			domainID:    config.DomainID,
 			id:          c.ClientID,
 			channels:    nil,
-			connections: []string{channels[1]},
+			connections: connections[1:],
 			err:         nil,
 		},
 		{
@@ -476,7 +485,7 @@ func TestUpdateConnections(t *testing.T) {
 			domainID:    config.DomainID,
 			id:          c2,
 			channels:    nil,
-			connections: channels,
+			connections: connections,
 			err:         nil,
 		},
 		{
@@ -494,6 +503,98 @@ func TestUpdateConnections(t *testing.T) {
 	}
 }
 
+func TestImport(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(context.Background(), repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	c := config
+	// Use UUID to prevent conflicts.
+	uid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+	c.ClientSecret = uid.String()
+	c.ClientID = uid.String()
+	c.ExternalID = uid.String()
+	c.ExternalKey = uid.String()
+	_, err = repo.Save(context.Background(), c, connections)
+	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	newConfig := func() bootstrap.Config {
+		cfg := config
+		cfg.ClientID = testsutil.GenerateUUID(t)
+		cfg.ClientSecret = testsutil.GenerateUUID(t)
+		cfg.ExternalID = testsutil.GenerateUUID(t)
+		cfg.ExternalKey = testsutil.GenerateUUID(t)
+		return cfg
+	}
+
+	created := newConfig()
+	conflicting := newConfig()
+	conflicting.ExternalID = c.ExternalID
+	merged := c
+	merged.Content = "merged content"
+	merged.State = bootstrap.Active
+	unknown := newConfig()
+	uncommitted := newConfig()
+
+	cases := []struct {
+		desc    string
+		configs []bootstrap.ImportedConfig
+		skipped []string
+		saved   []string
+		err     error
+	}{
+		{
+			desc:    "import new config with existing channels",
+			configs: []bootstrap.ImportedConfig{{Config: created, Connections: connections}},
+			skipped: []string{},
+			saved:   []string{created.ClientID},
+			err:     nil,
+		},
+		{
+			desc:    "import config conflicting by external ID",
+			configs: []bootstrap.ImportedConfig{{Config: conflicting, Connections: connections}},
+			skipped: []string{conflicting.ClientID},
+			err:     nil,
+		},
+		{
+			desc:    "import merged config",
+			configs: []bootstrap.ImportedConfig{{Config: merged, Connections: connections[:1], Merge: true}},
+			skipped: []string{},
+			saved:   []string{merged.ClientID},
+			err:     nil,
+		},
+		{
+			desc: "import with non-existing merged config",
+			configs: []bootstrap.ImportedConfig{
+				{Config: uncommitted, Connections: connections},
+				{Config: unknown, Connections: connections, Merge: true},
+			},
+			err: repoerr.ErrUpdateEntity,
+		},
+	}
+	for _, tc := range cases {
+		skipped, err := repo.Import(context.Background(), tc.configs)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.skipped, skipped, fmt.Sprintf("%s: expected skipped %v got %v\n", tc.desc, tc.skipped, skipped))
+		}
+		for _, id := range tc.saved {
+			_, err := repo.RetrieveByID(context.Background(), config.DomainID, id)
+			assert.Nil(t, err, fmt.Sprintf("%s: retrieving imported config expected to succeed: %s.\n", tc.desc, err))
+		}
+	}
+
+	cfg, err := repo.RetrieveByID(context.Background(), config.DomainID, merged.ClientID)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving merged config expected to succeed: %s.\n", err))
+	assert.Equal(t, merged.Content, cfg.Content, fmt.Sprintf("expected content %s got %s\n", merged.Content, cfg.Content))
+	assert.Equal(t, merged.State, cfg.State, fmt.Sprintf("expected state %s got %s\n", merged.State, cfg.State))
+	assert.Len(t, cfg.Channels, 1, fmt.Sprintf("expected 1 channel got %d\n", len(cfg.Channels)))
+
+	_, err = repo.RetrieveByID(context.Background(), config.DomainID, uncommitted.ClientID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected config of failed import to be rolled back, got %s\n", err))
+}
+
 func TestRemove(t *testing.T) {
 	repo := postgres.NewConfigRepository(db, testLog)
 	err := deleteChannels(context.Background(), repo)
@@ -507,7 +608,7 @@ func TestRemove(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	id, err := repo.Save(context.Background(), c, channels)
+	id, err := repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	// Removal works the same for both existing and non-existing
@@ -534,7 +635,7 @@ func TestChangeState(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	saved, err := repo.Save(context.Background(), c, channels)
+	saved, err := repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	cases := []struct {
@@ -590,7 +691,7 @@ func TestListExisting(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	_, err = repo.Save(context.Background(), c, channels)
+	_, err = repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	var chs []bootstrap.Channel
@@ -641,7 +742,7 @@ func TestRemoveClient(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	saved, err := repo.Save(context.Background(), c, channels)
+	saved, err := repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 	for i := 0; i < 2; i++ {
 		err := repo.RemoveClient(context.Background(), saved)
@@ -662,7 +763,7 @@ func TestUpdateChannel(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	_, err = repo.Save(context.Background(), c, channels)
+	_, err = repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	id := c.Channels[0].ID
@@ -699,7 +800,7 @@ func TestRemoveChannel(t *testing.T) {
 	c.ClientID = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
-	_, err = repo.Save(context.Background(), c, channels)
+	_, err = repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	err = repo.RemoveChannel(context.Background(), c.Channels[0].ID)
@@ -724,7 +825,7 @@ func TestConnectClient(t *testing.T) {
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
 	c.State = bootstrap.Inactive
-	saved, err := repo.Save(context.Background(), c, channels)
+	saved, err := repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	wrongID := testsutil.GenerateUUID(&testing.T{})
@@ -797,7 +898,7 @@ func TestConnectClient(t *testing.T) {
 				assert.Equal(t, tc.err, err, fmt.Sprintf("%s: Expected error: %s, got: %s.\n", tc.desc, tc.err, err))
 				cfg, err := repo.RetrieveByID(context.Background(), c.DomainID, c.ClientID)
 				assert.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
-				assert.Equal(t, cfg.State, bootstrap.Active, fmt.Sprintf("expected to be active when a connection is added from %s", cfg))
+				assert.Equal(t, cfg.State, bootstrap.Active, fmt.Sprintf("expected to be active when a connection is added from %v", cfg))
 			} else {
 				_ = repo.ConnectClient(context.Background(), ch.ID, tc.id)
 			}
@@ -805,7 +906,7 @@ func TestConnectClient(t *testing.T) {
 
 		cfg, err := repo.RetrieveByID(context.Background(), c.DomainID, c.ClientID)
 		assert.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
-		assert.Equal(t, cfg.State, bootstrap.Active, fmt.Sprintf("expected to be active when a connection is added from %s", cfg))
+		assert.Equal(t, cfg.State, bootstrap.Active, fmt.Sprintf("expected to be active when a connection is added from %v", cfg))
 	}
 }
 
@@ -823,7 +924,7 @@ func TestDisconnectClient(t *testing.T) {
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
 	c.State = bootstrap.Inactive
-	saved, err := repo.Save(context.Background(), c, channels)
+	saved, err := repo.Save(context.Background(), c, connections)
 	assert.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	wrongID := testsutil.GenerateUUID(&testing.T{})
@@ -898,7 +999,7 @@ func TestDisconnectClient(t *testing.T) {
 
 		cfg, err := repo.RetrieveByID(context.Background(), c.DomainID, c.ClientID)
 		assert.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
-		assert.Equal(t, cfg.State, bootstrap.Inactive, fmt.Sprintf("expected to be inactive when a connection is removed from %s", cfg))
+		assert.Equal(t, cfg.State, bootstrap.Inactive, fmt.Sprintf("expected to be inactive when a connection is removed from %v", cfg))
 	}
 }
 
@@ -103,6 +103,55 @@ func Migration() *migrate.MemoryMigrationSource {
 					`ALTER TABLE IF EXISTS connections ADD FOREIGN KEY (config_id, domain_id) REFERENCES configs (magistrala_client, domain_id) ON DELETE CASCADE ON UPDATE CASCADE`,
 				},
 			},
+			{
+				Id: "configs_7",
+				Up: []string{
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0`,
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS role VARCHAR(64)`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS connections DROP COLUMN IF EXISTS position`,
+					`ALTER TABLE IF EXISTS connections DROP COLUMN IF EXISTS role`,
+				},
+			},
+			{
+				Id: "configs_8",
+				Up: []string{
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS fetched_version BIGINT NOT NULL DEFAULT 0`,
+					`CREATE TABLE IF NOT EXISTS rollouts (
+						id         VARCHAR(36) PRIMARY KEY,
+						domain_id  VARCHAR(256) NOT NULL,
+						content    TEXT,
+						client_ids TEXT[],
+						percentage SMALLINT NOT NULL DEFAULT 0,
+						cohort     TEXT[],
+						state      VARCHAR(16) NOT NULL,
+						created_at TIMESTAMP NOT NULL,
+						created_by VARCHAR(254),
+						updated_at TIMESTAMP,
+						updated_by VARCHAR(254)
+					)`,
+					`CREATE TABLE IF NOT EXISTS rollout_targets (
+						rollout_id       VARCHAR(36) NOT NULL REFERENCES rollouts (id) ON DELETE CASCADE,
+						config_id        TEXT NOT NULL,
+						domain_id        VARCHAR(256) NOT NULL,
+						staged           BOOLEAN NOT NULL DEFAULT FALSE,
+						applied          BOOLEAN NOT NULL DEFAULT FALSE,
+						version          BIGINT NOT NULL DEFAULT 0,
+						previous_content TEXT,
+						skipped          BOOLEAN NOT NULL DEFAULT FALSE,
+						FOREIGN KEY (config_id, domain_id) REFERENCES configs (magistrala_client, domain_id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (rollout_id, config_id)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rollout_targets`,
+					`DROP TABLE IF EXISTS rollouts`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS version`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS fetched_version`,
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,351 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/bootstrap"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+var errSaveTargets = errors.New("failed to insert rollout targets to database")
+
+var _ bootstrap.RolloutRepository = (*rolloutRepository)(nil)
+
+type rolloutRepository struct {
+	db  postgres.Database
+	log *slog.Logger
+}
+
+// NewRolloutRepository instantiates a PostgreSQL implementation of rollout
+// repository.
+func NewRolloutRepository(db postgres.Database, log *slog.Logger) bootstrap.RolloutRepository {
+	return &rolloutRepository{db: db, log: log}
+}
+
+func (rr rolloutRepository) Save(ctx context.Context, r bootstrap.Rollout) (err error) {
+	q := `INSERT INTO rollouts (id, domain_id, content, client_ids, percentage, cohort, state, created_at, created_by)
+	VALUES (:id, :domain_id, :content, :client_ids, :percentage, :cohort, :state, :created_at, :created_by)`
+
+	dbr, err := toDBRollout(r)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	defer func() {
+		if err != nil {
+			err = rr.rollback("Save method", err, tx)
+		}
+	}()
+
+	if _, err := tx.NamedExec(q, dbr); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return repoerr.ErrConflict
+		}
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	// Targets are inserted only for the Clients having Configs in the domain.
+	var targets, staged []string
+	for _, t := range r.Targets {
+		targets = append(targets, t.ClientID)
+		if t.Staged {
+			staged = append(staged, t.ClientID)
+		}
+	}
+	var ids, stagedIDs pgtype.TextArray
+	if err := ids.Set(targets); err != nil {
+		return errors.Wrap(errSaveTargets, err)
+	}
+	if err := stagedIDs.Set(staged); err != nil {
+		return errors.Wrap(errSaveTargets, err)
+	}
+	q = `INSERT INTO rollout_targets (rollout_id, config_id, domain_id, staged)
+		 SELECT $1, magistrala_client, domain_id, magistrala_client = ANY ($4) FROM configs
+		 WHERE domain_id = $2 AND magistrala_client = ANY ($3)`
+	res, err := tx.ExecContext(ctx, q, r.ID, r.DomainID, ids, stagedIDs)
+	if err != nil {
+		return errors.Wrap(errSaveTargets, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errSaveTargets, err)
+	}
+	if cnt != int64(len(targets)) {
+		return errors.Wrap(errSaveTargets, repoerr.ErrNotFound)
+	}
+
+	if err := apply(ctx, tx, r, true); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return tx.Commit()
+}
+
+func (rr rolloutRepository) Retrieve(ctx context.Context, domainID, id string) (bootstrap.Rollout, error) {
+	q := `SELECT id, domain_id, content, client_ids, percentage, cohort, state, created_at, created_by, updated_at, updated_by
+		  FROM rollouts WHERE id = $1 AND domain_id = $2`
+
+	var dbr dbRollout
+	if err := rr.db.QueryRowxContext(ctx, q, id, domainID).StructScan(&dbr); err != nil {
+		if err == sql.ErrNoRows {
+			return bootstrap.Rollout{}, errors.Wrap(repoerr.ErrNotFound, err)
+		}
+		return bootstrap.Rollout{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	// The target is fetched once its Client fetched the Config version set by the Rollout.
+	q = `SELECT rt.config_id, rt.staged, rt.applied, rt.version, rt.applied AND c.fetched_version >= rt.version AS fetched, rt.skipped
+		 FROM rollout_targets rt
+		 INNER JOIN configs c ON c.magistrala_client = rt.config_id AND c.domain_id = rt.domain_id
+		 WHERE rt.rollout_id = $1
+		 ORDER BY rt.config_id`
+	rows, err := rr.db.QueryxContext(ctx, q, id)
+	if err != nil {
+		return bootstrap.Rollout{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	r, err := toRollout(dbr)
+	if err != nil {
+		return bootstrap.Rollout{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	r.Targets = []bootstrap.RolloutTarget{}
+	for rows.Next() {
+		var t dbRolloutTarget
+		if err := rows.StructScan(&t); err != nil {
+			return bootstrap.Rollout{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		r.Targets = append(r.Targets, bootstrap.RolloutTarget{
+			ClientID: t.ClientID,
+			Staged:   t.Staged,
+			Applied:  t.Applied,
+			Version:  t.Version,
+			Fetched:  t.Fetched,
+			Skipped:  t.Skipped,
+		})
+	}
+
+	return r, nil
+}
+
+func (rr rolloutRepository) Promote(ctx context.Context, r bootstrap.Rollout) (err error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	defer func() {
+		if err != nil {
+			err = rr.rollback("Promote method", err, tx)
+		}
+	}()
+
+	if err := apply(ctx, tx, r, false); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	r.State = bootstrap.RolloutPromoted
+	if err := changeRolloutState(ctx, tx, r); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (rr rolloutRepository) RollBack(ctx context.Context, r bootstrap.Rollout) (err error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	defer func() {
+		if err != nil {
+			err = rr.rollback("RollBack method", err, tx)
+		}
+	}()
+
+	// Configs changed since the Rollout was applied to them are skipped, so
+	// that the rollback doesn't overwrite the newer content.
+	q := `UPDATE rollout_targets rt SET skipped = TRUE
+		  FROM configs c
+		  WHERE rt.rollout_id = $1 AND rt.applied AND c.version <> rt.version
+		  AND c.magistrala_client = rt.config_id AND c.domain_id = rt.domain_id`
+	if _, err := tx.ExecContext(ctx, q, r.ID); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	// The restored content gets a new version, so the Clients fetch it again.
+	q = `UPDATE rollout_targets rt SET version = c.version + 1
+		 FROM configs c
+		 WHERE rt.rollout_id = $1 AND rt.applied AND NOT rt.skipped AND c.version = rt.version
+		 AND c.magistrala_client = rt.config_id AND c.domain_id = rt.domain_id`
+	if _, err := tx.ExecContext(ctx, q, r.ID); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	q = `UPDATE configs c SET content = rt.previous_content, version = rt.version
+		 FROM rollout_targets rt
+		 WHERE rt.rollout_id = $1 AND rt.applied AND NOT rt.skipped AND c.version = rt.version - 1
+		 AND c.magistrala_client = rt.config_id AND c.domain_id = rt.domain_id`
+	if _, err := tx.ExecContext(ctx, q, r.ID); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	r.State = bootstrap.RolloutRolledBack
+	if err := changeRolloutState(ctx, tx, r); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (rr rolloutRepository) rollback(content string, defErr error, tx *sqlx.Tx) error {
+	if err := tx.Rollback(); err != nil {
+		return errors.Wrap(defErr, errors.Wrap(errors.New("failed to rollback at "+content), err))
+	}
+
+	return defErr
+}
+
+// apply applies the Rollout content to the Configs of the targets which it
+// wasn't applied to yet, keeping their previous content. If staged is set,
+// only the staged targets are applied.
+func apply(ctx context.Context, tx *sqlx.Tx, r bootstrap.Rollout, staged bool) error {
+	q := `UPDATE rollout_targets rt SET applied = TRUE, previous_content = c.content, version = c.version + 1
+		  FROM configs c
+		  WHERE rt.rollout_id = $1 AND NOT rt.applied AND (rt.staged OR NOT $2)
+		  AND c.magistrala_client = rt.config_id AND c.domain_id = rt.domain_id
+		  RETURNING rt.config_id`
+	rows, err := tx.QueryxContext(ctx, q, r.ID, staged)
+	if err != nil {
+		return err
+	}
+	var applied []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		applied = append(applied, id)
+	}
+	rows.Close()
+	if len(applied) == 0 {
+		return nil
+	}
+
+	var ids pgtype.TextArray
+	if err := ids.Set(applied); err != nil {
+		return err
+	}
+	q = `UPDATE configs c SET content = r.content, version = rt.version
+		 FROM rollout_targets rt
+		 INNER JOIN rollouts r ON r.id = rt.rollout_id
+		 WHERE rt.rollout_id = $1 AND rt.config_id = ANY ($2)
+		 AND c.magistrala_client = rt.config_id AND c.domain_id = rt.domain_id`
+	_, err = tx.ExecContext(ctx, q, r.ID, ids)
+
+	return err
+}
+
+func changeRolloutState(ctx context.Context, tx *sqlx.Tx, r bootstrap.Rollout) error {
+	q := `UPDATE rollouts SET state = :state, updated_at = :updated_at, updated_by = :updated_by
+		  WHERE id = :id AND domain_id = :domain_id`
+	dbr, err := toDBRollout(r)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	res, err := tx.NamedExecContext(ctx, q, dbr)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbRollout struct {
+	ID         string           `db:"id"`
+	DomainID   string           `db:"domain_id"`
+	Content    sql.NullString   `db:"content"`
+	ClientIDs  pgtype.TextArray `db:"client_ids"`
+	Percentage uint             `db:"percentage"`
+	Cohort     pgtype.TextArray `db:"cohort"`
+	State      string           `db:"state"`
+	CreatedAt  time.Time        `db:"created_at"`
+	CreatedBy  sql.NullString   `db:"created_by"`
+	UpdatedAt  sql.NullTime     `db:"updated_at"`
+	UpdatedBy  sql.NullString   `db:"updated_by"`
+}
+
+type dbRolloutTarget struct {
+	ClientID string `db:"config_id"`
+	Staged   bool   `db:"staged"`
+	Applied  bool   `db:"applied"`
+	Version  uint64 `db:"version"`
+	Fetched  bool   `db:"fetched"`
+	Skipped  bool   `db:"skipped"`
+}
+
+func toDBRollout(r bootstrap.Rollout) (dbRollout, error) {
+	dbr := dbRollout{
+		ID:         r.ID,
+		DomainID:   r.DomainID,
+		Content:    nullString(r.Content),
+		Percentage: r.Percentage,
+		State:      string(r.State),
+		CreatedAt:  r.CreatedAt,
+		CreatedBy:  nullString(r.CreatedBy),
+		UpdatedAt:  nullTime(r.UpdatedAt),
+		UpdatedBy:  nullString(r.UpdatedBy),
+	}
+	if err := dbr.ClientIDs.Set(r.ClientIDs); err != nil {
+		return dbRollout{}, err
+	}
+	if err := dbr.Cohort.Set(r.Cohort); err != nil {
+		return dbRollout{}, err
+	}
+
+	return dbr, nil
+}
+
+func toRollout(dbr dbRollout) (bootstrap.Rollout, error) {
+	r := bootstrap.Rollout{
+		ID:         dbr.ID,
+		DomainID:   dbr.DomainID,
+		Content:    dbr.Content.String,
+		Percentage: dbr.Percentage,
+		State:      bootstrap.RolloutState(dbr.State),
+		CreatedAt:  dbr.CreatedAt,
+		CreatedBy:  dbr.CreatedBy.String,
+		UpdatedAt:  dbr.UpdatedAt.Time,
+		UpdatedBy:  dbr.UpdatedBy.String,
+	}
+	if err := dbr.ClientIDs.AssignTo(&r.ClientIDs); err != nil {
+		return bootstrap.Rollout{}, err
+	}
+	if err := dbr.Cohort.AssignTo(&r.Cohort); err != nil {
+		return bootstrap.Rollout{}, err
+	}
+
+	return r, nil
+}
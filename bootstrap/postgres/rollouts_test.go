@@ -0,0 +1,140 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/bootstrap"
+	"github.com/absmach/magistrala/bootstrap/postgres"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollout(t *testing.T) {
+	configs := postgres.NewConfigRepository(db, testLog)
+	repo := postgres.NewRolloutRepository(db, testLog)
+
+	domainID := testsutil.GenerateUUID(t)
+	var clientIDs []string
+	for i := 0; i < 2; i++ {
+		c := config
+		c.ClientID = testsutil.GenerateUUID(t)
+		c.ExternalID = testsutil.GenerateUUID(t)
+		c.DomainID = domainID
+		c.Channels = []bootstrap.Channel{}
+		_, err := configs.Save(context.Background(), c, nil)
+		require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+		clientIDs = append(clientIDs, c.ClientID)
+	}
+
+	rollout := bootstrap.Rollout{
+		ID:        testsutil.GenerateUUID(t),
+		DomainID:  domainID,
+		Content:   "rollout content",
+		ClientIDs: clientIDs,
+		Cohort:    clientIDs[:1],
+		State:     bootstrap.RolloutStaged,
+		Targets: []bootstrap.RolloutTarget{
+			{ClientID: clientIDs[0], Staged: true},
+			{ClientID: clientIDs[1]},
+		},
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: testsutil.GenerateUUID(t),
+	}
+	missing := rollout
+	missing.ID = testsutil.GenerateUUID(t)
+	missing.Targets = []bootstrap.RolloutTarget{{ClientID: testsutil.GenerateUUID(t), Staged: true}}
+
+	err := repo.Save(context.Background(), missing)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("save rollout with missing config: expected %s got %s\n", repoerr.ErrNotFound, err))
+
+	err = repo.Save(context.Background(), rollout)
+	require.Nil(t, err, fmt.Sprintf("Saving rollout expected to succeed: %s.\n", err))
+
+	err = repo.Save(context.Background(), rollout)
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("save existing rollout: expected %s got %s\n", repoerr.ErrConflict, err))
+
+	staged, err := configs.RetrieveByID(context.Background(), domainID, clientIDs[0])
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	assert.Equal(t, rollout.Content, staged.Content, fmt.Sprintf("staged config: expected content %s got %s\n", rollout.Content, staged.Content))
+	assert.Equal(t, uint64(1), staged.Version, fmt.Sprintf("staged config: expected version 1 got %d\n", staged.Version))
+
+	remaining, err := configs.RetrieveByID(context.Background(), domainID, clientIDs[1])
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	assert.Equal(t, config.Content, remaining.Content, fmt.Sprintf("remaining config: expected content %s got %s\n", config.Content, remaining.Content))
+
+	err = configs.UpdateFetchedVersion(context.Background(), testsutil.GenerateUUID(t), clientIDs[0], staged.Version)
+	require.Nil(t, err, fmt.Sprintf("Updating fetched version of other domain expected to succeed: %s.\n", err))
+
+	r, err := repo.Retrieve(context.Background(), domainID, rollout.ID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving rollout expected to succeed: %s.\n", err))
+	for _, target := range r.Targets {
+		assert.False(t, target.Fetched, fmt.Sprintf("retrieve staged rollout: expected config %s not to be fetched by other domain\n", target.ClientID))
+	}
+
+	err = configs.UpdateFetchedVersion(context.Background(), domainID, clientIDs[0], staged.Version)
+	require.Nil(t, err, fmt.Sprintf("Updating fetched version expected to succeed: %s.\n", err))
+
+	r, err = repo.Retrieve(context.Background(), domainID, rollout.ID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving rollout expected to succeed: %s.\n", err))
+	assert.Equal(t, rollout.ClientIDs, r.ClientIDs, fmt.Sprintf("retrieve staged rollout: expected client IDs %v got %v\n", rollout.ClientIDs, r.ClientIDs))
+	assert.Equal(t, rollout.Cohort, r.Cohort, fmt.Sprintf("retrieve staged rollout: expected cohort %v got %v\n", rollout.Cohort, r.Cohort))
+	expected := []bootstrap.RolloutTarget{
+		{ClientID: clientIDs[0], Staged: true, Applied: true, Version: 1, Fetched: true},
+		{ClientID: clientIDs[1]},
+	}
+	assert.ElementsMatch(t, expected, r.Targets, fmt.Sprintf("retrieve staged rollout: expected %v got %v\n", expected, r.Targets))
+
+	_, err = repo.Retrieve(context.Background(), domainID, missing.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve non-existing rollout: expected %s got %s\n", repoerr.ErrNotFound, err))
+
+	rollout.UpdatedAt = time.Now().UTC()
+	err = repo.Promote(context.Background(), rollout)
+	require.Nil(t, err, fmt.Sprintf("Promoting rollout expected to succeed: %s.\n", err))
+
+	r, err = repo.Retrieve(context.Background(), domainID, rollout.ID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving rollout expected to succeed: %s.\n", err))
+	assert.Equal(t, bootstrap.RolloutPromoted, r.State, fmt.Sprintf("promote rollout: expected %s got %s\n", bootstrap.RolloutPromoted, r.State))
+	expected = []bootstrap.RolloutTarget{
+		{ClientID: clientIDs[0], Staged: true, Applied: true, Version: 1, Fetched: true},
+		{ClientID: clientIDs[1], Applied: true, Version: 1},
+	}
+	assert.ElementsMatch(t, expected, r.Targets, fmt.Sprintf("retrieve promoted rollout: expected %v got %v\n", expected, r.Targets))
+
+	edited := config
+	edited.ClientID = clientIDs[1]
+	edited.DomainID = domainID
+	edited.Content = "edited content"
+	err = configs.Update(context.Background(), edited)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	err = repo.RollBack(context.Background(), rollout)
+	require.Nil(t, err, fmt.Sprintf("Rolling back rollout expected to succeed: %s.\n", err))
+
+	restored, err := configs.RetrieveByID(context.Background(), domainID, clientIDs[0])
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	assert.Equal(t, config.Content, restored.Content, fmt.Sprintf("rolled back config: expected content %s got %s\n", config.Content, restored.Content))
+	assert.Equal(t, uint64(2), restored.Version, fmt.Sprintf("rolled back config: expected version 2 got %d\n", restored.Version))
+
+	skipped, err := configs.RetrieveByID(context.Background(), domainID, clientIDs[1])
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	assert.Equal(t, edited.Content, skipped.Content, fmt.Sprintf("skipped config: expected content %s got %s\n", edited.Content, skipped.Content))
+	assert.Equal(t, uint64(2), skipped.Version, fmt.Sprintf("skipped config: expected version 2 got %d\n", skipped.Version))
+
+	r, err = repo.Retrieve(context.Background(), domainID, rollout.ID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving rollout expected to succeed: %s.\n", err))
+	assert.Equal(t, bootstrap.RolloutRolledBack, r.State, fmt.Sprintf("roll back rollout: expected %s got %s\n", bootstrap.RolloutRolledBack, r.State))
+	expected = []bootstrap.RolloutTarget{
+		{ClientID: clientIDs[0], Staged: true, Applied: true, Version: 2},
+		{ClientID: clientIDs[1], Applied: true, Version: 1, Skipped: true},
+	}
+	assert.ElementsMatch(t, expected, r.Targets, fmt.Sprintf("retrieve rolled back rollout: expected %v got %v\n", expected, r.Targets))
+}
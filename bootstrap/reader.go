@@ -23,6 +23,7 @@ type bootstrapRes struct {
 	ClientCert   string       `json:"client_cert,omitempty"`
 	ClientKey    string       `json:"client_key,omitempty"`
 	CACert       string       `json:"ca_cert,omitempty"`
+	Version      uint64       `json:"version,omitempty"`
 }
 
 type channelRes struct {
@@ -67,6 +68,7 @@ func (r reader) ReadConfig(cfg Config, secure bool) (interface{}, error) {
 		ClientCert:   cfg.ClientCert,
 		ClientKey:    cfg.ClientKey,
 		CACert:       cfg.CACert,
+		Version:      cfg.Version,
 	}
 	if secure {
 		b, err := json.Marshal(res)
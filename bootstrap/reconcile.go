@@ -0,0 +1,41 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import "time"
+
+// DriftType represents the kind of difference between the stored Config and
+// the actual state of its Client and Channels.
+type DriftType string
+
+const (
+	// ClientMissing indicates that the Config Client no longer exists.
+	// Repair removes the Config.
+	ClientMissing DriftType = "client_missing"
+	// ChannelMissing indicates that the Config Channel no longer exists.
+	// Repair removes the Channel from all Configs.
+	ChannelMissing DriftType = "channel_missing"
+	// ConnectionMissing indicates that the Client of the active Config was
+	// not connected to the Config Channel. Repair connects them.
+	ConnectionMissing DriftType = "connection_missing"
+	// ConnectionUnexpected indicates that the Client of the inactive Config
+	// was connected to the Config Channel. Repair disconnects them.
+	ConnectionUnexpected DriftType = "connection_unexpected"
+)
+
+// Drift represents a single difference found during reconciliation.
+type Drift struct {
+	Type      DriftType `json:"type"`
+	ClientID  string    `json:"client_id"`
+	ChannelID string    `json:"channel_id,omitempty"`
+	Repaired  bool      `json:"repaired"`
+}
+
+// ReconcileReport contains the drifts found while comparing the stored
+// Configs against the Clients service.
+type ReconcileReport struct {
+	CreatedAt time.Time `json:"created_at"`
+	Checked   uint64    `json:"checked"`
+	Drifts    []Drift   `json:"drifts"`
+}
@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// ErrRollout indicates malformed Rollout.
+var ErrRollout = errors.New("invalid bootstrap configuration rollout")
+
+// RolloutState represents the stage of the Rollout.
+type RolloutState string
+
+const (
+	// RolloutStaged Rollout content is applied to the staged Configs only.
+	RolloutStaged RolloutState = "staged"
+	// RolloutPromoted Rollout content is applied to all the target Configs.
+	RolloutPromoted RolloutState = "promoted"
+	// RolloutRolledBack Configs the Rollout was applied to are restored to
+	// their previous content.
+	RolloutRolledBack RolloutState = "rolled_back"
+)
+
+// Rollout represents the staged change of the Config content of a fleet of
+// Clients. The content is first applied to the Configs of the stage, which
+// is either the percentage of the target Clients or the named cohort of
+// them. Once the staged Clients fetch their new Configs, the Rollout is
+// promoted to all the target Clients, or rolled back.
+type Rollout struct {
+	ID       string `json:"id"`
+	DomainID string `json:"domain_id,omitempty"`
+	Content  string `json:"content"`
+	// ClientIDs are the target Clients. If empty, all the Clients with Configs
+	// in the domain are targeted.
+	ClientIDs []string `json:"client_ids,omitempty"`
+	// Percentage of the target Clients which are staged.
+	Percentage uint `json:"percentage,omitempty"`
+	// Cohort is the list of the staged Clients. It's mutually exclusive with
+	// the percentage.
+	Cohort    []string        `json:"cohort,omitempty"`
+	State     RolloutState    `json:"state"`
+	Targets   []RolloutTarget `json:"targets"`
+	CreatedAt time.Time       `json:"created_at"`
+	CreatedBy string          `json:"created_by,omitempty"`
+	UpdatedAt time.Time       `json:"updated_at,omitempty"`
+	UpdatedBy string          `json:"updated_by,omitempty"`
+}
+
+// RolloutTarget represents the progress of the Rollout for a single Client.
+// Version is the Config version set by the Rollout, and Fetched reports
+// whether the Client fetched its Config of that version. Skipped reports
+// that the Config was changed after the Rollout was applied to it, so the
+// rollback didn't restore its previous content.
+type RolloutTarget struct {
+	ClientID string `json:"client_id"`
+	Staged   bool   `json:"staged"`
+	Applied  bool   `json:"applied"`
+	Version  uint64 `json:"version,omitempty"`
+	Fetched  bool   `json:"fetched"`
+	Skipped  bool   `json:"skipped,omitempty"`
+}
+
+// Validate checks that exactly one of the percentage and the cohort is set.
+func (r Rollout) Validate() error {
+	switch {
+	case r.Percentage > 0 && len(r.Cohort) > 0:
+		return ErrRollout
+	case r.Percentage > 100:
+		return ErrRollout
+	case r.Percentage == 0 && len(r.Cohort) == 0:
+		return ErrRollout
+	}
+
+	return nil
+}
+
+// RolloutRepository specifies a Rollout persistence API. Applying the Rollout
+// content to the Config increments the Config version.
+//
+//go:generate mockery --name RolloutRepository --output=./mocks --filename rollouts.go --quiet --note "Copyright (c) Abstract Machines"
+type RolloutRepository interface {
+	// Save persists the Rollout with its targets, and applies the Rollout
+	// content to the Configs of the staged targets.
+	Save(ctx context.Context, r Rollout) error
+
+	// Retrieve retrieves the Rollout with the progress of its targets.
+	Retrieve(ctx context.Context, domainID, id string) (Rollout, error)
+
+	// Promote applies the Rollout content to the Configs of the remaining
+	// targets and changes the Rollout state to promoted.
+	Promote(ctx context.Context, r Rollout) error
+
+	// RollBack restores the previous content of the Configs the Rollout was
+	// applied to and changes the Rollout state to rolled back. The Configs
+	// changed since the Rollout was applied to them are marked as skipped.
+	RollBack(ctx context.Context, r Rollout) error
+}
@@ -4,10 +4,18 @@
 package bootstrap
 
 import (
+	"cmp"
 	"context"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/rand"
 	"encoding/hex"
+	"encoding/pem"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"slices"
+	"time"
 
 	"github.com/absmach/supermq"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
@@ -51,8 +59,30 @@ var (
 	errConnectionChannels = errors.New("failed to check channels connections")
 	errClientNotFound     = errors.New("failed to find client")
 	errUpdateCert         = errors.New("failed to update cert")
+	errIssueCert          = errors.New("failed to issue cert")
+	errRevokeCert         = errors.New("failed to revoke cert")
+	errExportConfigs      = errors.New("failed to export bootstrap configurations")
+	errImportConfigs      = errors.New("failed to import bootstrap configurations")
+	errBundleVersion      = errors.New("unsupported bootstrap configurations bundle version")
+	errBundleKey          = errors.New("missing key for bootstrap configurations bundle")
+	errConflictStrategy   = errors.New("invalid import conflict strategy")
+	errReconcile          = errors.New("failed to reconcile bootstrap configurations")
+	errFetchedVersion     = errors.New("failed to record fetched bootstrap configuration version")
+	errCreateRollout      = errors.New("failed to create bootstrap configuration rollout")
+	errViewRollout        = errors.New("failed to view bootstrap configuration rollout")
+	errPromoteRollout     = errors.New("failed to promote bootstrap configuration rollout")
+	errRollBackRollout    = errors.New("failed to roll back bootstrap configuration rollout")
+	errRolloutState       = errors.New("invalid bootstrap configuration rollout state")
+	errRolloutCohort      = errors.New("rollout cohort contains clients which are not targeted")
+	errRolloutTargets     = errors.New("rollout has no target clients")
 )
 
+// exportPageSize is the number of Configs retrieved at once on export.
+const exportPageSize = 100
+
+// DefCertTTL is the default validity of the issued client certificates.
+const DefCertTTL = "2400h"
+
 var _ Service = (*bootstrapService)(nil)
 
 // Service specifies an API that must be fulfilled by the domain service
@@ -73,6 +103,12 @@ type Service interface {
 	// A non-nil error is returned to indicate operation failure.
 	UpdateCert(ctx context.Context, session smqauthn.Session, clientID, clientCert, clientKey, caCert string) (Config, error)
 
+	// IssueCert issues the Config Client certificate of the given validity
+	// using the certs service, and stores it in the Config. The previously
+	// issued certificates of the Client are revoked. The Config CA certificate
+	// is kept.
+	IssueCert(ctx context.Context, session smqauthn.Session, token, clientID, ttl string) (Config, error)
+
 	// UpdateConnections updates list of Channels related to given Config.
 	UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error
 
@@ -81,13 +117,49 @@ type Service interface {
 	List(ctx context.Context, session smqauthn.Session, filter Filter, offset, limit uint64) (ConfigsPage, error)
 
 	// Remove removes Config with specified token that belongs to the user identified by the given token.
-	Remove(ctx context.Context, session smqauthn.Session, id string) error
+	// Certificates of the Config Client are revoked before the Config is removed.
+	Remove(ctx context.Context, session smqauthn.Session, token, id string) error
 
 	// Bootstrap returns Config to the Client with provided external ID using external key.
+	// The returned Config version is recorded as fetched by the Client.
 	Bootstrap(ctx context.Context, externalKey, externalID string, secure bool) (Config, error)
 
 	// ChangeState changes state of the Client with given client ID and domain ID.
-	ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state State) error
+	// The Client is connected to the Config Channels in the Config order, or
+	// disconnected in the reverse order, and the resulting connections are
+	// returned. If connecting fails, the connections made so far are rolled back.
+	ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state State) ([]Connection, error)
+
+	// ExportConfigs returns the bundle of Configs matching the filter. Config
+	// secrets are encrypted with the key, which is required.
+	ExportConfigs(ctx context.Context, session smqauthn.Session, filter Filter, key []byte) (Bundle, error)
+
+	// ImportConfigs restores Configs from the bundle, decrypting Config secrets
+	// with the key if the bundle is encrypted. Configs that already exist are
+	// skipped or merged depending on the conflict strategy. Configs whose
+	// Clients can't be retrieved are skipped as well. Configs are imported
+	// all at once, so a failed import changes nothing.
+	ImportConfigs(ctx context.Context, session smqauthn.Session, token string, bundle Bundle, key []byte, strategy ConflictStrategy) (ImportSummary, error)
+
+	// Reconcile compares the stored Configs against their Clients and Channels
+	// and reports the drifts. If repair is set, the drifts are also repaired.
+	// Since connections can't be read, connection drifts are found only
+	// while repairing.
+	Reconcile(ctx context.Context, session smqauthn.Session, token string, repair bool) (ReconcileReport, error)
+
+	// CreateRollout starts the Rollout of the Config content to the target
+	// Clients by applying it to the Configs of the staged Clients.
+	CreateRollout(ctx context.Context, session smqauthn.Session, r Rollout) (Rollout, error)
+
+	// ViewRollout returns the Rollout with the progress of its targets.
+	ViewRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error)
+
+	// PromoteRollout applies the staged Rollout content to all the target Clients.
+	PromoteRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error)
+
+	// RollBackRollout restores the previous content of the Configs the staged
+	// or promoted Rollout was applied to.
+	RollBackRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error)
 
 	// Methods RemoveConfig, UpdateChannel, and RemoveChannel are used as
 	// handlers for events. That's why these methods surpass ownership check.
@@ -121,15 +193,17 @@ type ConfigReader interface {
 type bootstrapService struct {
 	policies   policies.Service
 	configs    ConfigRepository
+	rollouts   RolloutRepository
 	sdk        mgsdk.SDK
 	encKey     []byte
 	idProvider supermq.IDProvider
 }
 
 // New returns new Bootstrap service.
-func New(policyService policies.Service, configs ConfigRepository, sdk mgsdk.SDK, encKey []byte, idp supermq.IDProvider) Service {
+func New(policyService policies.Service, configs ConfigRepository, rollouts RolloutRepository, sdk mgsdk.SDK, encKey []byte, idp supermq.IDProvider) Service {
 	return &bootstrapService{
 		configs:    configs,
+		rollouts:   rollouts,
 		sdk:        sdk,
 		policies:   policyService,
 		encKey:     encKey,
@@ -146,6 +220,8 @@ func (bs bootstrapService) Add(ctx context.Context, session smqauthn.Session, to
 		return Config{}, errors.Wrap(errCheckChannels, err)
 	}
 
+	// Keep the requested order and roles of the Channels.
+	connections := toConnections(cfg.Channels)
 	cfg.Channels, err = bs.connectionChannels(toConnect, bs.toIDList(existing), session.DomainID, token)
 	if err != nil {
 		return Config{}, errors.Wrap(errConnectionChannels, err)
@@ -168,7 +244,7 @@ func (bs bootstrapService) Add(ctx context.Context, session smqauthn.Session, to
 	cfg.State = Inactive
 	cfg.ClientSecret = mgClient.Credentials.Secret
 
-	saved, err := bs.configs.Save(ctx, cfg, toConnect)
+	saved, err := bs.configs.Save(ctx, cfg, connections)
 	if err != nil {
 		// If id is empty, then a new client has been created function - bs.client(id, token)
 		// So, on bootstrap config save error , delete the newly created client.
@@ -181,7 +257,7 @@ func (bs bootstrapService) Add(ctx context.Context, session smqauthn.Session, to
 	}
 
 	cfg.ClientID = saved
-	cfg.Channels = append(cfg.Channels, existing...)
+	cfg.Channels = orderChannels(append(cfg.Channels, existing...), connections)
 
 	return cfg, nil
 }
@@ -210,6 +286,45 @@ func (bs bootstrapService) UpdateCert(ctx context.Context, session smqauthn.Sess
 	return cfg, nil
 }
 
+func (bs bootstrapService) IssueCert(ctx context.Context, session smqauthn.Session, token, clientID, ttl string) (Config, error) {
+	cfg, err := bs.configs.RetrieveByID(ctx, session.DomainID, clientID)
+	if err != nil {
+		return Config{}, errors.Wrap(errIssueCert, err)
+	}
+	if ttl == "" {
+		ttl = DefCertTTL
+	}
+
+	// Certificates are revoked per Client, so the previous certificates are
+	// revoked before the new one is issued.
+	if cfg.ClientCert != "" {
+		if err := bs.revokeCert(session.DomainID, token, clientID); err != nil {
+			return Config{}, errors.Wrap(errIssueCert, err)
+		}
+	}
+	cert, sdkErr := bs.sdk.IssueCert(clientID, ttl, session.DomainID, token)
+	if sdkErr != nil {
+		return Config{}, errors.Wrap(errIssueCert, sdkErr)
+	}
+	cert, sdkErr = bs.sdk.ViewCert(cert.SerialNumber, session.DomainID, token)
+	if sdkErr != nil {
+		return Config{}, errors.Wrap(errIssueCert, sdkErr)
+	}
+
+	clientCert, caCert := splitChain(cert.Certificate)
+	if caCert == "" {
+		// The certs service returned no issuer chain, so the configured CA is kept.
+		caCert = cfg.CACert
+	}
+
+	cfg, err = bs.configs.UpdateCert(ctx, session.DomainID, clientID, clientCert, cert.Key, caCert)
+	if err != nil {
+		return Config{}, errors.Wrap(errUpdateCert, err)
+	}
+
+	return cfg, nil
+}
+
 func (bs bootstrapService) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error {
 	cfg, err := bs.configs.RetrieveByID(ctx, session.DomainID, id)
 	if err != nil {
@@ -229,11 +344,24 @@ func (bs bootstrapService) UpdateConnections(ctx context.Context, session smqaut
 		return errors.Wrap(errUpdateConnections, err)
 	}
 
+	// Channels which stay connected keep their roles.
+	roles := make(map[string]string, len(cfg.Channels))
+	for _, c := range cfg.Channels {
+		roles[c.ID] = c.Role
+	}
+	conns := make([]Connection, 0, len(connections))
+	for _, c := range connections {
+		conns = append(conns, Connection{ChannelID: c, Role: roles[c]})
+	}
+
 	cfg.Channels = channels
-	var connect, disconnect []string
+	var connect []Connection
+	var disconnect []string
 
 	if cfg.State == Active {
-		connect = add
+		for _, c := range add {
+			connect = append(connect, Connection{ChannelID: c})
+		}
 		disconnect = remove
 	}
 
@@ -246,17 +374,10 @@ func (bs bootstrapService) UpdateConnections(ctx context.Context, session smqaut
 		}
 	}
 
-	for _, c := range connect {
-		conIDs := mgsdk.Connection{
-			ChannelIDs: []string{c},
-			ClientIDs:  []string{id},
-			Types:      []string{"Publish", "Subscribe"},
-		}
-		if err := bs.sdk.Connect(conIDs, session.DomainID, token); err != nil {
-			return ErrClients
-		}
+	if _, err := bs.connect(session.DomainID, token, id, connect); err != nil {
+		return err
 	}
-	if err := bs.configs.UpdateConnections(ctx, session.DomainID, id, channels, connections); err != nil {
+	if err := bs.configs.UpdateConnections(ctx, session.DomainID, id, channels, conns); err != nil {
 		return errors.Wrap(errUpdateConnections, err)
 	}
 	return nil
@@ -298,7 +419,16 @@ func (bs bootstrapService) List(ctx context.Context, session smqauthn.Session, f
 	return bs.configs.RetrieveAll(ctx, session.DomainID, clientIDs, filter, offset, limit), nil
 }
 
-func (bs bootstrapService) Remove(ctx context.Context, session smqauthn.Session, id string) error {
+func (bs bootstrapService) Remove(ctx context.Context, session smqauthn.Session, token, id string) error {
+	cfg, err := bs.configs.RetrieveByID(ctx, session.DomainID, id)
+	switch {
+	case err == nil && cfg.ClientCert != "":
+		if err := bs.revokeCert(session.DomainID, token, id); err != nil {
+			return errors.Wrap(errRemoveBootstrap, err)
+		}
+	case err != nil && !errors.Contains(err, repoerr.ErrNotFound):
+		return errors.Wrap(errRemoveBootstrap, err)
+	}
 	if err := bs.configs.Remove(ctx, session.DomainID, id); err != nil {
 		return errors.Wrap(errRemoveBootstrap, err)
 	}
@@ -320,45 +450,435 @@ func (bs bootstrapService) Bootstrap(ctx context.Context, externalKey, externalI
 	if cfg.ExternalKey != externalKey {
 		return Config{}, ErrExternalKey
 	}
+	if cfg.FetchedVersion < cfg.Version {
+		if err := bs.configs.UpdateFetchedVersion(ctx, cfg.DomainID, cfg.ClientID, cfg.Version); err != nil {
+			return Config{}, errors.Wrap(errFetchedVersion, err)
+		}
+		cfg.FetchedVersion = cfg.Version
+	}
 
 	return cfg, nil
 }
 
-func (bs bootstrapService) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state State) error {
+func (bs bootstrapService) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state State) ([]Connection, error) {
 	cfg, err := bs.configs.RetrieveByID(ctx, session.DomainID, id)
 	if err != nil {
-		return errors.Wrap(errChangeState, err)
+		return nil, errors.Wrap(errChangeState, err)
 	}
 
 	if cfg.State == state {
-		return nil
+		return []Connection{}, nil
 	}
 
+	conns := []Connection{}
 	switch state {
 	case Active:
-		for _, c := range cfg.Channels {
-			if err := bs.sdk.ConnectClients(c.ID, []string{cfg.ClientID}, []string{"Publish", "Subscribe"}, session.DomainID, token); err != nil {
-				// Ignore conflict errors as they indicate the connection already exists.
-				if errors.Contains(err, svcerr.ErrConflict) {
-					continue
-				}
-				return ErrClients
-			}
+		if conns, err = bs.connect(session.DomainID, token, cfg.ClientID, toConnections(cfg.Channels)); err != nil {
+			return conns, err
 		}
 	case Inactive:
-		for _, c := range cfg.Channels {
-			if err := bs.sdk.DisconnectClients(c.ID, []string{cfg.ClientID}, []string{"Publish", "Subscribe"}, session.DomainID, token); err != nil {
-				if errors.Contains(err, repoerr.ErrNotFound) {
+		if conns, err = bs.disconnect(session.DomainID, token, cfg.ClientID, toConnections(cfg.Channels)); err != nil {
+			return conns, err
+		}
+	}
+	if err := bs.configs.ChangeState(ctx, session.DomainID, id, state); err != nil {
+		return conns, errors.Wrap(errChangeState, err)
+	}
+	return conns, nil
+}
+
+// Method connect connects the Client to the Channels in the order of the
+// connections. If a connection fails, the connections made so far are
+// disconnected in the reverse order. Connections which already existed are
+// reported as connected, but they are not rolled back.
+func (bs bootstrapService) connect(domainID, token, clientID string, connections []Connection) ([]Connection, error) {
+	var made []int
+	for i, c := range connections {
+		err := bs.sdk.ConnectClients(c.ChannelID, []string{clientID}, []string{"Publish", "Subscribe"}, domainID, token)
+		switch {
+		case err == nil:
+			made = append(made, i)
+		case errors.Contains(err, svcerr.ErrConflict):
+			// Ignore conflict errors as they indicate the connection already exists.
+		default:
+			for j := len(made) - 1; j >= 0; j-- {
+				c := connections[made[j]]
+				if err := bs.sdk.DisconnectClients(c.ChannelID, []string{clientID}, []string{"Publish", "Subscribe"}, domainID, token); err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+					// The connection couldn't be rolled back, so it's still reported.
 					continue
 				}
-				return ErrClients
+				connections[made[j]].Connected = false
 			}
+			return connections, errors.Wrap(ErrClients, err)
 		}
+		connections[i].Connected = true
 	}
-	if err := bs.configs.ChangeState(ctx, session.DomainID, id, state); err != nil {
-		return errors.Wrap(errChangeState, err)
+
+	return connections, nil
+}
+
+// Method disconnect disconnects the Client from the Channels in the reverse
+// order of the connections.
+func (bs bootstrapService) disconnect(domainID, token, clientID string, connections []Connection) ([]Connection, error) {
+	for i := range connections {
+		connections[i].Connected = true
 	}
-	return nil
+	for i := len(connections) - 1; i >= 0; i-- {
+		c := connections[i]
+		if err := bs.sdk.DisconnectClients(c.ChannelID, []string{clientID}, []string{"Publish", "Subscribe"}, domainID, token); err != nil {
+			if !errors.Contains(err, repoerr.ErrNotFound) {
+				return connections, errors.Wrap(ErrClients, err)
+			}
+		}
+		connections[i].Connected = false
+	}
+
+	return connections, nil
+}
+
+func (bs bootstrapService) ExportConfigs(ctx context.Context, session smqauthn.Session, filter Filter, key []byte) (Bundle, error) {
+	// Bundles carry Client secrets and keys, so they're never exported in plain text.
+	if len(key) == 0 {
+		return Bundle{}, errors.Wrap(svcerr.ErrMalformedEntity, errBundleKey)
+	}
+	bundle := Bundle{
+		Version:   BundleVersion,
+		Encrypted: true,
+		CreatedAt: time.Now().UTC(),
+		Configs:   []Config{},
+	}
+
+	for offset := uint64(0); ; offset += exportPageSize {
+		page, err := bs.List(ctx, session, filter, offset, exportPageSize)
+		if err != nil {
+			return Bundle{}, errors.Wrap(errExportConfigs, err)
+		}
+		for _, c := range page.Configs {
+			// Retrieve the whole Config, since listing omits certificates and channels.
+			cfg, err := bs.configs.RetrieveByID(ctx, session.DomainID, c.ClientID)
+			if err != nil {
+				return Bundle{}, errors.Wrap(errExportConfigs, err)
+			}
+			if cfg, err = encryptSecrets(key, cfg); err != nil {
+				return Bundle{}, errors.Wrap(errExportConfigs, err)
+			}
+			bundle.Configs = append(bundle.Configs, cfg)
+		}
+		if offset+exportPageSize >= page.Total || len(page.Configs) == 0 {
+			break
+		}
+	}
+
+	return bundle, nil
+}
+
+func (bs bootstrapService) ImportConfigs(ctx context.Context, session smqauthn.Session, token string, bundle Bundle, key []byte, strategy ConflictStrategy) (ImportSummary, error) {
+	if bundle.Version != BundleVersion {
+		return ImportSummary{}, errors.Wrap(svcerr.ErrMalformedEntity, errBundleVersion)
+	}
+	if bundle.Encrypted && len(key) == 0 {
+		return ImportSummary{}, errors.Wrap(svcerr.ErrMalformedEntity, errBundleKey)
+	}
+	if strategy != SkipConflicts && strategy != MergeConflicts {
+		return ImportSummary{}, errors.Wrap(svcerr.ErrMalformedEntity, errConflictStrategy)
+	}
+
+	summary := ImportSummary{
+		Created: []string{},
+		Merged:  []string{},
+		Skipped: []string{},
+	}
+	var configs []ImportedConfig
+	for _, cfg := range bundle.Configs {
+		if cfg.ClientID == "" || cfg.ExternalID == "" {
+			return summary, errors.Wrap(svcerr.ErrMalformedEntity, errImportConfigs)
+		}
+		if bundle.Encrypted {
+			var err error
+			if cfg, err = decryptSecrets(key, cfg); err != nil {
+				return summary, errors.Wrap(errImportConfigs, errors.Wrap(svcerr.ErrMalformedEntity, err))
+			}
+		}
+		cfg.DomainID = session.DomainID
+
+		_, err := bs.configs.RetrieveByID(ctx, session.DomainID, cfg.ClientID)
+		switch {
+		case err == nil && strategy == SkipConflicts:
+			summary.Skipped = append(summary.Skipped, cfg.ClientID)
+		case err == nil, errors.Contains(err, repoerr.ErrNotFound):
+			// Configs are bound to their Clients the same way as on Add, so
+			// Configs of Clients missing from this domain are skipped.
+			merge := err == nil
+			mgClient, err := bs.client(session.DomainID, cfg.ClientID, token)
+			if err != nil {
+				summary.Skipped = append(summary.Skipped, cfg.ClientID)
+				continue
+			}
+			cfg.ClientSecret = mgClient.Credentials.Secret
+			configs = append(configs, ImportedConfig{
+				Config:      cfg,
+				Connections: toConnections(cfg.Channels),
+				Merge:       merge,
+			})
+		default:
+			return summary, errors.Wrap(errImportConfigs, err)
+		}
+	}
+	if len(configs) == 0 {
+		return summary, nil
+	}
+
+	skipped, err := bs.configs.Import(ctx, configs)
+	if err != nil {
+		return ImportSummary{}, errors.Wrap(errImportConfigs, err)
+	}
+	conflicts := make(map[string]bool, len(skipped))
+	for _, id := range skipped {
+		conflicts[id] = true
+	}
+	for _, ic := range configs {
+		switch id := ic.Config.ClientID; {
+		case ic.Merge:
+			summary.Merged = append(summary.Merged, id)
+		case conflicts[id]:
+			summary.Skipped = append(summary.Skipped, id)
+		default:
+			summary.Created = append(summary.Created, id)
+		}
+	}
+
+	return summary, nil
+}
+
+func (bs bootstrapService) Reconcile(ctx context.Context, session smqauthn.Session, token string, repair bool) (ReconcileReport, error) {
+	// Collect the Configs first, since repair may remove them while paging.
+	var configs []Config
+	for offset := uint64(0); ; offset += exportPageSize {
+		page, err := bs.List(ctx, session, Filter{}, offset, exportPageSize)
+		if err != nil {
+			return ReconcileReport{}, errors.Wrap(errReconcile, err)
+		}
+		configs = append(configs, page.Configs...)
+		if offset+exportPageSize >= page.Total || len(page.Configs) == 0 {
+			break
+		}
+	}
+
+	report := ReconcileReport{
+		CreatedAt: time.Now().UTC(),
+		Drifts:    []Drift{},
+	}
+	// Channels are shared between Configs, so each one is checked only once.
+	channels := make(map[string]bool)
+	for _, c := range configs {
+		// Retrieve the whole Config, since listing omits channels.
+		cfg, err := bs.configs.RetrieveByID(ctx, session.DomainID, c.ClientID)
+		if err != nil {
+			return report, errors.Wrap(errReconcile, err)
+		}
+		drifts, err := bs.reconcileConfig(ctx, session.DomainID, token, cfg, channels, repair)
+		if err != nil {
+			return report, errors.Wrap(errReconcile, err)
+		}
+		report.Checked++
+		report.Drifts = append(report.Drifts, drifts...)
+	}
+
+	return report, nil
+}
+
+func (bs bootstrapService) CreateRollout(ctx context.Context, session smqauthn.Session, r Rollout) (Rollout, error) {
+	if err := r.Validate(); err != nil {
+		return Rollout{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	id, err := bs.idProvider.ID()
+	if err != nil {
+		return Rollout{}, errors.Wrap(errCreateRollout, err)
+	}
+	r.ID = id
+
+	targets := slices.Clone(r.ClientIDs)
+	if len(targets) == 0 {
+		for offset := uint64(0); ; offset += exportPageSize {
+			page, err := bs.List(ctx, session, Filter{}, offset, exportPageSize)
+			if err != nil {
+				return Rollout{}, errors.Wrap(errCreateRollout, err)
+			}
+			for _, cfg := range page.Configs {
+				targets = append(targets, cfg.ClientID)
+			}
+			if offset+exportPageSize >= page.Total || len(page.Configs) == 0 {
+				break
+			}
+		}
+	}
+	slices.Sort(targets)
+	targets = slices.Compact(targets)
+	staged, err := stage(r, targets)
+	if err != nil {
+		return Rollout{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	r.DomainID = session.DomainID
+	r.State = RolloutStaged
+	r.CreatedAt = time.Now().UTC()
+	r.CreatedBy = session.UserID
+	r.Targets = make([]RolloutTarget, 0, len(targets))
+	for _, id := range targets {
+		r.Targets = append(r.Targets, RolloutTarget{ClientID: id, Staged: staged[id]})
+	}
+	if err := bs.rollouts.Save(ctx, r); err != nil {
+		return Rollout{}, errors.Wrap(errCreateRollout, err)
+	}
+
+	return bs.ViewRollout(ctx, session, r.ID)
+}
+
+func (bs bootstrapService) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error) {
+	r, err := bs.rollouts.Retrieve(ctx, session.DomainID, id)
+	if err != nil {
+		return Rollout{}, errors.Wrap(errViewRollout, err)
+	}
+
+	return r, nil
+}
+
+func (bs bootstrapService) PromoteRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error) {
+	r, err := bs.rollouts.Retrieve(ctx, session.DomainID, id)
+	if err != nil {
+		return Rollout{}, errors.Wrap(errPromoteRollout, err)
+	}
+	if r.State != RolloutStaged {
+		return Rollout{}, errors.Wrap(svcerr.ErrConflict, errRolloutState)
+	}
+	r.UpdatedAt = time.Now().UTC()
+	r.UpdatedBy = session.UserID
+	if err := bs.rollouts.Promote(ctx, r); err != nil {
+		return Rollout{}, errors.Wrap(errPromoteRollout, err)
+	}
+
+	return bs.ViewRollout(ctx, session, id)
+}
+
+func (bs bootstrapService) RollBackRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error) {
+	r, err := bs.rollouts.Retrieve(ctx, session.DomainID, id)
+	if err != nil {
+		return Rollout{}, errors.Wrap(errRollBackRollout, err)
+	}
+	if r.State == RolloutRolledBack {
+		return Rollout{}, errors.Wrap(svcerr.ErrConflict, errRolloutState)
+	}
+	r.UpdatedAt = time.Now().UTC()
+	r.UpdatedBy = session.UserID
+	if err := bs.rollouts.RollBack(ctx, r); err != nil {
+		return Rollout{}, errors.Wrap(errRollBackRollout, err)
+	}
+
+	return bs.ViewRollout(ctx, session, id)
+}
+
+// stage returns the staged target Clients of the Rollout. The percentage of
+// the targets is picked by the hash of the Rollout and Client IDs, so that
+// the subsequent Rollouts stage different Clients.
+func stage(r Rollout, targets []string) (map[string]bool, error) {
+	if len(targets) == 0 {
+		return nil, errRolloutTargets
+	}
+	staged := make(map[string]bool, len(targets))
+	if len(r.Cohort) > 0 {
+		targeted := make(map[string]bool, len(targets))
+		for _, id := range targets {
+			targeted[id] = true
+		}
+		for _, id := range r.Cohort {
+			if !targeted[id] {
+				return nil, errRolloutCohort
+			}
+			staged[id] = true
+		}
+		return staged, nil
+	}
+
+	ordered := slices.Clone(targets)
+	slices.SortFunc(ordered, func(a, b string) int {
+		return cmp.Compare(rolloutHash(r.ID, a), rolloutHash(r.ID, b))
+	})
+	n := (len(ordered)*int(r.Percentage) + 99) / 100
+	for _, id := range ordered[:n] {
+		staged[id] = true
+	}
+
+	return staged, nil
+}
+
+func rolloutHash(rolloutID, clientID string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(rolloutID + clientID))
+	return h.Sum32()
+}
+
+// Method reconcileConfig returns the drifts of a single Config. The channels
+// map caches whether the already checked Channels exist.
+func (bs bootstrapService) reconcileConfig(ctx context.Context, domainID, token string, cfg Config, channels map[string]bool, repair bool) ([]Drift, error) {
+	if _, err := bs.sdk.Client(cfg.ClientID, domainID, token); err != nil {
+		if err.StatusCode() != http.StatusNotFound {
+			return nil, errors.Wrap(ErrClients, err)
+		}
+		drift := Drift{Type: ClientMissing, ClientID: cfg.ClientID}
+		if repair {
+			if err := bs.configs.Remove(ctx, domainID, cfg.ClientID); err != nil {
+				return nil, err
+			}
+			drift.Repaired = true
+		}
+		return []Drift{drift}, nil
+	}
+
+	drifts := []Drift{}
+	for _, ch := range cfg.Channels {
+		exists, checked := channels[ch.ID]
+		if !checked {
+			_, err := bs.sdk.Channel(ch.ID, domainID, token)
+			if err != nil && err.StatusCode() != http.StatusNotFound {
+				return nil, errors.Wrap(ErrClients, err)
+			}
+			exists = err == nil
+			channels[ch.ID] = exists
+			if !exists && repair {
+				if err := bs.configs.RemoveChannel(ctx, ch.ID); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if !exists {
+			drifts = append(drifts, Drift{Type: ChannelMissing, ClientID: cfg.ClientID, ChannelID: ch.ID, Repaired: repair})
+			continue
+		}
+		if !repair {
+			continue
+		}
+
+		switch cfg.State {
+		case Active:
+			err := bs.sdk.ConnectClients(ch.ID, []string{cfg.ClientID}, []string{"Publish", "Subscribe"}, domainID, token)
+			switch {
+			case err == nil:
+				drifts = append(drifts, Drift{Type: ConnectionMissing, ClientID: cfg.ClientID, ChannelID: ch.ID, Repaired: true})
+			case !errors.Contains(err, svcerr.ErrConflict):
+				return nil, errors.Wrap(ErrClients, err)
+			}
+		case Inactive:
+			err := bs.sdk.DisconnectClients(ch.ID, []string{cfg.ClientID}, []string{"Publish", "Subscribe"}, domainID, token)
+			switch {
+			case err == nil:
+				drifts = append(drifts, Drift{Type: ConnectionUnexpected, ClientID: cfg.ClientID, ChannelID: ch.ID, Repaired: true})
+			case !errors.Contains(err, repoerr.ErrNotFound):
+				return nil, errors.Wrap(ErrClients, err)
+			}
+		}
+	}
+
+	return drifts, nil
 }
 
 func (bs bootstrapService) UpdateChannelHandler(ctx context.Context, channel Channel) error {
@@ -485,12 +1005,133 @@ func (bs bootstrapService) toIDList(channels []Channel) []string {
 	return ret
 }
 
+// toConnections returns the connections of the Channels, keeping their order
+// and roles.
+func toConnections(channels []Channel) []Connection {
+	conns := make([]Connection, 0, len(channels))
+	for _, ch := range channels {
+		conns = append(conns, Connection{ChannelID: ch.ID, Role: ch.Role})
+	}
+
+	return conns
+}
+
+// orderChannels returns the Channels in the order of the connections, with
+// the roles of the connections.
+func orderChannels(channels []Channel, connections []Connection) []Channel {
+	byID := make(map[string]Channel, len(channels))
+	for _, ch := range channels {
+		byID[ch.ID] = ch
+	}
+	ret := make([]Channel, 0, len(connections))
+	for _, c := range connections {
+		ch, ok := byID[c.ChannelID]
+		if !ok {
+			continue
+		}
+		ch.Role = c.Role
+		ret = append(ret, ch)
+	}
+
+	return ret
+}
+
+// Method revokeCert revokes the certificates of the Client. Clients without
+// certificates issued by the certs service are ignored.
+func (bs bootstrapService) revokeCert(domainID, token, clientID string) error {
+	if _, err := bs.sdk.RevokeCert(clientID, domainID, token); err != nil {
+		if err.StatusCode() == http.StatusNotFound {
+			return nil
+		}
+		return errors.Wrap(errRevokeCert, err)
+	}
+
+	return nil
+}
+
+// Method splitChain splits the PEM chain returned by the certs service into
+// the Client certificate and the CA certificates which issued it.
+func splitChain(chain string) (string, string) {
+	block, rest := pem.Decode([]byte(chain))
+	if block == nil {
+		return chain, ""
+	}
+	var ca []byte
+	for {
+		var b *pem.Block
+		if b, rest = pem.Decode(rest); b == nil {
+			break
+		}
+		ca = append(ca, pem.EncodeToMemory(b)...)
+	}
+
+	return string(pem.EncodeToMemory(block)), string(ca)
+}
+
 func (bs bootstrapService) dec(in string) (string, error) {
+	return decrypt(bs.encKey, in)
+}
+
+func encryptSecrets(key []byte, cfg Config) (Config, error) {
+	var err error
+	if cfg.ClientSecret, err = encrypt(key, cfg.ClientSecret); err != nil {
+		return Config{}, err
+	}
+	if cfg.ClientKey, err = encrypt(key, cfg.ClientKey); err != nil {
+		return Config{}, err
+	}
+	if cfg.ExternalKey, err = encrypt(key, cfg.ExternalKey); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func decryptSecrets(key []byte, cfg Config) (Config, error) {
+	var err error
+	if cfg.ClientSecret, err = decrypt(key, cfg.ClientSecret); err != nil {
+		return Config{}, err
+	}
+	if cfg.ClientKey, err = decrypt(key, cfg.ClientKey); err != nil {
+		return Config{}, err
+	}
+	if cfg.ExternalKey, err = decrypt(key, cfg.ExternalKey); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// encrypt encrypts the value using AES in CFB mode and returns the hex
+// encoded IV followed by the ciphertext. Empty values are left as they are.
+func encrypt(key []byte, in string) (string, error) {
+	if in == "" {
+		return "", nil
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	ciphertext := make([]byte, aes.BlockSize+len(in))
+	iv := ciphertext[:aes.BlockSize]
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return "", err
+	}
+	stream := cipher.NewCFBEncrypter(block, iv)
+	stream.XORKeyStream(ciphertext[aes.BlockSize:], []byte(in))
+
+	return hex.EncodeToString(ciphertext), nil
+}
+
+func decrypt(key []byte, in string) (string, error) {
+	if in == "" {
+		return "", nil
+	}
 	ciphertext, err := hex.DecodeString(in)
 	if err != nil {
 		return "", err
 	}
-	block, err := aes.NewCipher(bs.encKey)
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return "", err
 	}
@@ -9,16 +9,20 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"net/http"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala/bootstrap"
 	"github.com/absmach/magistrala/bootstrap/mocks"
 	"github.com/absmach/magistrala/internal/testsutil"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	policysvc "github.com/absmach/supermq/pkg/policies"
 	policymocks "github.com/absmach/supermq/pkg/policies/mocks"
@@ -61,16 +65,18 @@ var (
 
 var (
 	boot     *mocks.ConfigRepository
+	rollouts *mocks.RolloutRepository
 	policies *policymocks.Service
 	sdk      *sdkmocks.SDK
 )
 
 func newService() bootstrap.Service {
 	boot = new(mocks.ConfigRepository)
+	rollouts = new(mocks.RolloutRepository)
 	policies = new(policymocks.Service)
 	sdk = new(sdkmocks.SDK)
 	idp := uuid.NewMock()
-	return bootstrap.New(policies, boot, sdk, encKey, idp)
+	return bootstrap.New(policies, boot, rollouts, sdk, encKey, idp)
 }
 
 func enc(in []byte) ([]byte, error) {
@@ -366,12 +372,153 @@ func TestUpdateCert(t *testing.T) {
 			sort.Slice(tc.expectedConfig.Channels, func(i, j int) bool {
 				return tc.expectedConfig.Channels[i].ID < tc.expectedConfig.Channels[j].ID
 			})
-			assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.expectedConfig, cfg))
+			assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expectedConfig, cfg))
 			repoCall.Unset()
 		})
 	}
 }
 
+func TestIssueCert(t *testing.T) {
+	svc := newService()
+
+	c := config
+	c.CACert = "ca"
+	issued := c
+	issued.ClientCert = "cert"
+	issued.ClientKey = "key"
+
+	clientCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("client")}))
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("ca")}))
+	chained := c
+	chained.ClientCert = clientCert
+	chained.ClientKey = "key"
+	chained.CACert = caCert
+
+	cases := []struct {
+		desc           string
+		token          string
+		session        smqauthn.Session
+		clientID       string
+		ttl            string
+		config         bootstrap.Config
+		certificate    string
+		expectedConfig bootstrap.Config
+		retrieveErr    error
+		revokeErr      errors.SDKError
+		issueErr       errors.SDKError
+		viewErr        errors.SDKError
+		updateErr      error
+		err            error
+	}{
+		{
+			desc:           "issue cert for a config without cert",
+			token:          validToken,
+			clientID:       c.ClientID,
+			ttl:            "24h",
+			config:         c,
+			expectedConfig: issued,
+		},
+		{
+			desc:           "issue cert for a config with cert",
+			token:          validToken,
+			clientID:       c.ClientID,
+			config:         issued,
+			expectedConfig: issued,
+		},
+		{
+			desc:           "issue cert with CA chain",
+			token:          validToken,
+			clientID:       c.ClientID,
+			config:         c,
+			certificate:    clientCert + caCert,
+			expectedConfig: chained,
+		},
+		{
+			desc:           "issue cert for a config with cert not found by certs service",
+			token:          validToken,
+			clientID:       c.ClientID,
+			config:         issued,
+			expectedConfig: issued,
+			revokeErr:      errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:        "issue cert for a non-existing config",
+			token:       validToken,
+			clientID:    unknown,
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:      "issue cert with failed revocation",
+			token:     validToken,
+			clientID:  c.ClientID,
+			config:    issued,
+			revokeErr: errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "issue cert with failed issue",
+			token:    validToken,
+			clientID: c.ClientID,
+			config:   c,
+			issueErr: errors.NewSDKErrorWithStatus(svcerr.ErrCreateEntity, http.StatusUnprocessableEntity),
+			err:      svcerr.ErrCreateEntity,
+		},
+		{
+			desc:     "issue cert with failed cert retrieval",
+			token:    validToken,
+			clientID: c.ClientID,
+			config:   c,
+			viewErr:  errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusBadRequest),
+			err:      svcerr.ErrViewEntity,
+		},
+		{
+			desc:      "issue cert with failed update",
+			token:     validToken,
+			clientID:  c.ClientID,
+			config:    c,
+			updateErr: svcerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.session = smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := boot.On("RetrieveByID", context.Background(), domainID, tc.clientID).Return(tc.config, tc.retrieveErr)
+			sdkCall := sdk.On("RevokeCert", tc.clientID, domainID, tc.token).Return(time.Time{}, tc.revokeErr)
+			sdkCall1 := sdk.On("IssueCert", tc.clientID, mock.Anything, domainID, tc.token).Return(mgsdk.Cert{SerialNumber: "serial"}, tc.issueErr)
+			certificate := tc.certificate
+			if certificate == "" {
+				certificate = "cert"
+			}
+			sdkCall2 := sdk.On("ViewCert", "serial", domainID, tc.token).Return(mgsdk.Cert{SerialNumber: "serial", Certificate: certificate, Key: "key"}, tc.viewErr)
+			repoCall1 := boot.On("UpdateCert", context.Background(), domainID, tc.clientID, mock.Anything, "key", mock.Anything).Return(tc.expectedConfig, tc.updateErr)
+			cfg, err := svc.IssueCert(context.Background(), tc.session, tc.token, tc.clientID, tc.ttl)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expectedConfig, cfg))
+			if tc.err == nil {
+				ttl := tc.ttl
+				if ttl == "" {
+					ttl = bootstrap.DefCertTTL
+				}
+				sdk.AssertCalled(t, "IssueCert", tc.clientID, ttl, domainID, tc.token)
+				boot.AssertCalled(t, "UpdateCert", context.Background(), domainID, tc.clientID, tc.expectedConfig.ClientCert, "key", tc.expectedConfig.CACert)
+				if tc.config.ClientCert == "" {
+					sdk.AssertNotCalled(t, "RevokeCert", tc.clientID, domainID, tc.token)
+				}
+			}
+			repoCall.Unset()
+			sdkCall.Unset()
+			sdkCall1.Unset()
+			sdkCall2.Unset()
+			repoCall1.Unset()
+			sdk.Calls = nil
+			boot.Calls = nil
+		})
+	}
+}
+
 func TestUpdateConnections(t *testing.T) {
 	svc := newService()
 
@@ -740,15 +887,20 @@ func TestRemove(t *testing.T) {
 	svc := newService()
 
 	c := config
+	withCert := config
+	withCert.ClientCert = "cert"
 	cases := []struct {
-		desc      string
-		id        string
-		token     string
-		session   smqauthn.Session
-		userID    string
-		domainID  string
-		removeErr error
-		err       error
+		desc        string
+		id          string
+		token       string
+		session     smqauthn.Session
+		userID      string
+		domainID    string
+		config      bootstrap.Config
+		retrieveErr error
+		revokeErr   errors.SDKError
+		removeErr   error
+		err         error
 	}{
 		{
 			desc:     "remove an existing config",
@@ -756,22 +908,63 @@ func TestRemove(t *testing.T) {
 			token:    validToken,
 			userID:   validID,
 			domainID: domainID,
+			config:   c,
 			err:      nil,
 		},
 		{
-			desc:     "remove removed config",
+			desc:     "remove an existing config with cert",
 			id:       c.ClientID,
 			token:    validToken,
 			userID:   validID,
 			domainID: domainID,
+			config:   withCert,
 			err:      nil,
 		},
+		{
+			desc:      "remove an existing config with cert not found by certs service",
+			id:        c.ClientID,
+			token:     validToken,
+			userID:    validID,
+			domainID:  domainID,
+			config:    withCert,
+			revokeErr: errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			err:       nil,
+		},
+		{
+			desc:      "remove an existing config with failed cert revocation",
+			id:        c.ClientID,
+			token:     validToken,
+			userID:    validID,
+			domainID:  domainID,
+			config:    withCert,
+			revokeErr: errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "remove removed config",
+			id:          c.ClientID,
+			token:       validToken,
+			userID:      validID,
+			domainID:    domainID,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         nil,
+		},
+		{
+			desc:        "remove a config with failed retrieval",
+			id:          c.ClientID,
+			token:       validToken,
+			userID:      validID,
+			domainID:    domainID,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         repoerr.ErrViewEntity,
+		},
 		{
 			desc:      "remove a config with failed remove",
 			id:        c.ClientID,
 			token:     validToken,
 			userID:    validID,
 			domainID:  domainID,
+			config:    c,
 			removeErr: svcerr.ErrRemoveEntity,
 			err:       svcerr.ErrRemoveEntity,
 		},
@@ -780,10 +973,572 @@ func TestRemove(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			tc.session = smqauthn.Session{UserID: tc.userID, DomainID: tc.domainID, DomainUserID: validID}
-			repoCall := boot.On("Remove", context.Background(), mock.Anything, mock.Anything).Return(tc.removeErr)
-			err := svc.Remove(context.Background(), tc.session, tc.id)
+			repoCall := boot.On("RetrieveByID", context.Background(), tc.domainID, tc.id).Return(tc.config, tc.retrieveErr)
+			sdkCall := sdk.On("RevokeCert", tc.id, tc.domainID, tc.token).Return(time.Time{}, tc.revokeErr)
+			repoCall1 := boot.On("Remove", context.Background(), mock.Anything, mock.Anything).Return(tc.removeErr)
+			err := svc.Remove(context.Background(), tc.session, tc.token, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+			sdkCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestExportConfigs(t *testing.T) {
+	svc := newService()
+
+	c := config
+	c.DomainID = domainID
+	bundleKey := []byte("0123456789abcdef0123456789abcdef")
+	session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID, SuperAdmin: true}
+
+	cases := []struct {
+		desc        string
+		key         []byte
+		page        bootstrap.ConfigsPage
+		retrieveErr error
+		err         error
+	}{
+		{
+			desc: "export configs successfully",
+			key:  bundleKey,
+			page: bootstrap.ConfigsPage{Total: 1, Limit: 100, Configs: []bootstrap.Config{c}},
+			err:  nil,
+		},
+		{
+			desc: "export configs without encryption key",
+			page: bootstrap.ConfigsPage{Total: 1, Limit: 100, Configs: []bootstrap.Config{c}},
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "export empty configs",
+			key:  bundleKey,
+			page: bootstrap.ConfigsPage{Total: 0, Limit: 100, Configs: []bootstrap.Config{}},
+			err:  nil,
+		},
+		{
+			desc:        "export configs with failed retrieve",
+			key:         bundleKey,
+			page:        bootstrap.ConfigsPage{Total: 1, Limit: 100, Configs: []bootstrap.Config{c}},
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := boot.On("RetrieveAll", context.Background(), domainID, []string{}, bootstrap.Filter{}, uint64(0), uint64(100)).Return(tc.page)
+			repoCall1 := boot.On("RetrieveByID", context.Background(), domainID, c.ClientID).Return(c, tc.retrieveErr)
+			bundle, err := svc.ExportConfigs(context.Background(), session, bootstrap.Filter{}, tc.key)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, bootstrap.BundleVersion, bundle.Version, fmt.Sprintf("%s: expected bundle version %d got %d\n", tc.desc, bootstrap.BundleVersion, bundle.Version))
+				assert.True(t, bundle.Encrypted, fmt.Sprintf("%s: expected bundle to be encrypted\n", tc.desc))
+				assert.Len(t, bundle.Configs, len(tc.page.Configs), fmt.Sprintf("%s: expected %d configs got %d\n", tc.desc, len(tc.page.Configs), len(bundle.Configs)))
+				for _, cfg := range bundle.Configs {
+					assert.NotEqual(t, c.ClientSecret, cfg.ClientSecret, fmt.Sprintf("%s: expected client secret to be encrypted\n", tc.desc))
+					assert.NotEqual(t, c.ExternalKey, cfg.ExternalKey, fmt.Sprintf("%s: expected external key to be encrypted\n", tc.desc))
+				}
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestImportConfigs(t *testing.T) {
+	svc := newService()
+
+	c := config
+	c.DomainID = domainID
+	bundleKey := []byte("0123456789abcdef0123456789abcdef")
+	session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID, SuperAdmin: true}
+
+	repoCall := boot.On("RetrieveAll", context.Background(), domainID, []string{}, bootstrap.Filter{}, uint64(0), uint64(100)).Return(bootstrap.ConfigsPage{Total: 1, Limit: 100, Configs: []bootstrap.Config{c}})
+	repoCall1 := boot.On("RetrieveByID", context.Background(), domainID, c.ClientID).Return(c, nil)
+	encrypted, err := svc.ExportConfigs(context.Background(), session, bootstrap.Filter{}, bundleKey)
+	assert.Nil(t, err, fmt.Sprintf("Exporting configs expected to succeed: %s.\n", err))
+	repoCall.Unset()
+	repoCall1.Unset()
+
+	plain := bootstrap.Bundle{Version: bootstrap.BundleVersion, Configs: []bootstrap.Config{c}}
+
+	cases := []struct {
+		desc        string
+		bundle      bootstrap.Bundle
+		key         []byte
+		strategy    bootstrap.ConflictStrategy
+		retrieveErr error
+		clientErr   errors.SDKError
+		skipped     []string
+		importErr   error
+		summary     bootstrap.ImportSummary
+		err         error
+	}{
+		{
+			desc:        "import new configs",
+			bundle:      plain,
+			strategy:    bootstrap.SkipConflicts,
+			retrieveErr: repoerr.ErrNotFound,
+			summary:     bootstrap.ImportSummary{Created: []string{c.ClientID}, Merged: []string{}, Skipped: []string{}},
+			err:         nil,
+		},
+		{
+			desc:        "import new encrypted configs",
+			bundle:      encrypted,
+			key:         bundleKey,
+			strategy:    bootstrap.SkipConflicts,
+			retrieveErr: repoerr.ErrNotFound,
+			summary:     bootstrap.ImportSummary{Created: []string{c.ClientID}, Merged: []string{}, Skipped: []string{}},
+			err:         nil,
+		},
+		{
+			desc:     "import existing configs with skip strategy",
+			bundle:   plain,
+			strategy: bootstrap.SkipConflicts,
+			summary:  bootstrap.ImportSummary{Created: []string{}, Merged: []string{}, Skipped: []string{c.ClientID}},
+			err:      nil,
+		},
+		{
+			desc:     "import existing configs with merge strategy",
+			bundle:   plain,
+			strategy: bootstrap.MergeConflicts,
+			summary:  bootstrap.ImportSummary{Created: []string{}, Merged: []string{c.ClientID}, Skipped: []string{}},
+			err:      nil,
+		},
+		{
+			desc:        "import configs conflicting by external ID",
+			bundle:      plain,
+			strategy:    bootstrap.SkipConflicts,
+			retrieveErr: repoerr.ErrNotFound,
+			skipped:     []string{c.ClientID},
+			summary:     bootstrap.ImportSummary{Created: []string{}, Merged: []string{}, Skipped: []string{c.ClientID}},
+			err:         nil,
+		},
+		{
+			desc:        "import configs of non-existing clients",
+			bundle:      plain,
+			strategy:    bootstrap.SkipConflicts,
+			retrieveErr: repoerr.ErrNotFound,
+			clientErr:   errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			summary:     bootstrap.ImportSummary{Created: []string{}, Merged: []string{}, Skipped: []string{c.ClientID}},
+			err:         nil,
+		},
+		{
+			desc:      "import existing configs of non-existing clients with merge strategy",
+			bundle:    plain,
+			strategy:  bootstrap.MergeConflicts,
+			clientErr: errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			summary:   bootstrap.ImportSummary{Created: []string{}, Merged: []string{}, Skipped: []string{c.ClientID}},
+			err:       nil,
+		},
+		{
+			desc:        "import configs with failed client retrieval",
+			bundle:      plain,
+			strategy:    bootstrap.SkipConflicts,
+			retrieveErr: repoerr.ErrNotFound,
+			clientErr:   errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			summary:     bootstrap.ImportSummary{Created: []string{}, Merged: []string{}, Skipped: []string{c.ClientID}},
+			err:         nil,
+		},
+		{
+			desc:        "import configs with failed import",
+			bundle:      plain,
+			strategy:    bootstrap.SkipConflicts,
+			retrieveErr: repoerr.ErrNotFound,
+			importErr:   repoerr.ErrCreateEntity,
+			err:         repoerr.ErrCreateEntity,
+		},
+		{
+			desc:     "import configs with unsupported bundle version",
+			bundle:   bootstrap.Bundle{Version: bootstrap.BundleVersion + 1, Configs: []bootstrap.Config{c}},
+			strategy: bootstrap.SkipConflicts,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "import encrypted configs without key",
+			bundle:   encrypted,
+			strategy: bootstrap.SkipConflicts,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "import encrypted configs with malformed secrets",
+			bundle:   bootstrap.Bundle{Version: bootstrap.BundleVersion, Encrypted: true, Configs: []bootstrap.Config{c}},
+			key:      bundleKey,
+			strategy: bootstrap.SkipConflicts,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "import configs with invalid strategy",
+			bundle:   plain,
+			strategy: bootstrap.ConflictStrategy(unknown),
+			err:      svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := boot.On("RetrieveByID", context.Background(), domainID, c.ClientID).Return(c, tc.retrieveErr)
+			sdkCall := sdk.On("Client", c.ClientID, domainID, validToken).Return(mgsdk.Client{ID: c.ClientID, Credentials: mgsdk.ClientCredentials{Secret: c.ClientSecret}}, tc.clientErr)
+			repoCall1 := boot.On("Import", context.Background(), mock.Anything).Return(tc.skipped, tc.importErr)
+			summary, err := svc.ImportConfigs(context.Background(), session, validToken, tc.bundle, tc.key, tc.strategy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.summary, summary, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.summary, summary))
+			}
+			if tc.clientErr != nil {
+				boot.AssertNotCalled(t, "Import", context.Background(), mock.Anything)
+			}
+			repoCall.Unset()
+			sdkCall.Unset()
+			repoCall1.Unset()
+			boot.Calls = nil
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	svc := newService()
+
+	active := config
+	active.DomainID = domainID
+	active.State = bootstrap.Active
+	inactive := active
+	inactive.State = bootstrap.Inactive
+	session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID, SuperAdmin: true}
+	notFound := errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound)
+
+	cases := []struct {
+		desc        string
+		config      bootstrap.Config
+		repair      bool
+		retrieveErr error
+		clientErr   errors.SDKError
+		channelErr  errors.SDKError
+		connectErr  errors.SDKError
+		report      bootstrap.ReconcileReport
+		err         error
+	}{
+		{
+			desc:   "reconcile configs without drift",
+			config: active,
+			report: bootstrap.ReconcileReport{Checked: 1, Drifts: []bootstrap.Drift{}},
+			err:    nil,
+		},
+		{
+			desc:      "reconcile configs with missing client",
+			config:    active,
+			clientErr: notFound,
+			report: bootstrap.ReconcileReport{Checked: 1, Drifts: []bootstrap.Drift{
+				{Type: bootstrap.ClientMissing, ClientID: active.ClientID},
+			}},
+			err: nil,
+		},
+		{
+			desc:      "repair configs with missing client",
+			config:    active,
+			repair:    true,
+			clientErr: notFound,
+			report: bootstrap.ReconcileReport{Checked: 1, Drifts: []bootstrap.Drift{
+				{Type: bootstrap.ClientMissing, ClientID: active.ClientID, Repaired: true},
+			}},
+			err: nil,
+		},
+		{
+			desc:       "repair configs with missing channel",
+			config:     active,
+			repair:     true,
+			channelErr: notFound,
+			report: bootstrap.ReconcileReport{Checked: 1, Drifts: []bootstrap.Drift{
+				{Type: bootstrap.ChannelMissing, ClientID: active.ClientID, ChannelID: channel.ID, Repaired: true},
+			}},
+			err: nil,
+		},
+		{
+			desc:   "repair active configs with missing connection",
+			config: active,
+			repair: true,
+			report: bootstrap.ReconcileReport{Checked: 1, Drifts: []bootstrap.Drift{
+				{Type: bootstrap.ConnectionMissing, ClientID: active.ClientID, ChannelID: channel.ID, Repaired: true},
+			}},
+			err: nil,
+		},
+		{
+			desc:       "repair active configs with existing connection",
+			config:     active,
+			repair:     true,
+			connectErr: errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+			report:     bootstrap.ReconcileReport{Checked: 1, Drifts: []bootstrap.Drift{}},
+			err:        nil,
+		},
+		{
+			desc:   "repair inactive configs with unexpected connection",
+			config: inactive,
+			repair: true,
+			report: bootstrap.ReconcileReport{Checked: 1, Drifts: []bootstrap.Drift{
+				{Type: bootstrap.ConnectionUnexpected, ClientID: inactive.ClientID, ChannelID: channel.ID, Repaired: true},
+			}},
+			err: nil,
+		},
+		{
+			desc:      "reconcile configs with failed clients service",
+			config:    active,
+			clientErr: errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			err:       bootstrap.ErrClients,
+		},
+		{
+			desc:        "reconcile configs with failed retrieval",
+			config:      active,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := boot.On("RetrieveAll", context.Background(), domainID, []string{}, bootstrap.Filter{}, uint64(0), uint64(100)).Return(bootstrap.ConfigsPage{Total: 1, Limit: 100, Configs: []bootstrap.Config{tc.config}})
+			repoCall1 := boot.On("RetrieveByID", context.Background(), domainID, tc.config.ClientID).Return(tc.config, tc.retrieveErr)
+			repoCall2 := boot.On("Remove", context.Background(), domainID, tc.config.ClientID).Return(nil)
+			repoCall3 := boot.On("RemoveChannel", context.Background(), channel.ID).Return(nil)
+			sdkCall := sdk.On("Client", tc.config.ClientID, domainID, validToken).Return(mgsdk.Client{ID: tc.config.ClientID}, tc.clientErr)
+			sdkCall1 := sdk.On("Channel", channel.ID, domainID, validToken).Return(mgsdk.Channel{ID: channel.ID}, tc.channelErr)
+			sdkCall2 := sdk.On("ConnectClients", channel.ID, []string{tc.config.ClientID}, mock.Anything, domainID, validToken).Return(tc.connectErr)
+			sdkCall3 := sdk.On("DisconnectClients", channel.ID, []string{tc.config.ClientID}, mock.Anything, domainID, validToken).Return(nil)
+			report, err := svc.Reconcile(context.Background(), session, validToken, tc.repair)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.report.Checked, report.Checked, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.report.Checked, report.Checked))
+				assert.Equal(t, tc.report.Drifts, report.Drifts, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.report.Drifts, report.Drifts))
+			}
+			if tc.repair && tc.clientErr != nil {
+				boot.AssertCalled(t, "Remove", context.Background(), domainID, tc.config.ClientID)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			sdkCall.Unset()
+			sdkCall1.Unset()
+			sdkCall2.Unset()
+			sdkCall3.Unset()
+		})
+	}
+}
+
+func TestCreateRollout(t *testing.T) {
+	svc := newService()
+
+	session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID, SuperAdmin: true}
+	clientIDs := []string{
+		testsutil.GenerateUUID(t),
+		testsutil.GenerateUUID(t),
+		testsutil.GenerateUUID(t),
+		testsutil.GenerateUUID(t),
+	}
+	configs := make([]bootstrap.Config, 0, len(clientIDs))
+	for _, id := range clientIDs {
+		configs = append(configs, bootstrap.Config{ClientID: id, DomainID: domainID})
+	}
+
+	cases := []struct {
+		desc    string
+		rollout bootstrap.Rollout
+		page    bootstrap.ConfigsPage
+		staged  int
+		targets int
+		saveErr error
+		err     error
+	}{
+		{
+			desc:    "create rollout to percentage of clients",
+			rollout: bootstrap.Rollout{Content: "content", ClientIDs: clientIDs, Percentage: 50},
+			staged:  2,
+			targets: 4,
+			err:     nil,
+		},
+		{
+			desc:    "create rollout to percentage rounded up",
+			rollout: bootstrap.Rollout{Content: "content", ClientIDs: clientIDs, Percentage: 10},
+			staged:  1,
+			targets: 4,
+			err:     nil,
+		},
+		{
+			desc:    "create rollout to cohort of all clients",
+			rollout: bootstrap.Rollout{Content: "content", Cohort: clientIDs[:1]},
+			page:    bootstrap.ConfigsPage{Total: uint64(len(configs)), Limit: 100, Configs: configs},
+			staged:  1,
+			targets: 4,
+			err:     nil,
+		},
+		{
+			desc:    "create rollout with duplicated clients",
+			rollout: bootstrap.Rollout{Content: "content", ClientIDs: append(clientIDs, clientIDs...), Percentage: 100},
+			staged:  4,
+			targets: 4,
+			err:     nil,
+		},
+		{
+			desc:    "create rollout with both percentage and cohort",
+			rollout: bootstrap.Rollout{Content: "content", ClientIDs: clientIDs, Percentage: 50, Cohort: clientIDs[:1]},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create rollout with invalid percentage",
+			rollout: bootstrap.Rollout{Content: "content", ClientIDs: clientIDs, Percentage: 101},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create rollout with cohort out of targets",
+			rollout: bootstrap.Rollout{Content: "content", ClientIDs: clientIDs[1:], Cohort: clientIDs[:1]},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create rollout without targets",
+			rollout: bootstrap.Rollout{Content: "content", Percentage: 50},
+			page:    bootstrap.ConfigsPage{Limit: 100, Configs: []bootstrap.Config{}},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create rollout with failed save",
+			rollout: bootstrap.Rollout{Content: "content", ClientIDs: clientIDs, Percentage: 50},
+			saveErr: repoerr.ErrNotFound,
+			err:     repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var saved bootstrap.Rollout
+			repoCall := boot.On("RetrieveAll", context.Background(), domainID, []string{}, bootstrap.Filter{}, uint64(0), uint64(100)).Return(tc.page)
+			repoCall1 := rollouts.On("Save", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(bootstrap.Rollout)
+			}).Return(tc.saveErr)
+			repoCall2 := rollouts.On("Retrieve", context.Background(), domainID, mock.Anything).Return(bootstrap.Rollout{}, nil)
+			_, err := svc.CreateRollout(context.Background(), session, tc.rollout)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				staged := 0
+				for _, target := range saved.Targets {
+					if target.Staged {
+						staged++
+					}
+				}
+				assert.Equal(t, tc.targets, len(saved.Targets), fmt.Sprintf("%s: expected %d targets got %d\n", tc.desc, tc.targets, len(saved.Targets)))
+				assert.Equal(t, tc.staged, staged, fmt.Sprintf("%s: expected %d staged targets got %d\n", tc.desc, tc.staged, staged))
+				assert.Equal(t, bootstrap.RolloutStaged, saved.State, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, bootstrap.RolloutStaged, saved.State))
+				assert.Equal(t, domainID, saved.DomainID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, domainID, saved.DomainID))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+		})
+	}
+}
+
+func TestPromoteRollout(t *testing.T) {
+	svc := newService()
+
+	session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID, SuperAdmin: true}
+	id := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		state       bootstrap.RolloutState
+		retrieveErr error
+		promoteErr  error
+		err         error
+	}{
+		{
+			desc:  "promote staged rollout",
+			state: bootstrap.RolloutStaged,
+			err:   nil,
+		},
+		{
+			desc:  "promote promoted rollout",
+			state: bootstrap.RolloutPromoted,
+			err:   svcerr.ErrConflict,
+		},
+		{
+			desc:  "promote rolled back rollout",
+			state: bootstrap.RolloutRolledBack,
+			err:   svcerr.ErrConflict,
+		},
+		{
+			desc:        "promote non-existing rollout",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         repoerr.ErrNotFound,
+		},
+		{
+			desc:       "promote rollout with failed promote",
+			state:      bootstrap.RolloutStaged,
+			promoteErr: repoerr.ErrUpdateEntity,
+			err:        repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := rollouts.On("Retrieve", context.Background(), domainID, id).Return(bootstrap.Rollout{ID: id, DomainID: domainID, State: tc.state}, tc.retrieveErr)
+			repoCall1 := rollouts.On("Promote", context.Background(), mock.Anything).Return(tc.promoteErr)
+			_, err := svc.PromoteRollout(context.Background(), session, id)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestRollBackRollout(t *testing.T) {
+	svc := newService()
+
+	session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID, SuperAdmin: true}
+	id := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc        string
+		state       bootstrap.RolloutState
+		retrieveErr error
+		rollBackErr error
+		err         error
+	}{
+		{
+			desc:  "roll back staged rollout",
+			state: bootstrap.RolloutStaged,
+			err:   nil,
+		},
+		{
+			desc:  "roll back promoted rollout",
+			state: bootstrap.RolloutPromoted,
+			err:   nil,
+		},
+		{
+			desc:  "roll back rolled back rollout",
+			state: bootstrap.RolloutRolledBack,
+			err:   svcerr.ErrConflict,
+		},
+		{
+			desc:        "roll back non-existing rollout",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         repoerr.ErrNotFound,
+		},
+		{
+			desc:        "roll back rollout with failed roll back",
+			state:       bootstrap.RolloutStaged,
+			rollBackErr: repoerr.ErrUpdateEntity,
+			err:         repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := rollouts.On("Retrieve", context.Background(), domainID, id).Return(bootstrap.Rollout{ID: id, DomainID: domainID, State: tc.state}, tc.retrieveErr)
+			repoCall1 := rollouts.On("RollBack", context.Background(), mock.Anything).Return(tc.rollBackErr)
+			_, err := svc.RollBackRollout(context.Background(), session, id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+			repoCall1.Unset()
 		})
 	}
 }
@@ -858,6 +1613,59 @@ func TestBootstrap(t *testing.T) {
 	}
 }
 
+func TestBootstrapFetchedVersion(t *testing.T) {
+	c := config
+	c.Version = 2
+	c.FetchedVersion = 1
+
+	cases := []struct {
+		desc     string
+		config   bootstrap.Config
+		fetched  uint64
+		fetchErr error
+		err      error
+	}{
+		{
+			desc:    "bootstrap config with new version",
+			config:  c,
+			fetched: c.Version,
+			err:     nil,
+		},
+		{
+			desc: "bootstrap config with fetched version",
+			config: func() bootstrap.Config {
+				cfg := c
+				cfg.FetchedVersion = cfg.Version
+				return cfg
+			}(),
+			fetched: c.Version,
+			err:     nil,
+		},
+		{
+			desc:     "bootstrap config with failed version update",
+			config:   c,
+			fetchErr: repoerr.ErrUpdateEntity,
+			err:      repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := newService()
+			repoCall := boot.On("RetrieveByExternalID", context.Background(), tc.config.ExternalID).Return(tc.config, nil)
+			repoCall1 := boot.On("UpdateFetchedVersion", context.Background(), tc.config.DomainID, tc.config.ClientID, tc.config.Version).Return(tc.fetchErr)
+			cfg, err := svc.Bootstrap(context.Background(), tc.config.ExternalKey, tc.config.ExternalID, false)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.fetched, cfg.FetchedVersion, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.fetched, cfg.FetchedVersion))
+			if tc.config.FetchedVersion == tc.config.Version {
+				boot.AssertNotCalled(t, "UpdateFetchedVersion", context.Background(), tc.config.DomainID, tc.config.ClientID, tc.config.Version)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
 func TestChangeState(t *testing.T) {
 	svc := newService()
 
@@ -941,7 +1749,7 @@ func TestChangeState(t *testing.T) {
 			repoCall := boot.On("RetrieveByID", context.Background(), tc.domainID, tc.id).Return(c, tc.retrieveErr)
 			sdkCall := sdk.On("ConnectClients", mock.Anything, mock.Anything, []string{"Publish", "Subscribe"}, mock.Anything, tc.token).Return(tc.connectErr)
 			repoCall1 := boot.On("ChangeState", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(tc.stateErr)
-			err := svc.ChangeState(context.Background(), tc.session, tc.token, tc.id, tc.state)
+			_, err := svc.ChangeState(context.Background(), tc.session, tc.token, tc.id, tc.state)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			sdkCall.Unset()
 			repoCall.Unset()
@@ -950,6 +1758,76 @@ func TestChangeState(t *testing.T) {
 	}
 }
 
+func TestChangeStateOrder(t *testing.T) {
+	svc := newService()
+
+	c := config
+	c.Channels = []bootstrap.Channel{
+		{ID: testsutil.GenerateUUID(t), Role: "control"},
+		{ID: testsutil.GenerateUUID(t), Role: "data"},
+		{ID: testsutil.GenerateUUID(t), Role: "data"},
+	}
+
+	cases := []struct {
+		desc        string
+		state       bootstrap.State
+		failed      int
+		connections []bootstrap.Connection
+		err         error
+	}{
+		{
+			desc:  "connect channels in order",
+			state: bootstrap.Active,
+			connections: []bootstrap.Connection{
+				{ChannelID: c.Channels[0].ID, Role: "control", Connected: true},
+				{ChannelID: c.Channels[1].ID, Role: "data", Connected: true},
+				{ChannelID: c.Channels[2].ID, Role: "data", Connected: true},
+			},
+			err: nil,
+		},
+		{
+			desc:   "roll back connections on failed Connect",
+			state:  bootstrap.Active,
+			failed: 3,
+			connections: []bootstrap.Connection{
+				{ChannelID: c.Channels[0].ID, Role: "control"},
+				{ChannelID: c.Channels[1].ID, Role: "data"},
+				{ChannelID: c.Channels[2].ID, Role: "data"},
+			},
+			err: bootstrap.ErrClients,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var connected []string
+			repoCall := boot.On("RetrieveByID", context.Background(), domainID, c.ClientID).Return(c, nil)
+			sdkCall := sdk.On("ConnectClients", mock.Anything, []string{c.ClientID}, []string{"Publish", "Subscribe"}, domainID, validToken).Return(func(id string, _, _ []string, _, _ string) errors.SDKError {
+				connected = append(connected, id)
+				if len(connected) == tc.failed {
+					return errors.NewSDKError(svcerr.ErrCreateEntity)
+				}
+				return nil
+			})
+			sdkCall1 := sdk.On("DisconnectClients", mock.Anything, []string{c.ClientID}, []string{"Publish", "Subscribe"}, domainID, validToken).Return(nil)
+			repoCall1 := boot.On("ChangeState", context.Background(), domainID, c.ClientID, tc.state).Return(nil)
+			conns, err := svc.ChangeState(context.Background(), smqauthn.Session{DomainID: domainID}, validToken, c.ClientID, tc.state)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.connections, conns, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.connections, conns))
+			assert.Equal(t, []string{c.Channels[0].ID, c.Channels[1].ID, c.Channels[2].ID}, connected, fmt.Sprintf("%s: expected channels to be connected in order\n", tc.desc))
+			if tc.err != nil {
+				sdk.AssertCalled(t, "DisconnectClients", c.Channels[0].ID, []string{c.ClientID}, []string{"Publish", "Subscribe"}, domainID, validToken)
+				sdk.AssertCalled(t, "DisconnectClients", c.Channels[1].ID, []string{c.ClientID}, []string{"Publish", "Subscribe"}, domainID, validToken)
+				sdk.AssertNotCalled(t, "DisconnectClients", c.Channels[2].ID, []string{c.ClientID}, []string{"Publish", "Subscribe"}, domainID, validToken)
+			}
+			repoCall.Unset()
+			sdkCall.Unset()
+			sdkCall1.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
 func TestUpdateChannelHandler(t *testing.T) {
 	svc := newService()
 
@@ -72,6 +72,17 @@ func (tm *tracingMiddleware) UpdateCert(ctx context.Context, session smqauthn.Se
 	return tm.svc.UpdateCert(ctx, session, clientID, clientCert, clientKey, caCert)
 }
 
+// IssueCert traces the "IssueCert" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) IssueCert(ctx context.Context, session smqauthn.Session, token, clientID, ttl string) (bootstrap.Config, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_issue_cert", trace.WithAttributes(
+		attribute.String("client_id", clientID),
+		attribute.String("ttl", ttl),
+	))
+	defer span.End()
+
+	return tm.svc.IssueCert(ctx, session, token, clientID, ttl)
+}
+
 // UpdateConnections traces the "UpdateConnections" operation of the wrapped bootstrap.Service.
 func (tm *tracingMiddleware) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error {
 	ctx, span := tm.tracer.Start(ctx, "svc_update_connections", trace.WithAttributes(
@@ -94,14 +105,87 @@ func (tm *tracingMiddleware) List(ctx context.Context, session smqauthn.Session,
 	return tm.svc.List(ctx, session, filter, offset, limit)
 }
 
+// ExportConfigs traces the "ExportConfigs" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) ExportConfigs(ctx context.Context, session smqauthn.Session, filter bootstrap.Filter, key []byte) (bootstrap.Bundle, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_export_configs", trace.WithAttributes(
+		attribute.Bool("encrypted", len(key) > 0),
+	))
+	defer span.End()
+
+	return tm.svc.ExportConfigs(ctx, session, filter, key)
+}
+
+// ImportConfigs traces the "ImportConfigs" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) ImportConfigs(ctx context.Context, session smqauthn.Session, token string, bundle bootstrap.Bundle, key []byte, strategy bootstrap.ConflictStrategy) (bootstrap.ImportSummary, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_import_configs", trace.WithAttributes(
+		attribute.Int("version", bundle.Version),
+		attribute.Int("configs", len(bundle.Configs)),
+		attribute.String("strategy", string(strategy)),
+	))
+	defer span.End()
+
+	return tm.svc.ImportConfigs(ctx, session, token, bundle, key, strategy)
+}
+
+// Reconcile traces the "Reconcile" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) Reconcile(ctx context.Context, session smqauthn.Session, token string, repair bool) (bootstrap.ReconcileReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_reconcile_configs", trace.WithAttributes(
+		attribute.Bool("repair", repair),
+	))
+	defer span.End()
+
+	return tm.svc.Reconcile(ctx, session, token, repair)
+}
+
+// CreateRollout traces the "CreateRollout" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) CreateRollout(ctx context.Context, session smqauthn.Session, r bootstrap.Rollout) (bootstrap.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_rollout", trace.WithAttributes(
+		attribute.Int("targets", len(r.ClientIDs)),
+		attribute.Int("percentage", int(r.Percentage)),
+	))
+	defer span.End()
+
+	return tm.svc.CreateRollout(ctx, session, r)
+}
+
+// ViewRollout traces the "ViewRollout" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_rollout", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewRollout(ctx, session, id)
+}
+
+// PromoteRollout traces the "PromoteRollout" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) PromoteRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_promote_rollout", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.PromoteRollout(ctx, session, id)
+}
+
+// RollBackRollout traces the "RollBackRollout" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) RollBackRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_roll_back_rollout", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RollBackRollout(ctx, session, id)
+}
+
 // Remove traces the "Remove" operation of the wrapped bootstrap.Service.
-func (tm *tracingMiddleware) Remove(ctx context.Context, session smqauthn.Session, id string) error {
+func (tm *tracingMiddleware) Remove(ctx context.Context, session smqauthn.Session, token, id string) error {
 	ctx, span := tm.tracer.Start(ctx, "svc_remove_user", trace.WithAttributes(
 		attribute.String("id", id),
 	))
 	defer span.End()
 
-	return tm.svc.Remove(ctx, session, id)
+	return tm.svc.Remove(ctx, session, token, id)
 }
 
 // Bootstrap traces the "Bootstrap" operation of the wrapped bootstrap.Service.
@@ -117,7 +201,7 @@ func (tm *tracingMiddleware) Bootstrap(ctx context.Context, externalKey, externa
 }
 
 // ChangeState traces the "ChangeState" operation of the wrapped bootstrap.Service.
-func (tm *tracingMiddleware) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) error {
+func (tm *tracingMiddleware) ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state bootstrap.State) ([]bootstrap.Connection, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_change_state", trace.WithAttributes(
 		attribute.String("id", id),
 		attribute.String("state", state.String()),
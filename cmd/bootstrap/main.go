@@ -20,6 +20,9 @@ import (
 	"github.com/absmach/magistrala/bootstrap/middleware"
 	bootstrappg "github.com/absmach/magistrala/bootstrap/postgres"
 	"github.com/absmach/magistrala/bootstrap/tracing"
+	redisclient "github.com/absmach/magistrala/internal/clients/redis"
+	"github.com/absmach/magistrala/pkg/ratelimit"
+	rlredis "github.com/absmach/magistrala/pkg/ratelimit/redis"
 	"github.com/absmach/supermq"
 	smqlog "github.com/absmach/supermq/logger"
 	authsvcAuthn "github.com/absmach/supermq/pkg/authn/authsvc"
@@ -49,13 +52,14 @@ import (
 )
 
 const (
-	svcName          = "bootstrap"
-	envPrefixDB      = "SMQ_BOOTSTRAP_DB_"
-	envPrefixHTTP    = "SMQ_BOOTSTRAP_HTTP_"
-	envPrefixAuth    = "SMQ_AUTH_GRPC_"
-	envPrefixDomains = "SMQ_DOMAINS_GRPC_"
-	defDB            = "bootstrap"
-	defSvcHTTPPort   = "9013"
+	svcName            = "bootstrap"
+	envPrefixDB        = "SMQ_BOOTSTRAP_DB_"
+	envPrefixHTTP      = "SMQ_BOOTSTRAP_HTTP_"
+	envPrefixAuth      = "SMQ_AUTH_GRPC_"
+	envPrefixDomains   = "SMQ_DOMAINS_GRPC_"
+	envPrefixRateLimit = "SMQ_BOOTSTRAP_RATE_LIMIT_"
+	defDB              = "bootstrap"
+	defSvcHTTPPort     = "9013"
 
 	stream   = "events.supermq.clients"
 	streamID = "supermq.bootstrap"
@@ -69,6 +73,7 @@ type config struct {
 	JaegerURL           url.URL `env:"SMQ_JAEGER_URL"                 envDefault:"http://localhost:4318/v1/traces"`
 	SendTelemetry       bool    `env:"SMQ_SEND_TELEMETRY"             envDefault:"true"`
 	InstanceID          string  `env:"SMQ_BOOTSTRAP_INSTANCE_ID"      envDefault:""`
+	CacheURL            string  `env:"SMQ_BOOTSTRAP_CACHE_URL"        envDefault:"redis://localhost:6379/0"`
 	ESURL               string  `env:"SMQ_ES_URL"                     envDefault:"nats://localhost:4222"`
 	TraceRatio          float64 `env:"SMQ_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
 	SpicedbHost         string  `env:"SMQ_SPICEDB_HOST"               envDefault:"localhost"`
@@ -195,7 +200,22 @@ func main() {
 		exitCode = 1
 		return
 	}
-	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, authn, bootstrap.NewConfigReader([]byte(cfg.EncKey)), logger, cfg.InstanceID), logger)
+	rlCfg := ratelimit.Config{}
+	if err := env.ParseWithOptions(&rlCfg, env.Options{Prefix: envPrefixRateLimit}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s rate limit configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	cacheClient, err := redisclient.Connect(cfg.CacheURL)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer cacheClient.Close()
+	rateLimit := ratelimit.Middleware(rlredis.NewStore(cacheClient), rlCfg, logger)
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, authn, rateLimit, bootstrap.NewConfigReader([]byte(cfg.EncKey)), logger, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
@@ -219,6 +239,7 @@ func newService(ctx context.Context, authz smqauthz.Authorization, policySvc pol
 	database := pgclient.NewDatabase(db, dbConfig, tracer)
 
 	repoConfig := bootstrappg.NewConfigRepository(database, logger)
+	repoRollout := bootstrappg.NewRolloutRepository(database, logger)
 
 	config := mgsdk.Config{
 		ClientsURL: cfg.ClientsURL,
@@ -227,7 +248,7 @@ func newService(ctx context.Context, authz smqauthz.Authorization, policySvc pol
 	sdk := mgsdk.NewSDK(config)
 	idp := uuid.New()
 
-	svc := bootstrap.New(policySvc, repoConfig, sdk, []byte(cfg.EncKey), idp)
+	svc := bootstrap.New(policySvc, repoConfig, repoRollout, sdk, []byte(cfg.EncKey), idp)
 
 	publisher, err := store.NewPublisher(ctx, cfg.ESURL, streamID)
 	if err != nil {
@@ -12,6 +12,12 @@ import (
 	"os"
 
 	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala/consumers/writers"
+	redisclient "github.com/absmach/magistrala/internal/clients/redis"
+	"github.com/absmach/magistrala/pkg/ratelimit"
+	rlredis "github.com/absmach/magistrala/pkg/ratelimit/redis"
+	"github.com/absmach/magistrala/readers"
+	httpapi "github.com/absmach/magistrala/readers/api"
 	"github.com/absmach/magistrala/readers/postgres"
 	"github.com/absmach/supermq"
 	smqlog "github.com/absmach/supermq/logger"
@@ -22,28 +28,29 @@ import (
 	"github.com/absmach/supermq/pkg/server"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
 	"github.com/absmach/supermq/pkg/uuid"
-	"github.com/absmach/supermq/readers"
-	httpapi "github.com/absmach/supermq/readers/api"
 	"github.com/caarlos0/env/v11"
 	"github.com/jmoiron/sqlx"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	svcName           = "postgres-reader"
-	envPrefixDB       = "SMQ_POSTGRES_"
-	envPrefixHTTP     = "SMQ_POSTGRES_READER_HTTP_"
-	envPrefixAuth     = "SMQ_AUTH_GRPC_"
-	envPrefixClients  = "SMQ_CLIENTS_AUTH_GRPC_"
-	envPrefixChannels = "SMQ_CHANNELS_GRPC_"
-	defDB             = "supermq"
-	defSvcHTTPPort    = "9009"
+	svcName            = "postgres-reader"
+	envPrefixDB        = "SMQ_POSTGRES_"
+	envPrefixHTTP      = "SMQ_POSTGRES_READER_HTTP_"
+	envPrefixAuth      = "SMQ_AUTH_GRPC_"
+	envPrefixClients   = "SMQ_CLIENTS_AUTH_GRPC_"
+	envPrefixChannels  = "SMQ_CHANNELS_GRPC_"
+	envPrefixRateLimit = "SMQ_POSTGRES_READER_RATE_LIMIT_"
+	defDB              = "supermq"
+	defSvcHTTPPort     = "9009"
 )
 
 type config struct {
 	LogLevel      string `env:"SMQ_POSTGRES_READER_LOG_LEVEL"     envDefault:"info"`
 	SendTelemetry bool   `env:"SMQ_SEND_TELEMETRY"                envDefault:"true"`
 	InstanceID    string `env:"SMQ_POSTGRES_READER_INSTANCE_ID"   envDefault:""`
+	TableNaming   string `env:"SMQ_POSTGRES_READER_TABLE_NAMING"  envDefault:"single"`
+	CacheURL      string `env:"SMQ_POSTGRES_READER_CACHE_URL"     envDefault:"redis://localhost:6379/0"`
 }
 
 func main() {
@@ -133,7 +140,14 @@ func main() {
 	defer authnHandler.Close()
 	logger.Info("authn successfully connected to auth gRPC server " + authnHandler.Secure())
 
-	repo := newService(db, logger)
+	naming, err := writers.ParseNaming(cfg.TableNaming)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s table naming strategy: %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	repo := newService(db, naming, logger)
 
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
@@ -141,7 +155,22 @@ func main() {
 		exitCode = 1
 		return
 	}
-	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(repo, authn, clientsClient, channelsClient, svcName, cfg.InstanceID), logger)
+	rlCfg := ratelimit.Config{}
+	if err := env.ParseWithOptions(&rlCfg, env.Options{Prefix: envPrefixRateLimit}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s rate limit configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	cacheClient, err := redisclient.Connect(cfg.CacheURL)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer cacheClient.Close()
+	rateLimit := ratelimit.Middleware(rlredis.NewStore(cacheClient), rlCfg, logger)
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(repo, authn, clientsClient, channelsClient, rateLimit, svcName, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
@@ -161,8 +190,8 @@ func main() {
 	}
 }
 
-func newService(db *sqlx.DB, logger *slog.Logger) readers.MessageRepository {
-	svc := postgres.New(db)
+func newService(db *sqlx.DB, naming writers.Naming, logger *slog.Logger) readers.MessageRepository {
+	svc := postgres.New(db, naming)
 	svc = httpapi.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("postgres", "message_reader")
 	svc = httpapi.MetricsMiddleware(svc, counter, latency)
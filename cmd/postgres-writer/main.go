@@ -14,8 +14,10 @@ import (
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	consumertracing "github.com/absmach/magistrala/consumers/tracing"
+	"github.com/absmach/magistrala/consumers/writers"
 	httpapi "github.com/absmach/magistrala/consumers/writers/api"
 	writerpg "github.com/absmach/magistrala/consumers/writers/postgres"
+	mgprometheus "github.com/absmach/magistrala/pkg/prometheus"
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/consumers"
 	smqlog "github.com/absmach/supermq/logger"
@@ -41,13 +43,15 @@ const (
 )
 
 type config struct {
-	LogLevel      string  `env:"SMQ_POSTGRES_WRITER_LOG_LEVEL"     envDefault:"info"`
-	ConfigPath    string  `env:"SMQ_POSTGRES_WRITER_CONFIG_PATH"   envDefault:"/config.toml"`
-	BrokerURL     string  `env:"SMQ_MESSAGE_BROKER_URL"            envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"SMQ_JAEGER_URL"                    envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"SMQ_SEND_TELEMETRY"                envDefault:"true"`
-	InstanceID    string  `env:"SMQ_POSTGRES_WRITER_INSTANCE_ID"   envDefault:""`
-	TraceRatio    float64 `env:"SMQ_JAEGER_TRACE_RATIO"            envDefault:"1.0"`
+	LogLevel          string  `env:"SMQ_POSTGRES_WRITER_LOG_LEVEL"           envDefault:"info"`
+	ConfigPath        string  `env:"SMQ_POSTGRES_WRITER_CONFIG_PATH"         envDefault:"/config.toml"`
+	BrokerURL         string  `env:"SMQ_MESSAGE_BROKER_URL"                  envDefault:"nats://localhost:4222"`
+	JaegerURL         url.URL `env:"SMQ_JAEGER_URL"                          envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry     bool    `env:"SMQ_SEND_TELEMETRY"                      envDefault:"true"`
+	InstanceID        string  `env:"SMQ_POSTGRES_WRITER_INSTANCE_ID"         envDefault:""`
+	TraceRatio        float64 `env:"SMQ_JAEGER_TRACE_RATIO"                  envDefault:"1.0"`
+	DeadLetterSubject string  `env:"SMQ_POSTGRES_WRITER_DEAD_LETTER_SUBJECT" envDefault:""`
+	TableNaming       string  `env:"SMQ_POSTGRES_WRITER_TABLE_NAMING"       envDefault:"single"`
 }
 
 func main() {
@@ -116,10 +120,20 @@ func main() {
 	defer pubSub.Close()
 	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
 
-	repo := newService(db, logger)
+	naming, err := writers.ParseNaming(cfg.TableNaming)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s table naming strategy: %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	repo := newService(db, naming, logger)
 	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
 
-	if err = consumers.Start(ctx, svcName, pubSub, repo, cfg.ConfigPath, logger); err != nil {
+	failed := mgprometheus.MakeCounter("postgres", "message_writer", "failed_writes", "Number of messages that failed to be written.", "status")
+	sub := writers.NewDeadLetter(pubSub, pubSub, cfg.DeadLetterSubject, failed, logger)
+
+	if err = consumers.Start(ctx, svcName, sub, repo, cfg.ConfigPath, logger); err != nil {
 		logger.Error(fmt.Sprintf("failed to create Postgres writer: %s", err))
 		exitCode = 1
 		return
@@ -145,8 +159,8 @@ func main() {
 	}
 }
 
-func newService(db *sqlx.DB, logger *slog.Logger) consumers.BlockingConsumer {
-	svc := writerpg.New(db)
+func newService(db *sqlx.DB, naming writers.Naming, logger *slog.Logger) consumers.BlockingConsumer {
+	svc := writerpg.New(db, naming)
 	svc = httpapi.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("postgres", "message_writer")
 	svc = httpapi.MetricsMiddleware(svc, counter, latency)
@@ -14,9 +14,15 @@ import (
 	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
+	redisclient "github.com/absmach/magistrala/internal/clients/redis"
+	mgprometheus "github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/ratelimit"
+	rlredis "github.com/absmach/magistrala/pkg/ratelimit/redis"
 	"github.com/absmach/magistrala/re"
 	httpapi "github.com/absmach/magistrala/re/api"
 	repg "github.com/absmach/magistrala/re/postgres"
+	rereader "github.com/absmach/magistrala/re/reader"
+	reredis "github.com/absmach/magistrala/re/redis"
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/consumers"
 	smqlog "github.com/absmach/supermq/logger"
@@ -25,25 +31,29 @@ import (
 	authzsvc "github.com/absmach/supermq/pkg/authz/authsvc"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
+	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
 	pgclient "github.com/absmach/supermq/pkg/postgres"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
 	"github.com/absmach/supermq/pkg/server"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/caarlos0/env/v11"
 	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	svcName        = "rules_engine"
-	envPrefixDB    = "SMQ_RE_DB_"
-	envPrefixHTTP  = "SMQ_RE_HTTP_"
-	envPrefixAuth  = "SMQ_AUTH_GRPC_"
-	defDB          = "r"
-	defSvcHTTPPort = "9008"
+	svcName            = "rules_engine"
+	envPrefixDB        = "SMQ_RE_DB_"
+	envPrefixHTTP      = "SMQ_RE_HTTP_"
+	envPrefixAuth      = "SMQ_AUTH_GRPC_"
+	envPrefixRateLimit = "SMQ_RE_RATE_LIMIT_"
+	defDB              = "r"
+	defSvcHTTPPort     = "9008"
 )
 
 type config struct {
@@ -57,6 +67,9 @@ type config struct {
 	TraceRatio       float64       `env:"SMQ_JAEGER_TRACE_RATIO"     envDefault:"1.0"`
 	ConfigPath       string        `env:"SMQ_RE_CONFIG_PATH"         envDefault:"/config.toml"`
 	BrokerURL        string        `env:"SMQ_MESSAGE_BROKER_URL"     envDefault:"nats://localhost:4222"`
+	MaxHops          int           `env:"SMQ_RE_MAX_HOPS"            envDefault:"8"`
+	MaxFailures      int           `env:"SMQ_RE_MAX_FAILURES"        envDefault:"5"`
+	ReaderURL        string        `env:"SMQ_READER_URL"             envDefault:"http://localhost:9011"`
 }
 
 func main() {
@@ -130,13 +143,13 @@ func main() {
 	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
 
 	// Setup new redis cache client
-	// cacheclient, err := redisclient.Connect(cfg.CacheURL)
-	// if err != nil {
-	// 	logger.Error(err.Error())
-	// 	exitCode = 1
-	// 	return
-	// }
-	// defer cacheclient.Close()
+	cacheclient, err := redisclient.Connect(cfg.CacheURL)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer cacheclient.Close()
 
 	grpcCfg := grpcclient.Config{}
 	if err := env.ParseWithOptions(&grpcCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
@@ -162,7 +175,7 @@ func main() {
 	defer authzClient.Close()
 	logger.Info("AuthZ  successfully connected to auth gRPC server " + authnClient.Secure())
 
-	svc, err := newService(ctx, db, dbConfig, authz, cfg.ESURL, tracer, logger)
+	svc, err := newService(ctx, db, dbConfig, authz, cacheclient, pubSub, cfg.MaxHops, cfg.MaxFailures, cfg.ReaderURL, cfg.ESURL, tracer, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create services: %s", err))
 		exitCode = 1
@@ -174,7 +187,22 @@ func main() {
 		exitCode = 1
 		return
 	}
-	httpSvc := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, authn, logger, cfg.InstanceID), logger)
+	go func() {
+		for err := range svc.Errors() {
+			if err != nil {
+				logger.Warn(fmt.Sprintf("failed to process message: %s", err))
+			}
+		}
+	}()
+	rlCfg := ratelimit.Config{}
+	if err := env.ParseWithOptions(&rlCfg, env.Options{Prefix: envPrefixRateLimit}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s rate limit configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	rateLimit := ratelimit.Middleware(rlredis.NewStore(cacheclient), rlCfg, logger)
+
+	httpSvc := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, authn, rateLimit, logger, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
@@ -195,13 +223,18 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorization, esURL string, tracer trace.Tracer, logger *slog.Logger) (re.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz mgauthz.Authorization, cacheClient *redis.Client, pubSub messaging.PubSub, maxHops, maxFailures int, readerURL, esURL string, tracer trace.Tracer, logger *slog.Logger) (re.Service, error) {
 	database := pgclient.NewDatabase(db, dbConfig, tracer)
 	repo := repg.NewRepository(database)
 	idp := uuid.New()
 
+	windows := reredis.NewWindowStore(cacheClient)
+	stats := reredis.NewStatsStore(cacheClient)
+	reader := rereader.New(mgsdk.NewSDK(mgsdk.Config{ReaderURL: readerURL}))
+	runs := mgprometheus.MakeCounter(svcName, "rules", "runs", "Number of rule runs by result.", "rule_id", "result")
+
 	// csvc = authzmw.AuthorizationMiddleware(csvc, authz)
-	csvc := re.NewService(repo, idp, nil)
+	csvc := re.NewService(repo, idp, pubSub, windows, stats, reader, runs, maxHops, maxFailures, logger)
 
 	return csvc, nil
 }
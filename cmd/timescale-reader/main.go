@@ -12,6 +12,12 @@ import (
 	"os"
 
 	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala/consumers/writers"
+	redisclient "github.com/absmach/magistrala/internal/clients/redis"
+	"github.com/absmach/magistrala/pkg/ratelimit"
+	rlredis "github.com/absmach/magistrala/pkg/ratelimit/redis"
+	"github.com/absmach/magistrala/readers"
+	httpapi "github.com/absmach/magistrala/readers/api"
 	"github.com/absmach/magistrala/readers/timescale"
 	"github.com/absmach/supermq"
 	smqlog "github.com/absmach/supermq/logger"
@@ -22,28 +28,29 @@ import (
 	"github.com/absmach/supermq/pkg/server"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
 	"github.com/absmach/supermq/pkg/uuid"
-	"github.com/absmach/supermq/readers"
-	httpapi "github.com/absmach/supermq/readers/api"
 	"github.com/caarlos0/env/v11"
 	"github.com/jmoiron/sqlx"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	svcName           = "timescaledb-reader"
-	envPrefixDB       = "SMQ_TIMESCALE_"
-	envPrefixHTTP     = "SMQ_TIMESCALE_READER_HTTP_"
-	envPrefixAuth     = "SMQ_AUTH_GRPC_"
-	envPrefixClients  = "SMQ_CLIENTS_AUTH_GRPC_"
-	envPrefixChannels = "SMQ_CHANNELS_GRPC_"
-	defDB             = "messages"
-	defSvcHTTPPort    = "9011"
+	svcName            = "timescaledb-reader"
+	envPrefixDB        = "SMQ_TIMESCALE_"
+	envPrefixHTTP      = "SMQ_TIMESCALE_READER_HTTP_"
+	envPrefixAuth      = "SMQ_AUTH_GRPC_"
+	envPrefixClients   = "SMQ_CLIENTS_AUTH_GRPC_"
+	envPrefixChannels  = "SMQ_CHANNELS_GRPC_"
+	envPrefixRateLimit = "SMQ_TIMESCALE_READER_RATE_LIMIT_"
+	defDB              = "messages"
+	defSvcHTTPPort     = "9011"
 )
 
 type config struct {
 	LogLevel      string `env:"SMQ_TIMESCALE_READER_LOG_LEVEL"    envDefault:"info"`
 	SendTelemetry bool   `env:"SMQ_SEND_TELEMETRY"                envDefault:"true"`
 	InstanceID    string `env:"SMQ_TIMESCALE_READER_INSTANCE_ID"  envDefault:""`
+	TableNaming   string `env:"SMQ_TIMESCALE_READER_TABLE_NAMING" envDefault:"single"`
+	CacheURL      string `env:"SMQ_TIMESCALE_READER_CACHE_URL"    envDefault:"redis://localhost:6379/0"`
 }
 
 func main() {
@@ -83,7 +90,14 @@ func main() {
 	}
 	defer db.Close()
 
-	repo := newService(db, logger)
+	naming, err := writers.ParseNaming(cfg.TableNaming)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s table naming strategy: %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	repo := newService(db, naming, logger)
 
 	clientsClientCfg := grpcclient.Config{}
 	if err := env.ParseWithOptions(&clientsClientCfg, env.Options{Prefix: envPrefixClients}); err != nil {
@@ -140,7 +154,22 @@ func main() {
 		exitCode = 1
 		return
 	}
-	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(repo, authn, clientsClient, channelsClient, svcName, cfg.InstanceID), logger)
+	rlCfg := ratelimit.Config{}
+	if err := env.ParseWithOptions(&rlCfg, env.Options{Prefix: envPrefixRateLimit}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s rate limit configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	cacheClient, err := redisclient.Connect(cfg.CacheURL)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer cacheClient.Close()
+	rateLimit := ratelimit.Middleware(rlredis.NewStore(cacheClient), rlCfg, logger)
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(repo, authn, clientsClient, channelsClient, rateLimit, svcName, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
@@ -160,8 +189,8 @@ func main() {
 	}
 }
 
-func newService(db *sqlx.DB, logger *slog.Logger) readers.MessageRepository {
-	svc := timescale.New(db)
+func newService(db *sqlx.DB, naming writers.Naming, logger *slog.Logger) readers.MessageRepository {
+	svc := timescale.New(db, naming)
 	svc = httpapi.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("timescale", "message_reader")
 	svc = httpapi.MetricsMiddleware(svc, counter, latency)
@@ -6,8 +6,8 @@ package api
 import (
 	"context"
 
+	notifiers "github.com/absmach/magistrala/consumers/notifiers"
 	apiutil "github.com/absmach/supermq/api/http/util"
-	notifiers "github.com/absmach/supermq/consumers/notifiers"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/go-kit/kit/endpoint"
 )
@@ -19,8 +19,12 @@ func createSubscriptionEndpoint(svc notifiers.Service) endpoint.Endpoint {
 			return createSubRes{}, errors.Wrap(apiutil.ErrValidation, err)
 		}
 		sub := notifiers.Subscription{
-			Contact: req.Contact,
-			Topic:   req.Topic,
+			Contact:    req.Contact,
+			Topic:      req.Topic,
+			Template:   req.Template,
+			Schedule:   req.Schedule,
+			Digest:     req.Digest,
+			Recipients: req.Recipients,
 		}
 		id, err := svc.CreateSubscription(ctx, req.token, sub)
 		if err != nil {
@@ -45,10 +49,14 @@ func viewSubscriptionEndpint(svc notifiers.Service) endpoint.Endpoint {
 			return viewSubRes{}, err
 		}
 		res := viewSubRes{
-			ID:      sub.ID,
-			OwnerID: sub.OwnerID,
-			Contact: sub.Contact,
-			Topic:   sub.Topic,
+			ID:         sub.ID,
+			OwnerID:    sub.OwnerID,
+			Contact:    sub.Contact,
+			Topic:      sub.Topic,
+			Template:   sub.Template,
+			Schedule:   sub.Schedule,
+			Digest:     sub.Digest,
+			Recipients: sub.Recipients,
 		}
 		return res, nil
 	}
@@ -77,10 +85,14 @@ func listSubscriptionsEndpoint(svc notifiers.Service) endpoint.Endpoint {
 		}
 		for _, sub := range page.Subscriptions {
 			r := viewSubRes{
-				ID:      sub.ID,
-				OwnerID: sub.OwnerID,
-				Contact: sub.Contact,
-				Topic:   sub.Topic,
+				ID:         sub.ID,
+				OwnerID:    sub.OwnerID,
+				Contact:    sub.Contact,
+				Topic:      sub.Topic,
+				Template:   sub.Template,
+				Schedule:   sub.Schedule,
+				Digest:     sub.Digest,
+				Recipients: sub.Recipients,
 			}
 			res.Subscriptions = append(res.Subscriptions, r)
 		}
@@ -101,3 +113,41 @@ func deleteSubscriptionEndpint(svc notifiers.Service) endpoint.Endpoint {
 		return removeSubRes{}, nil
 	}
 }
+
+func listDeliveriesEndpoint(svc notifiers.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listDeliveriesReq)
+		if err := req.validate(); err != nil {
+			return listDeliveriesRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		pm := notifiers.DeliveriesPageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+			Status: notifiers.DeliveryStatus(req.status),
+		}
+		page, err := svc.ListDeliveries(ctx, req.token, req.id, pm)
+		if err != nil {
+			return listDeliveriesRes{}, err
+		}
+		res := listDeliveriesRes{
+			Offset:     page.Offset,
+			Limit:      page.Limit,
+			Total:      page.Total,
+			Deliveries: []deliveryRes{},
+		}
+		for _, d := range page.Deliveries {
+			res.Deliveries = append(res.Deliveries, deliveryRes{
+				ID:             d.ID,
+				SubscriptionID: d.SubscriptionID,
+				Contact:        d.Contact,
+				Channel:        d.Channel,
+				Subtopic:       d.Subtopic,
+				Status:         string(d.Status),
+				Error:          d.Error,
+				CreatedAt:      d.CreatedAt,
+			})
+		}
+
+		return res, nil
+	}
+}
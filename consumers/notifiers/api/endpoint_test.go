@@ -13,11 +13,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/magistrala/consumers/notifiers/api"
 	"github.com/absmach/magistrala/consumers/notifiers/mocks"
 	"github.com/absmach/magistrala/internal/testsutil"
 	apiutil "github.com/absmach/supermq/api/http/util"
-	"github.com/absmach/supermq/consumers/notifiers"
 	smqlog "github.com/absmach/supermq/logger"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/uuid"
@@ -95,6 +95,10 @@ func TestCreate(t *testing.T) {
 
 	emptyTopic := toJSON(notifiers.Subscription{Contact: contact1})
 	emptyContact := toJSON(notifiers.Subscription{Topic: "topic123"})
+	invalidTemplate := toJSON(notifiers.Subscription{Topic: topic, Contact: contact1, Template: "{{.Payload"})
+	unknownFieldTemplate := toJSON(notifiers.Subscription{Topic: topic, Contact: contact1, Template: "{{.Value}}"})
+	contactAndRecipients := toJSON(notifiers.Subscription{Topic: topic, Contact: contact1, Recipients: &notifiers.Recipients{GroupID: "group", RoleID: "role"}})
+	invalidRecipients := toJSON(notifiers.Subscription{Topic: topic, Recipients: &notifiers.Recipients{GroupID: "group"}})
 
 	cases := []struct {
 		desc        string
@@ -141,6 +145,42 @@ func TestCreate(t *testing.T) {
 			location:    "",
 			err:         svcerr.ErrMalformedEntity,
 		},
+		{
+			desc:        "add with invalid template",
+			req:         invalidTemplate,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "add with template using unknown field",
+			req:         unknownFieldTemplate,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "add with contact and recipients",
+			req:         contactAndRecipients,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "add with invalid recipients",
+			req:         invalidRecipients,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+			err:         svcerr.ErrMalformedEntity,
+		},
 		{
 			desc:        "add with invalid auth token",
 			req:         data,
@@ -509,6 +549,95 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+func TestListDeliveries(t *testing.T) {
+	ss, svc := newServer()
+	defer ss.Close()
+	id := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		query  map[string]string
+		pm     notifiers.DeliveriesPageMetadata
+		status int
+		err    error
+	}{
+		{
+			desc:   "list deliveries successfully",
+			id:     id,
+			auth:   token,
+			pm:     notifiers.DeliveriesPageMetadata{Limit: 20},
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list failed deliveries",
+			id:     id,
+			auth:   token,
+			query:  map[string]string{"status": "failed", "offset": "5", "limit": "10"},
+			pm:     notifiers.DeliveriesPageMetadata{Offset: 5, Limit: 10, Status: notifiers.DeliveryFailed},
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list deliveries with invalid status",
+			id:     id,
+			auth:   token,
+			query:  map[string]string{"status": "unknown"},
+			status: http.StatusBadRequest,
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "list deliveries with limit too big",
+			id:     id,
+			auth:   token,
+			query:  map[string]string{"limit": "1000"},
+			status: http.StatusBadRequest,
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "list deliveries of not existing subscription",
+			id:     "not-existing",
+			auth:   token,
+			pm:     notifiers.DeliveriesPageMetadata{Limit: 20},
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+		{
+			desc:   "list deliveries with invalid auth token",
+			id:     id,
+			auth:   invalidToken,
+			pm:     notifiers.DeliveriesPageMetadata{Limit: 20},
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "list deliveries with empty auth token",
+			id:     id,
+			auth:   "",
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ListDeliveries", mock.Anything, tc.auth, tc.id, tc.pm).Return(notifiers.DeliveriesPage{}, tc.err)
+
+		req := testRequest{
+			client: ss.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/subscriptions/%s/deliveries%s", ss.URL, tc.id, makeQuery(tc.query)),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		svcCall.Unset()
+	}
+}
+
 func makeQuery(m map[string]string) string {
 	var ret string
 	for k, v := range m {
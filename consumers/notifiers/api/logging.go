@@ -10,7 +10,7 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/absmach/supermq/consumers/notifiers"
+	"github.com/absmach/magistrala/consumers/notifiers"
 )
 
 var _ notifiers.Service = (*loggingMiddleware)(nil)
@@ -112,6 +112,49 @@ func (lm *loggingMiddleware) RemoveSubscription(ctx context.Context, token, id s
 	return lm.svc.RemoveSubscription(ctx, token, id)
 }
 
+// ListDeliveries logs the list_deliveries request. It logs subscription ID, page metadata and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListDeliveries(ctx context.Context, token, id string, pm notifiers.DeliveriesPageMetadata) (res notifiers.DeliveriesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("subscription_id", id),
+			slog.Group("page",
+				slog.String("status", string(pm.Status)),
+				slog.Uint64("limit", uint64(pm.Limit)),
+				slog.Uint64("offset", uint64(pm.Offset)),
+				slog.Uint64("total", uint64(res.Total)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List deliveries failed", args...)
+			return
+		}
+		lm.logger.Info("List deliveries completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListDeliveries(ctx, token, id, pm)
+}
+
+// DeliverScheduled logs the deliver_scheduled request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) DeliverScheduled(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Deliver scheduled notifications failed", args...)
+			return
+		}
+		lm.logger.Info("Deliver scheduled notifications completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeliverScheduled(ctx)
+}
+
 // ConsumeBlocking logs the consume_blocking request. It logs the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) (err error) {
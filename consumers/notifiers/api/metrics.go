@@ -9,7 +9,7 @@ import (
 	"context"
 	"time"
 
-	"github.com/absmach/supermq/consumers/notifiers"
+	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/go-kit/kit/metrics"
 )
 
@@ -70,6 +70,26 @@ func (ms *metricsMiddleware) RemoveSubscription(ctx context.Context, token, id s
 	return ms.svc.RemoveSubscription(ctx, token, id)
 }
 
+// ListDeliveries instruments ListDeliveries method with metrics.
+func (ms *metricsMiddleware) ListDeliveries(ctx context.Context, token, id string, pm notifiers.DeliveriesPageMetadata) (notifiers.DeliveriesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_deliveries").Add(1)
+		ms.latency.With("method", "list_deliveries").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListDeliveries(ctx, token, id, pm)
+}
+
+// DeliverScheduled instruments DeliverScheduled method with metrics.
+func (ms *metricsMiddleware) DeliverScheduled(ctx context.Context) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "deliver_scheduled").Add(1)
+		ms.latency.With("method", "deliver_scheduled").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DeliverScheduled(ctx)
+}
+
 // ConsumeBlocking instruments ConsumeBlocking method with metrics.
 func (ms *metricsMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) error {
 	defer func(begin time.Time) {
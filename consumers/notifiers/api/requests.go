@@ -3,12 +3,24 @@
 
 package api
 
-import apiutil "github.com/absmach/supermq/api/http/util"
+import (
+	"github.com/absmach/magistrala/consumers/notifiers"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const maxLimitSize = 100
+
+var errDeliveryStatus = errors.New("invalid delivery status")
 
 type createSubReq struct {
-	token   string
-	Topic   string `json:"topic,omitempty"`
-	Contact string `json:"contact,omitempty"`
+	token      string
+	Topic      string                `json:"topic,omitempty"`
+	Contact    string                `json:"contact,omitempty"`
+	Template   string                `json:"template,omitempty"`
+	Schedule   *notifiers.Schedule   `json:"schedule,omitempty"`
+	Digest     *notifiers.Digest     `json:"digest,omitempty"`
+	Recipients *notifiers.Recipients `json:"recipients,omitempty"`
 }
 
 func (req createSubReq) validate() error {
@@ -18,9 +30,29 @@ func (req createSubReq) validate() error {
 	if req.Topic == "" {
 		return apiutil.ErrInvalidTopic
 	}
-	if req.Contact == "" {
+	if req.Recipients == nil && req.Contact == "" {
 		return apiutil.ErrInvalidContact
 	}
+	if req.Recipients != nil {
+		// Recipients replace the contact, so they can't be combined.
+		if req.Contact != "" {
+			return errors.Wrap(errors.ErrMalformedEntity, notifiers.ErrRecipients)
+		}
+		if err := req.Recipients.Validate(); err != nil {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+	if err := notifiers.ValidateTemplate(req.Template); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if req.Schedule != nil {
+		if err := req.Schedule.Validate(); err != nil {
+			return err
+		}
+	}
+	if req.Digest != nil {
+		return req.Digest.Validate()
+	}
 	return nil
 }
 
@@ -53,3 +85,29 @@ func (req listSubsReq) validate() error {
 	}
 	return nil
 }
+
+type listDeliveriesReq struct {
+	token  string
+	id     string
+	status string
+	offset uint
+	limit  uint
+}
+
+func (req listDeliveriesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.limit < 1 || req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	switch notifiers.DeliveryStatus(req.status) {
+	case "", notifiers.DeliverySent, notifiers.DeliveryFailed:
+		return nil
+	default:
+		return errors.Wrap(apiutil.ErrInvalidQueryParams, errDeliveryStatus)
+	}
+}
@@ -6,7 +6,9 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/supermq"
 )
 
@@ -15,6 +17,7 @@ var (
 	_ supermq.Response = (*viewSubRes)(nil)
 	_ supermq.Response = (*listSubsRes)(nil)
 	_ supermq.Response = (*removeSubRes)(nil)
+	_ supermq.Response = (*listDeliveriesRes)(nil)
 )
 
 type createSubRes struct {
@@ -36,10 +39,14 @@ func (res createSubRes) Empty() bool {
 }
 
 type viewSubRes struct {
-	ID      string `json:"id"`
-	OwnerID string `json:"owner_id"`
-	Contact string `json:"contact"`
-	Topic   string `json:"topic"`
+	ID         string                `json:"id"`
+	OwnerID    string                `json:"owner_id"`
+	Contact    string                `json:"contact"`
+	Topic      string                `json:"topic"`
+	Template   string                `json:"template,omitempty"`
+	Schedule   *notifiers.Schedule   `json:"schedule,omitempty"`
+	Digest     *notifiers.Digest     `json:"digest,omitempty"`
+	Recipients *notifiers.Recipients `json:"recipients,omitempty"`
 }
 
 func (res viewSubRes) Code() int {
@@ -86,3 +93,33 @@ func (res removeSubRes) Headers() map[string]string {
 func (res removeSubRes) Empty() bool {
 	return true
 }
+
+type deliveryRes struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscription_id"`
+	Contact        string    `json:"contact"`
+	Channel        string    `json:"channel"`
+	Subtopic       string    `json:"subtopic,omitempty"`
+	Status         string    `json:"status"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type listDeliveriesRes struct {
+	Offset     uint          `json:"offset"`
+	Limit      uint          `json:"limit"`
+	Total      uint          `json:"total"`
+	Deliveries []deliveryRes `json:"deliveries"`
+}
+
+func (res listDeliveriesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listDeliveriesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listDeliveriesRes) Empty() bool {
+	return false
+}
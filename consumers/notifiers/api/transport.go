@@ -10,10 +10,10 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/supermq"
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
-	"github.com/absmach/supermq/consumers/notifiers"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -27,6 +27,7 @@ const (
 	limitKey    = "limit"
 	topicKey    = "topic"
 	contactKey  = "contact"
+	statusKey   = "status"
 	defOffset   = 0
 	defLimit    = 20
 )
@@ -68,6 +69,13 @@ func MakeHandler(svc notifiers.Service, logger *slog.Logger, instanceID string)
 			opts...,
 		), "view").ServeHTTP)
 
+		r.Get("/{subID}/deliveries", otelhttp.NewHandler(kithttp.NewServer(
+			listDeliveriesEndpoint(svc),
+			decodeListDeliveries,
+			api.EncodeResponse,
+			opts...,
+		), "list_deliveries").ServeHTTP)
+
 		r.Delete("/{subID}", otelhttp.NewHandler(kithttp.NewServer(
 			deleteSubscriptionEndpint(svc),
 			decodeSubscription,
@@ -129,3 +137,30 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 
 	return req, nil
 }
+
+func decodeListDeliveries(_ context.Context, r *http.Request) (interface{}, error) {
+	req := listDeliveriesReq{
+		id:    chi.URLParam(r, "subID"),
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	status, err := apiutil.ReadStringQuery(r, statusKey, "")
+	if err != nil {
+		return listDeliveriesReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req.status = status
+
+	offset, err := apiutil.ReadNumQuery[uint64](r, offsetKey, defOffset)
+	if err != nil {
+		return listDeliveriesReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req.offset = uint(offset)
+
+	limit, err := apiutil.ReadNumQuery[uint64](r, limitKey, defLimit)
+	if err != nil {
+		return listDeliveriesReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req.limit = uint(limit)
+
+	return req, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"context"
+	"time"
+)
+
+// DeliveryStatus represents the outcome of a notification delivery attempt.
+type DeliveryStatus string
+
+const (
+	// DeliverySent indicates that the notification was sent.
+	DeliverySent DeliveryStatus = "sent"
+	// DeliveryFailed indicates that sending the notification failed.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Delivery represents a single attempt to notify a Subscription of a message
+// published to the Channel.
+type Delivery struct {
+	ID             string
+	SubscriptionID string
+	Contact        string
+	Channel        string
+	Subtopic       string
+	Status         DeliveryStatus
+	Error          string
+	CreatedAt      time.Time
+}
+
+// DeliveriesPage represents a page of Subscription deliveries.
+type DeliveriesPage struct {
+	DeliveriesPageMetadata
+	Total      uint
+	Deliveries []Delivery
+}
+
+// DeliveriesPageMetadata contains delivery page metadata that helps navigation.
+type DeliveriesPageMetadata struct {
+	Offset         uint
+	Limit          uint
+	SubscriptionID string
+	Status         DeliveryStatus
+}
+
+// DeliveriesRepository specifies a Delivery persistence API.
+//
+//go:generate mockery --name DeliveriesRepository --output=./mocks --filename deliveries.go --quiet --note "Copyright (c) Abstract Machines"
+type DeliveriesRepository interface {
+	// Save persists the deliveries.
+	Save(ctx context.Context, deliveries []Delivery) error
+
+	// RetrieveAll retrieves the deliveries for the given page metadata,
+	// starting from the most recent one.
+	RetrieveAll(ctx context.Context, pm DeliveriesPageMetadata) (DeliveriesPage, error)
+
+	// RemoveBefore removes the deliveries created before the given time.
+	RemoveBefore(ctx context.Context, before time.Time) error
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+// digestSamples is the maximal number of message payloads included in the
+// digest summary.
+const digestSamples = 3
+
+// ErrDigest indicates that subscription digest is invalid.
+var ErrDigest = errors.New("invalid subscription digest")
+
+// Digest batches subscription notifications. Instead of notifying each
+// message right away, messages are accumulated and a single summary is sent
+// at the end of the interval. Messages of severity at or above Severity
+// bypass the digest and are notified right away.
+type Digest struct {
+	// Interval is the interval between summaries in the Go duration
+	// format, e.g. "5m".
+	Interval string `json:"interval"`
+	Severity int    `json:"severity,omitempty"`
+}
+
+// Validate checks that the digest is well-formed.
+func (d Digest) Validate() error {
+	interval, err := time.ParseDuration(d.Interval)
+	if err != nil {
+		return errors.Wrap(ErrDigest, err)
+	}
+	if interval <= 0 {
+		return ErrDigest
+	}
+
+	return nil
+}
+
+// Bypasses reports whether the notification of the given severity bypasses
+// the digest.
+func (d Digest) Bypasses(severity int) bool {
+	return d.Severity > 0 && severity >= d.Severity
+}
+
+func (d Digest) interval() time.Duration {
+	interval, err := time.ParseDuration(d.Interval)
+	if err != nil || interval <= 0 {
+		return 0
+	}
+
+	return interval
+}
+
+// DigestSummary is the payload of the message which summarizes the messages
+// accumulated by the subscription digest.
+type DigestSummary struct {
+	Count  uint64    `json:"count"`
+	First  time.Time `json:"first"`
+	Last   time.Time `json:"last"`
+	Sample []string  `json:"sample"`
+}
+
+// digest accumulates messages of a single subscription.
+type digest struct {
+	msg     *messaging.Message
+	summary DigestSummary
+}
+
+func newDigest(msg *messaging.Message, first time.Time) *digest {
+	return &digest{
+		msg:     msg,
+		summary: DigestSummary{First: first},
+	}
+}
+
+func (d *digest) add(msg *messaging.Message, at time.Time) {
+	d.summary.Count++
+	d.summary.Last = at
+	if len(d.summary.Sample) < digestSamples {
+		d.summary.Sample = append(d.summary.Sample, string(msg.GetPayload()))
+	}
+}
+
+// message returns the summary message, published to the topic of the
+// accumulated messages.
+func (d *digest) message() (*messaging.Message, error) {
+	payload, err := json.Marshal(d.summary)
+	if err != nil {
+		return nil, err
+	}
+
+	return &messaging.Message{
+		Channel:  d.msg.GetChannel(),
+		Subtopic: d.msg.GetSubtopic(),
+		Protocol: d.msg.GetProtocol(),
+		Payload:  payload,
+		Created:  time.Now().UnixNano(),
+	}, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestValidate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		digest notifiers.Digest
+		err    error
+	}{
+		{
+			desc:   "valid digest",
+			digest: notifiers.Digest{Interval: "5m", Severity: 5},
+			err:    nil,
+		},
+		{
+			desc:   "invalid interval",
+			digest: notifiers.Digest{Interval: "5 minutes"},
+			err:    notifiers.ErrDigest,
+		},
+		{
+			desc:   "empty interval",
+			digest: notifiers.Digest{},
+			err:    notifiers.ErrDigest,
+		},
+		{
+			desc:   "negative interval",
+			digest: notifiers.Digest{Interval: "-1m"},
+			err:    notifiers.ErrDigest,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.digest.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestDigestBypasses(t *testing.T) {
+	cases := []struct {
+		desc     string
+		digest   notifiers.Digest
+		severity int
+		bypasses bool
+	}{
+		{
+			desc:     "severity below digest severity",
+			digest:   notifiers.Digest{Interval: "5m", Severity: 5},
+			severity: 4,
+			bypasses: false,
+		},
+		{
+			desc:     "severity at digest severity",
+			digest:   notifiers.Digest{Interval: "5m", Severity: 5},
+			severity: 5,
+			bypasses: true,
+		},
+		{
+			desc:     "digest without severity",
+			digest:   notifiers.Digest{Interval: "5m"},
+			severity: 10,
+			bypasses: false,
+		},
+	}
+
+	for _, tc := range cases {
+		bypasses := tc.digest.Bypasses(tc.severity)
+		assert.Equal(t, tc.bypasses, bypasses, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.bypasses, bypasses))
+	}
+}
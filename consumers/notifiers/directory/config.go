@@ -0,0 +1,17 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package directory
+
+import "time"
+
+// Config represents directory configuration. Username and Password are the
+// credentials of the user whose token is used to read Group role members,
+// Field is the user field used as the contact, either "email" or the key of
+// the user metadata, e.g. "phone".
+type Config struct {
+	Username string        `env:"MG_NOTIFIERS_DIRECTORY_USERNAME"  envDefault:""`
+	Password string        `env:"MG_NOTIFIERS_DIRECTORY_PASSWORD"  envDefault:""`
+	Field    string        `env:"MG_NOTIFIERS_DIRECTORY_FIELD"     envDefault:"email"`
+	CacheTTL time.Duration `env:"MG_NOTIFIERS_DIRECTORY_CACHE_TTL" envDefault:"1m"`
+}
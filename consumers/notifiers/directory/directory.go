@@ -0,0 +1,139 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package directory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/supermq/pkg/errors"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+)
+
+const (
+	emailField = "email"
+	pageLimit  = 100
+)
+
+var errUnauthorized = errors.New("directory user is not authorized")
+
+var _ notifiers.Directory = (*directory)(nil)
+
+type entry struct {
+	contacts []string
+	expires  time.Time
+}
+
+type directory struct {
+	sdk   mgsdk.SDK
+	cfg   Config
+	mu    sync.Mutex
+	token string
+	cache map[notifiers.Recipients]entry
+}
+
+// New instantiates the directory which resolves Group role members using the
+// SuperMQ SDK. Resolved contacts are cached for the configured TTL, so the
+// membership changes take effect once the cached contacts expire.
+func New(sdk mgsdk.SDK, cfg Config) notifiers.Directory {
+	return &directory{
+		sdk:   sdk,
+		cfg:   cfg,
+		cache: make(map[notifiers.Recipients]entry),
+	}
+}
+
+func (d *directory) Contacts(ctx context.Context, recipients notifiers.Recipients) ([]string, error) {
+	d.mu.Lock()
+	if e, ok := d.cache[recipients]; ok && time.Now().Before(e.expires) {
+		d.mu.Unlock()
+		return e.contacts, nil
+	}
+	token := d.token
+	d.mu.Unlock()
+
+	// The lock is not held during the SDK calls, so a slow resolution
+	// doesn't block the cached lookups of the other recipients.
+	contacts, token, err := d.contacts(token, recipients)
+	if errors.Contains(err, errUnauthorized) {
+		// The token may have expired, so a new one is issued once.
+		contacts, token, err = d.contacts("", recipients)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.token = token
+	if d.cfg.CacheTTL > 0 {
+		d.cache[recipients] = entry{contacts: contacts, expires: time.Now().Add(d.cfg.CacheTTL)}
+	}
+
+	return contacts, nil
+}
+
+// contacts resolves the recipients using the given token, issuing a new
+// one if the token is empty. It returns the token used for the resolution.
+func (d *directory) contacts(token string, recipients notifiers.Recipients) ([]string, string, error) {
+	if token == "" {
+		t, sdkErr := d.sdk.CreateToken(mgsdk.Login{Username: d.cfg.Username, Password: d.cfg.Password})
+		if sdkErr != nil {
+			return nil, "", sdkErr
+		}
+		token = t.AccessToken
+	}
+
+	var members []string
+	pm := mgsdk.PageMetadata{Limit: pageLimit}
+	for {
+		page, sdkErr := d.sdk.GroupRoleMembers(recipients.GroupID, recipients.RoleID, recipients.DomainID, pm, token)
+		if sdkErr != nil {
+			return nil, token, wrap(sdkErr)
+		}
+		members = append(members, page.Members...)
+		pm.Offset += uint64(len(page.Members))
+		if len(page.Members) == 0 || pm.Offset >= page.Total {
+			break
+		}
+	}
+
+	contacts := make([]string, 0, len(members))
+	for _, id := range members {
+		user, sdkErr := d.sdk.User(id, token)
+		if sdkErr != nil {
+			return nil, token, wrap(sdkErr)
+		}
+		if contact := d.contact(user); contact != "" {
+			contacts = append(contacts, contact)
+		}
+	}
+
+	return contacts, token, nil
+}
+
+// contact returns the configured contact field of the user. Users
+// without the field are skipped.
+func (d *directory) contact(user mgsdk.User) string {
+	if d.cfg.Field == "" || d.cfg.Field == emailField {
+		return user.Email
+	}
+	if v, ok := user.Metadata[d.cfg.Field]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+
+	return ""
+}
+
+func wrap(err errors.SDKError) error {
+	if err.StatusCode() == http.StatusUnauthorized {
+		return errors.Wrap(errUnauthorized, err)
+	}
+
+	return err
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package directory_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/magistrala/consumers/notifiers/directory"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	sdkmocks "github.com/absmach/supermq/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	token    = "token"
+	newToken = "newToken"
+)
+
+var (
+	recipients = notifiers.Recipients{DomainID: "domain", GroupID: "group", RoleID: "on-call"}
+	login      = mgsdk.Login{Username: "notifier", Password: "password"}
+	users      = map[string]mgsdk.User{
+		"user1": {ID: "user1", Email: "user1@example.com", Metadata: mgsdk.Metadata{"phone": "+381601234567"}},
+		"user2": {ID: "user2", Email: "user2@example.com"},
+	}
+)
+
+func newSDK(members []string) *sdkmocks.SDK {
+	sdk := new(sdkmocks.SDK)
+	sdk.On("CreateToken", login).Return(mgsdk.Token{AccessToken: token}, nil)
+	sdk.On("GroupRoleMembers", recipients.GroupID, recipients.RoleID, recipients.DomainID, mock.Anything, token).Return(mgsdk.RoleMembersPage{Total: uint64(len(members)), Members: members}, nil)
+	for id, user := range users {
+		sdk.On("User", id, token).Return(user, nil)
+	}
+
+	return sdk
+}
+
+func TestContacts(t *testing.T) {
+	cases := []struct {
+		desc     string
+		field    string
+		members  []string
+		contacts []string
+	}{
+		{
+			desc:     "resolve emails",
+			field:    "email",
+			members:  []string{"user1", "user2"},
+			contacts: []string{"user1@example.com", "user2@example.com"},
+		},
+		{
+			desc:     "resolve metadata field",
+			field:    "phone",
+			members:  []string{"user1", "user2"},
+			contacts: []string{"+381601234567"},
+		},
+		{
+			desc:     "resolve role without members",
+			field:    "email",
+			members:  []string{},
+			contacts: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		sdk := newSDK(tc.members)
+		dir := directory.New(sdk, directory.Config{Username: login.Username, Password: login.Password, Field: tc.field, CacheTTL: time.Minute})
+		contacts, err := dir.Contacts(context.Background(), recipients)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.contacts, contacts, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.contacts, contacts))
+	}
+}
+
+func TestContactsCache(t *testing.T) {
+	sdk := newSDK([]string{"user1"})
+	dir := directory.New(sdk, directory.Config{Username: login.Username, Password: login.Password, Field: "email", CacheTTL: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		contacts, err := dir.Contacts(context.Background(), recipients)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s\n", err))
+		assert.Equal(t, []string{"user1@example.com"}, contacts, fmt.Sprintf("expected %v got %v\n", []string{"user1@example.com"}, contacts))
+	}
+	sdk.AssertNumberOfCalls(t, "CreateToken", 1)
+	sdk.AssertNumberOfCalls(t, "GroupRoleMembers", 1)
+}
+
+func TestContactsExpiredToken(t *testing.T) {
+	sdk := new(sdkmocks.SDK)
+	sdk.On("CreateToken", login).Return(mgsdk.Token{AccessToken: token}, nil).Once()
+	sdk.On("CreateToken", login).Return(mgsdk.Token{AccessToken: newToken}, nil).Once()
+	sdk.On("GroupRoleMembers", recipients.GroupID, recipients.RoleID, recipients.DomainID, mock.Anything, token).Return(mgsdk.RoleMembersPage{}, errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized))
+	sdk.On("GroupRoleMembers", recipients.GroupID, recipients.RoleID, recipients.DomainID, mock.Anything, newToken).Return(mgsdk.RoleMembersPage{Total: 1, Members: []string{"user2"}}, nil)
+	userCall := sdk.On("User", "user2", newToken).Return(users["user2"], nil)
+	dir := directory.New(sdk, directory.Config{Username: login.Username, Password: login.Password, Field: "email"})
+
+	contacts, err := dir.Contacts(context.Background(), recipients)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s\n", err))
+	assert.Equal(t, []string{"user2@example.com"}, contacts, fmt.Sprintf("expected %v got %v\n", []string{"user2@example.com"}, contacts))
+
+	// Without cache, each resolution reads the members again.
+	userCall.Unset()
+	sdk.On("User", "user2", newToken).Return(mgsdk.User{}, errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden))
+	_, err = dir.Contacts(context.Background(), recipients)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthorization), fmt.Sprintf("expected %s got %s\n", svcerr.ErrAuthorization, err))
+}
+
+func TestContactsConcurrent(t *testing.T) {
+	other := notifiers.Recipients{DomainID: "domain", GroupID: "other", RoleID: "on-call"}
+	sdk := newSDK([]string{"user1"})
+	started, release := make(chan struct{}), make(chan struct{})
+	sdk.On("GroupRoleMembers", other.GroupID, other.RoleID, other.DomainID, mock.Anything, token).Run(func(mock.Arguments) {
+		close(started)
+		<-release
+	}).Return(mgsdk.RoleMembersPage{Total: 1, Members: []string{"user2"}}, nil)
+	dir := directory.New(sdk, directory.Config{Username: login.Username, Password: login.Password, Field: "email", CacheTTL: time.Minute})
+
+	_, err := dir.Contacts(context.Background(), recipients)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s\n", err))
+
+	done := make(chan error)
+	go func() {
+		_, err := dir.Contacts(context.Background(), other)
+		done <- err
+	}()
+	<-started
+
+	// The cached contacts are returned while the other resolution is in progress.
+	contacts, err := dir.Contacts(context.Background(), recipients)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s\n", err))
+	assert.Equal(t, []string{"user1@example.com"}, contacts, fmt.Sprintf("expected %v got %v\n", []string{"user1@example.com"}, contacts))
+
+	close(release)
+	err = <-done
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s\n", err))
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package directory contains the SDK based resolution of notifier
+// subscription recipients from Group role membership.
+package directory
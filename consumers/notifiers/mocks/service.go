@@ -7,7 +7,7 @@ package mocks
 import (
 	context "context"
 
-	notifiers "github.com/absmach/supermq/consumers/notifiers"
+	notifiers "github.com/absmach/magistrala/consumers/notifiers"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -62,6 +62,52 @@ func (_m *Service) CreateSubscription(ctx context.Context, token string, sub not
 	return r0, r1
 }
 
+// DeliverScheduled provides a mock function with given fields: ctx
+func (_m *Service) DeliverScheduled(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeliverScheduled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, token, id, pm
+func (_m *Service) ListDeliveries(ctx context.Context, token string, id string, pm notifiers.DeliveriesPageMetadata) (notifiers.DeliveriesPage, error) {
+	ret := _m.Called(ctx, token, id, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 notifiers.DeliveriesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, notifiers.DeliveriesPageMetadata) (notifiers.DeliveriesPage, error)); ok {
+		return rf(ctx, token, id, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, notifiers.DeliveriesPageMetadata) notifiers.DeliveriesPage); ok {
+		r0 = rf(ctx, token, id, pm)
+	} else {
+		r0 = ret.Get(0).(notifiers.DeliveriesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, notifiers.DeliveriesPageMetadata) error); ok {
+		r1 = rf(ctx, token, id, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListSubscriptions provides a mock function with given fields: ctx, token, pm
 func (_m *Service) ListSubscriptions(ctx context.Context, token string, pm notifiers.PageMetadata) (notifiers.Page, error) {
 	ret := _m.Called(ctx, token, pm)
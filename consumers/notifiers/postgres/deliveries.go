@@ -0,0 +1,142 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+)
+
+var _ notifiers.DeliveriesRepository = (*deliveriesRepo)(nil)
+
+type deliveriesRepo struct {
+	db Database
+}
+
+// NewDeliveriesRepository instantiates a PostgreSQL implementation of Deliveries repository.
+func NewDeliveriesRepository(db Database) notifiers.DeliveriesRepository {
+	return &deliveriesRepo{
+		db: db,
+	}
+}
+
+func (repo deliveriesRepo) Save(ctx context.Context, deliveries []notifiers.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	q := `INSERT INTO deliveries (id, subscription_id, contact, channel, subtopic, status, error, created_at)
+		VALUES (:id, :subscription_id, :contact, :channel, :subtopic, :status, :error, :created_at)`
+
+	dbDels := make([]dbDelivery, len(deliveries))
+	for i, d := range deliveries {
+		dbDels[i] = toDBDelivery(d)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbDels); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo deliveriesRepo) RetrieveAll(ctx context.Context, pm notifiers.DeliveriesPageMetadata) (notifiers.DeliveriesPage, error) {
+	args := map[string]interface{}{
+		"offset": pm.Offset,
+		"limit":  pm.Limit,
+	}
+	var cond []string
+	if pm.SubscriptionID != "" {
+		cond = append(cond, "subscription_id = :subscription_id")
+		args["subscription_id"] = pm.SubscriptionID
+	}
+	if pm.Status != "" {
+		cond = append(cond, "status = :status")
+		args["status"] = string(pm.Status)
+	}
+	var condition string
+	if len(cond) > 0 {
+		condition = fmt.Sprintf(" WHERE %s", strings.Join(cond, " AND "))
+	}
+
+	q := fmt.Sprintf(`SELECT id, subscription_id, contact, channel, subtopic, status, error, created_at FROM deliveries%s
+		ORDER BY created_at DESC, id OFFSET :offset LIMIT :limit`, condition)
+	rows, err := repo.db.NamedQueryContext(ctx, q, args)
+	if err != nil {
+		return notifiers.DeliveriesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	deliveries := []notifiers.Delivery{}
+	for rows.Next() {
+		d := dbDelivery{}
+		if err := rows.StructScan(&d); err != nil {
+			return notifiers.DeliveriesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		deliveries = append(deliveries, fromDBDelivery(d))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM deliveries%s`, condition)
+	total, err := total(ctx, repo.db, cq, args)
+	if err != nil {
+		return notifiers.DeliveriesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return notifiers.DeliveriesPage{
+		DeliveriesPageMetadata: pm,
+		Total:                  total,
+		Deliveries:             deliveries,
+	}, nil
+}
+
+func (repo deliveriesRepo) RemoveBefore(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM deliveries WHERE created_at < :before`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, map[string]interface{}{"before": before}); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbDelivery struct {
+	ID             string    `db:"id"`
+	SubscriptionID string    `db:"subscription_id"`
+	Contact        string    `db:"contact"`
+	Channel        string    `db:"channel"`
+	Subtopic       string    `db:"subtopic"`
+	Status         string    `db:"status"`
+	Error          string    `db:"error"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+func toDBDelivery(d notifiers.Delivery) dbDelivery {
+	return dbDelivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		Contact:        d.Contact,
+		Channel:        d.Channel,
+		Subtopic:       d.Subtopic,
+		Status:         string(d.Status),
+		Error:          d.Error,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func fromDBDelivery(d dbDelivery) notifiers.Delivery {
+	return notifiers.Delivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		Contact:        d.Contact,
+		Channel:        d.Channel,
+		Subtopic:       d.Subtopic,
+		Status:         notifiers.DeliveryStatus(d.Status),
+		Error:          d.Error,
+		CreatedAt:      d.CreatedAt,
+	}
+}
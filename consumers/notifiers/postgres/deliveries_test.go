@@ -0,0 +1,96 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/magistrala/consumers/notifiers/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numDeliveries = 10
+
+func TestDeliveries(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db, tracer)
+	repo := postgres.NewDeliveriesRepository(dbMiddleware)
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	var deliveries []notifiers.Delivery
+	for i := 0; i < numDeliveries; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+		d := notifiers.Delivery{
+			ID:             id,
+			SubscriptionID: subID,
+			Contact:        owner,
+			Channel:        "channel",
+			Subtopic:       "subtopic",
+			Status:         notifiers.DeliverySent,
+			CreatedAt:      now.Add(-time.Duration(i) * time.Hour),
+		}
+		if i%2 == 1 {
+			d.Status = notifiers.DeliveryFailed
+			d.Error = "failed to deliver notification"
+		}
+		deliveries = append(deliveries, d)
+	}
+	err = repo.Save(context.Background(), deliveries)
+	require.Nil(t, err, fmt.Sprintf("saving deliveries must not fail: %s", err))
+
+	cases := []struct {
+		desc       string
+		pm         notifiers.DeliveriesPageMetadata
+		total      uint
+		deliveries []notifiers.Delivery
+	}{
+		{
+			desc:       "retrieve all deliveries of subscription",
+			pm:         notifiers.DeliveriesPageMetadata{SubscriptionID: subID, Limit: numDeliveries},
+			total:      numDeliveries,
+			deliveries: deliveries,
+		},
+		{
+			desc:       "retrieve a page of deliveries of subscription",
+			pm:         notifiers.DeliveriesPageMetadata{SubscriptionID: subID, Offset: 2, Limit: 3},
+			total:      numDeliveries,
+			deliveries: deliveries[2:5],
+		},
+		{
+			desc:       "retrieve failed deliveries of subscription",
+			pm:         notifiers.DeliveriesPageMetadata{SubscriptionID: subID, Status: notifiers.DeliveryFailed, Limit: 2},
+			total:      numDeliveries / 2,
+			deliveries: []notifiers.Delivery{deliveries[1], deliveries[3]},
+		},
+		{
+			desc:       "retrieve deliveries of unknown subscription",
+			pm:         notifiers.DeliveriesPageMetadata{SubscriptionID: "unknown", Limit: numDeliveries},
+			total:      0,
+			deliveries: []notifiers.Delivery{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.Equal(t, tc.deliveries, page.Deliveries, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.deliveries, page.Deliveries))
+	}
+
+	// Remove deliveries older than 4.5 hours, keeping the five most recent ones.
+	err = repo.RemoveBefore(context.Background(), now.Add(-4*time.Hour-30*time.Minute))
+	assert.Nil(t, err, fmt.Sprintf("removing deliveries must not fail: %s", err))
+
+	page, err := repo.RetrieveAll(context.Background(), notifiers.DeliveriesPageMetadata{SubscriptionID: subID, Limit: numDeliveries})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s\n", err))
+	assert.Equal(t, deliveries[:5], page.Deliveries, fmt.Sprintf("expected %v got %v\n", deliveries[:5], page.Deliveries))
+}
@@ -23,6 +23,96 @@ func Migration() *migrate.MemoryMigrationSource {
 					"DROP TABLE IF EXISTS subscriptions",
 				},
 			},
+			{
+				Id: "subscriptions_2",
+				Up: []string{
+					`ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS template TEXT NOT NULL DEFAULT ''`,
+				},
+				Down: []string{
+					`ALTER TABLE subscriptions DROP COLUMN IF EXISTS template`,
+				},
+			},
+			{
+				Id: "subscriptions_3",
+				Up: []string{
+					`ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS schedule JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE subscriptions DROP COLUMN IF EXISTS schedule`,
+				},
+			},
+			{
+				Id: "subscriptions_4",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS deliveries (
+                        id              VARCHAR(254) PRIMARY KEY,
+                        subscription_id VARCHAR(254) NOT NULL,
+                        contact         VARCHAR(254),
+                        channel         VARCHAR(254),
+                        subtopic        TEXT,
+                        status          VARCHAR(16) NOT NULL,
+                        error           TEXT NOT NULL DEFAULT '',
+                        created_at      TIMESTAMP NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS deliveries_subscription_created_idx ON deliveries (subscription_id, created_at DESC)`,
+					`CREATE INDEX IF NOT EXISTS deliveries_created_idx ON deliveries (created_at)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS deliveries",
+				},
+			},
+			{
+				Id: "subscriptions_5",
+				Up: []string{
+					`ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS digest JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE subscriptions DROP COLUMN IF EXISTS digest`,
+				},
+			},
+			{
+				Id: "subscriptions_6",
+				Up: []string{
+					`ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS recipients JSONB`,
+					`ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_topic_contact_key`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS subscriptions_topic_contact_recipients_idx ON subscriptions (topic, contact, COALESCE(recipients::TEXT, ''))`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS subscriptions_topic_contact_recipients_idx`,
+					`ALTER TABLE subscriptions ADD CONSTRAINT subscriptions_topic_contact_key UNIQUE (topic, contact)`,
+					`ALTER TABLE subscriptions DROP COLUMN IF EXISTS recipients`,
+				},
+			},
+			{
+				Id: "subscriptions_7",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS scheduled_deliveries (
+                        id              VARCHAR(254) PRIMARY KEY,
+                        subscription_id VARCHAR(254) NOT NULL,
+                        channel         VARCHAR(254),
+                        subtopic        TEXT,
+                        publisher       VARCHAR(254),
+                        protocol        TEXT,
+                        payload         BYTEA,
+                        created         BIGINT,
+                        due_at          TIMESTAMP NOT NULL,
+                        created_at      TIMESTAMP NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS scheduled_deliveries_due_idx ON scheduled_deliveries (due_at)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS scheduled_deliveries",
+				},
+			},
+			{
+				Id: "subscriptions_8",
+				Up: []string{
+					`ALTER TABLE scheduled_deliveries ADD COLUMN IF NOT EXISTS digest BOOLEAN NOT NULL DEFAULT FALSE`,
+				},
+				Down: []string{
+					`ALTER TABLE scheduled_deliveries DROP COLUMN IF EXISTS digest`,
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,112 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+var _ notifiers.ScheduledRepository = (*scheduledRepo)(nil)
+
+type scheduledRepo struct {
+	db Database
+}
+
+// NewScheduledRepository instantiates a PostgreSQL implementation of
+// Scheduled deliveries repository.
+func NewScheduledRepository(db Database) notifiers.ScheduledRepository {
+	return &scheduledRepo{
+		db: db,
+	}
+}
+
+func (repo scheduledRepo) Save(ctx context.Context, sd notifiers.ScheduledDelivery) error {
+	q := `INSERT INTO scheduled_deliveries (id, subscription_id, channel, subtopic, publisher, protocol, payload, created, digest, due_at, created_at)
+		VALUES (:id, :subscription_id, :channel, :subtopic, :publisher, :protocol, :payload, :created, :digest, :due_at, :created_at)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBScheduled(sd)); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo scheduledRepo) Pop(ctx context.Context, due time.Time, limit uint64) ([]notifiers.ScheduledDelivery, error) {
+	// Locked rows are skipped, so that concurrent services pop different deliveries.
+	q := `DELETE FROM scheduled_deliveries WHERE id IN (
+			SELECT id FROM scheduled_deliveries WHERE due_at <= :due
+			ORDER BY due_at, created_at LIMIT :limit FOR UPDATE SKIP LOCKED)
+		RETURNING id, subscription_id, channel, subtopic, publisher, protocol, payload, created, digest, due_at, created_at`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, map[string]interface{}{"due": due, "limit": limit})
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	defer rows.Close()
+
+	scheduled := []notifiers.ScheduledDelivery{}
+	for rows.Next() {
+		sd := dbScheduled{}
+		if err := rows.StructScan(&sd); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		scheduled = append(scheduled, fromDBScheduled(sd))
+	}
+
+	return scheduled, nil
+}
+
+type dbScheduled struct {
+	ID             string    `db:"id"`
+	SubscriptionID string    `db:"subscription_id"`
+	Channel        string    `db:"channel"`
+	Subtopic       string    `db:"subtopic"`
+	Publisher      string    `db:"publisher"`
+	Protocol       string    `db:"protocol"`
+	Payload        []byte    `db:"payload"`
+	Created        int64     `db:"created"`
+	Digest         bool      `db:"digest"`
+	DueAt          time.Time `db:"due_at"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+func toDBScheduled(sd notifiers.ScheduledDelivery) dbScheduled {
+	return dbScheduled{
+		ID:             sd.ID,
+		SubscriptionID: sd.SubscriptionID,
+		Channel:        sd.Message.GetChannel(),
+		Subtopic:       sd.Message.GetSubtopic(),
+		Publisher:      sd.Message.GetPublisher(),
+		Protocol:       sd.Message.GetProtocol(),
+		Payload:        sd.Message.GetPayload(),
+		Created:        sd.Message.GetCreated(),
+		Digest:         sd.Digest,
+		DueAt:          sd.DueAt,
+		CreatedAt:      sd.CreatedAt,
+	}
+}
+
+func fromDBScheduled(sd dbScheduled) notifiers.ScheduledDelivery {
+	return notifiers.ScheduledDelivery{
+		ID:             sd.ID,
+		SubscriptionID: sd.SubscriptionID,
+		Message: &messaging.Message{
+			Channel:   sd.Channel,
+			Subtopic:  sd.Subtopic,
+			Publisher: sd.Publisher,
+			Protocol:  sd.Protocol,
+			Payload:   sd.Payload,
+			Created:   sd.Created,
+		},
+		Digest:    sd.Digest,
+		DueAt:     sd.DueAt,
+		CreatedAt: sd.CreatedAt,
+	}
+}
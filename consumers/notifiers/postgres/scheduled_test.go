@@ -0,0 +1,86 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/magistrala/consumers/notifiers/postgres"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduled(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db, tracer)
+	repo := postgres.NewScheduledRepository(dbMiddleware)
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	var scheduled []notifiers.ScheduledDelivery
+	for i := 0; i < 3; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+		sd := notifiers.ScheduledDelivery{
+			ID:             id,
+			SubscriptionID: subID,
+			Message: &messaging.Message{
+				Channel:  "channel",
+				Subtopic: "subtopic",
+				Protocol: "http",
+				Payload:  []byte(fmt.Sprintf(`{"n":%d}`, i)),
+				Created:  now.UnixNano(),
+			},
+			DueAt:     now.Add(time.Duration(i-1) * time.Hour),
+			CreatedAt: now,
+		}
+		err = repo.Save(context.Background(), sd)
+		require.Nil(t, err, fmt.Sprintf("saving scheduled delivery must not fail: %s", err))
+		scheduled = append(scheduled, sd)
+	}
+
+	cases := []struct {
+		desc      string
+		due       time.Time
+		limit     uint64
+		scheduled []notifiers.ScheduledDelivery
+	}{
+		{
+			desc:      "pop due scheduled delivery",
+			due:       now.Add(-time.Minute),
+			limit:     10,
+			scheduled: scheduled[:1],
+		},
+		{
+			desc:      "pop already popped scheduled delivery",
+			due:       now.Add(-time.Minute),
+			limit:     10,
+			scheduled: []notifiers.ScheduledDelivery{},
+		},
+		{
+			desc:      "pop limited scheduled deliveries",
+			due:       now.Add(2 * time.Hour),
+			limit:     1,
+			scheduled: scheduled[1:2],
+		},
+		{
+			desc:      "pop remaining scheduled deliveries",
+			due:       now.Add(2 * time.Hour),
+			limit:     10,
+			scheduled: scheduled[2:],
+		},
+	}
+
+	for _, tc := range cases {
+		popped, err := repo.Pop(context.Background(), tc.due, tc.limit)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.scheduled, popped, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.scheduled, popped))
+	}
+}
@@ -6,10 +6,11 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/absmach/supermq/consumers/notifiers"
+	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	"github.com/jackc/pgerrcode"
@@ -30,13 +31,11 @@ func New(db Database) notifiers.SubscriptionsRepository {
 }
 
 func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscription) (string, error) {
-	q := `INSERT INTO subscriptions (id, owner_id, contact, topic) VALUES (:id, :owner_id, :contact, :topic) RETURNING id`
+	q := `INSERT INTO subscriptions (id, owner_id, contact, topic, template, schedule, digest, recipients) VALUES (:id, :owner_id, :contact, :topic, :template, :schedule, :digest, :recipients) RETURNING id`
 
-	dbSub := dbSubscription{
-		ID:      sub.ID,
-		OwnerID: sub.OwnerID,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
+	dbSub, err := toDBSub(sub)
+	if err != nil {
+		return "", errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
 
 	row, err := repo.db.NamedQueryContext(ctx, q, dbSub)
@@ -52,7 +51,7 @@ func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscripti
 }
 
 func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifiers.Subscription, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions WHERE id = $1`
+	q := `SELECT id, owner_id, contact, topic, template, schedule, digest, recipients FROM subscriptions WHERE id = $1`
 	sub := dbSubscription{}
 	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&sub); err != nil {
 		if err == sql.ErrNoRows {
@@ -61,11 +60,16 @@ func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifier
 		return notifiers.Subscription{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
 
-	return fromDBSub(sub), nil
+	ret, err := fromDBSub(sub)
+	if err != nil {
+		return notifiers.Subscription{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return ret, nil
 }
 
 func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.PageMetadata) (notifiers.Page, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions`
+	q := `SELECT id, owner_id, contact, topic, template, schedule, digest, recipients FROM subscriptions`
 	args := make(map[string]interface{})
 	if pm.Topic != "" {
 		args["topic"] = pm.Topic
@@ -101,7 +105,11 @@ func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.Page
 		if err := rows.StructScan(&sub); err != nil {
 			return notifiers.Page{}, errors.Wrap(repoerr.ErrViewEntity, err)
 		}
-		subs = append(subs, fromDBSub(sub))
+		s, err := fromDBSub(sub)
+		if err != nil {
+			return notifiers.Page{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		subs = append(subs, s)
 	}
 
 	if len(subs) == 0 {
@@ -148,17 +156,82 @@ func total(ctx context.Context, db Database, query string, params interface{}) (
 }
 
 type dbSubscription struct {
-	ID      string `db:"id"`
-	OwnerID string `db:"owner_id"`
-	Contact string `db:"contact"`
-	Topic   string `db:"topic"`
+	ID         string `db:"id"`
+	OwnerID    string `db:"owner_id"`
+	Contact    string `db:"contact"`
+	Topic      string `db:"topic"`
+	Template   string `db:"template"`
+	Schedule   []byte `db:"schedule"`
+	Digest     []byte `db:"digest"`
+	Recipients []byte `db:"recipients"`
 }
 
-func fromDBSub(sub dbSubscription) notifiers.Subscription {
-	return notifiers.Subscription{
-		ID:      sub.ID,
-		OwnerID: sub.OwnerID,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
+func toDBSub(sub notifiers.Subscription) (dbSubscription, error) {
+	var schedule []byte
+	if sub.Schedule != nil {
+		var err error
+		if schedule, err = json.Marshal(sub.Schedule); err != nil {
+			return dbSubscription{}, err
+		}
+	}
+	var digest []byte
+	if sub.Digest != nil {
+		var err error
+		if digest, err = json.Marshal(sub.Digest); err != nil {
+			return dbSubscription{}, err
+		}
 	}
+	var recipients []byte
+	if sub.Recipients != nil {
+		var err error
+		if recipients, err = json.Marshal(sub.Recipients); err != nil {
+			return dbSubscription{}, err
+		}
+	}
+
+	return dbSubscription{
+		ID:         sub.ID,
+		OwnerID:    sub.OwnerID,
+		Contact:    sub.Contact,
+		Topic:      sub.Topic,
+		Template:   sub.Template,
+		Schedule:   schedule,
+		Digest:     digest,
+		Recipients: recipients,
+	}, nil
+}
+
+func fromDBSub(sub dbSubscription) (notifiers.Subscription, error) {
+	var schedule *notifiers.Schedule
+	if len(sub.Schedule) > 0 {
+		schedule = &notifiers.Schedule{}
+		if err := json.Unmarshal(sub.Schedule, schedule); err != nil {
+			return notifiers.Subscription{}, err
+		}
+	}
+	var digest *notifiers.Digest
+	if len(sub.Digest) > 0 {
+		digest = &notifiers.Digest{}
+		if err := json.Unmarshal(sub.Digest, digest); err != nil {
+			return notifiers.Subscription{}, err
+		}
+	}
+	var recipients *notifiers.Recipients
+	if len(sub.Recipients) > 0 {
+		recipients = &notifiers.Recipients{}
+		if err := json.Unmarshal(sub.Recipients, recipients); err != nil {
+			return notifiers.Subscription{}, err
+		}
+	}
+
+	return notifiers.Subscription{
+		ID:         sub.ID,
+		OwnerID:    sub.OwnerID,
+		Contact:    sub.Contact,
+		Topic:      sub.Topic,
+		Template:   sub.Template,
+		Schedule:   schedule,
+		Digest:     digest,
+		Recipients: recipients,
+	}, nil
 }
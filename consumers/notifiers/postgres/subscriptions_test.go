@@ -7,9 +7,10 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/magistrala/consumers/notifiers/postgres"
-	"github.com/absmach/supermq/consumers/notifiers"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	"github.com/stretchr/testify/assert"
@@ -79,10 +80,23 @@ func TestView(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
 
 	sub := notifiers.Subscription{
-		OwnerID: id,
-		ID:      id,
-		Contact: owner,
-		Topic:   "view.subtopic",
+		OwnerID:  id,
+		ID:       id,
+		Contact:  owner,
+		Topic:    "view.subtopic",
+		Template: "{{.Channel}}: {{.Payload}}",
+		Schedule: &notifiers.Schedule{
+			Timezone: "Europe/Belgrade",
+			Days:     []time.Weekday{time.Monday, time.Friday},
+			Start:    "09:00",
+			End:      "17:00",
+			Severity: 5,
+			Defer:    true,
+		},
+		Digest: &notifiers.Digest{
+			Interval: "5m",
+			Severity: 8,
+		},
 	}
 
 	ret, err := repo.Save(context.Background(), sub)
@@ -0,0 +1,42 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// ErrRecipients indicates that subscription recipients are invalid or can't
+// be resolved.
+var ErrRecipients = errors.New("invalid subscription recipients")
+
+// Recipients represent the members of the Group role which are notified
+// instead of the static subscription contact. Members are resolved when the
+// notification is sent, so membership changes affect the notified contacts
+// without updating the subscription. DomainID is set to the domain of the
+// subscription owner.
+type Recipients struct {
+	DomainID string `json:"domain_id,omitempty"`
+	GroupID  string `json:"group_id"`
+	RoleID   string `json:"role_id"`
+}
+
+// Validate checks that the recipients are well-formed.
+func (r Recipients) Validate() error {
+	if r.GroupID == "" || r.RoleID == "" {
+		return ErrRecipients
+	}
+
+	return nil
+}
+
+// Directory resolves the contacts of the Group role members.
+//
+//go:generate mockery --name Directory --output=./mocks --filename directory.go --quiet --note "Copyright (c) Abstract Machines"
+type Directory interface {
+	// Contacts returns the contacts of the current members of the Group role.
+	Contacts(ctx context.Context, recipients Recipients) ([]string, error)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+const (
+	clockLayout = "15:04"
+	severityKey = "severity"
+)
+
+// ErrSchedule indicates that subscription schedule is invalid.
+var ErrSchedule = errors.New("invalid subscription schedule")
+
+// Schedule restricts subscription notifications to the allowed hours of the
+// allowed days in the subscription timezone. Notifications outside of the
+// allowed hours are suppressed or, if Defer is set, deferred until the start
+// of the next allowed window. Messages of severity at or above Severity are
+// always notified.
+type Schedule struct {
+	// Timezone is the IANA timezone name. Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+	// Days are the allowed days of week, where 0 is Sunday. Defaults to
+	// every day. Windows spanning midnight belong to the day they start on.
+	Days []time.Weekday `json:"days,omitempty"`
+	// Start and End are the allowed hours in the "15:04" format. End before
+	// Start spans midnight, while the same Start and End allow the whole day.
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Severity int    `json:"severity,omitempty"`
+	Defer    bool   `json:"defer,omitempty"`
+}
+
+// Validate checks that the schedule is well-formed.
+func (s Schedule) Validate() error {
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return errors.Wrap(ErrSchedule, err)
+	}
+	for _, d := range s.Days {
+		if d < time.Sunday || d > time.Saturday {
+			return ErrSchedule
+		}
+	}
+	if _, err := time.Parse(clockLayout, s.Start); err != nil {
+		return errors.Wrap(ErrSchedule, err)
+	}
+	if _, err := time.Parse(clockLayout, s.End); err != nil {
+		return errors.Wrap(ErrSchedule, err)
+	}
+
+	return nil
+}
+
+// Allows reports whether the notification of the given severity is allowed at
+// the given time.
+func (s Schedule) Allows(t time.Time, severity int) bool {
+	if s.Severity > 0 && severity >= s.Severity {
+		return true
+	}
+	loc, start, end, err := s.parse()
+	if err != nil {
+		// Invalid schedule doesn't restrict notifications.
+		return true
+	}
+	t = t.In(loc)
+	now := minutes(t)
+
+	switch {
+	case start == end:
+		return s.allowsDay(t.Weekday())
+	case start < end:
+		return s.allowsDay(t.Weekday()) && now >= start && now < end
+	default:
+		return (s.allowsDay(t.Weekday()) && now >= start) ||
+			(s.allowsDay(t.AddDate(0, 0, -1).Weekday()) && now < end)
+	}
+}
+
+// Next returns the start of the first allowed window after the given time, or
+// the given time if it's within the allowed window.
+func (s Schedule) Next(t time.Time) time.Time {
+	if s.Allows(t, 0) {
+		return t
+	}
+	loc, start, _, err := s.parse()
+	if err != nil {
+		return t
+	}
+	local := t.In(loc)
+	for i := 0; i <= 7; i++ {
+		day := local.AddDate(0, 0, i)
+		next := time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, loc)
+		if next.After(t) && s.allowsDay(next.Weekday()) {
+			return next
+		}
+	}
+
+	return t
+}
+
+func (s Schedule) allowsDay(d time.Weekday) bool {
+	return len(s.Days) == 0 || slices.Contains(s.Days, d)
+}
+
+func (s Schedule) parse() (*time.Location, int, int, error) {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	start, err := time.Parse(clockLayout, s.Start)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	end, err := time.Parse(clockLayout, s.End)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return loc, minutes(start), minutes(end), nil
+}
+
+func minutes(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+// Severity returns the message severity, read from the numeric "severity"
+// field of JSON object payload or the "severity" record of SenML pack. The
+// severity of other messages is 0.
+func Severity(msg *messaging.Message) int {
+	var pld interface{}
+	if err := json.Unmarshal(msg.GetPayload(), &pld); err != nil {
+		return 0
+	}
+
+	switch p := pld.(type) {
+	case map[string]interface{}:
+		if v, ok := p[severityKey].(float64); ok {
+			return int(v)
+		}
+	case []interface{}:
+		for _, r := range p {
+			rec, ok := r.(map[string]interface{})
+			if !ok || rec["n"] != severityKey {
+				continue
+			}
+			if v, ok := rec["v"].(float64); ok {
+				return int(v)
+			}
+		}
+	}
+
+	return 0
+}
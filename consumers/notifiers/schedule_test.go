@@ -0,0 +1,227 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleValidate(t *testing.T) {
+	cases := []struct {
+		desc     string
+		schedule notifiers.Schedule
+		err      error
+	}{
+		{
+			desc:     "valid schedule",
+			schedule: notifiers.Schedule{Timezone: "Europe/Belgrade", Days: []time.Weekday{time.Monday, time.Friday}, Start: "09:00", End: "17:30"},
+			err:      nil,
+		},
+		{
+			desc:     "valid schedule without timezone",
+			schedule: notifiers.Schedule{Start: "22:00", End: "06:00"},
+			err:      nil,
+		},
+		{
+			desc:     "invalid timezone",
+			schedule: notifiers.Schedule{Timezone: "Mars/Olympus", Start: "09:00", End: "17:00"},
+			err:      notifiers.ErrSchedule,
+		},
+		{
+			desc:     "invalid day",
+			schedule: notifiers.Schedule{Days: []time.Weekday{7}, Start: "09:00", End: "17:00"},
+			err:      notifiers.ErrSchedule,
+		},
+		{
+			desc:     "invalid start",
+			schedule: notifiers.Schedule{Start: "9am", End: "17:00"},
+			err:      notifiers.ErrSchedule,
+		},
+		{
+			desc:     "invalid end",
+			schedule: notifiers.Schedule{Start: "09:00", End: "25:00"},
+			err:      notifiers.ErrSchedule,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.schedule.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestScheduleAllows(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error loading location: %s", err))
+
+	workHours := notifiers.Schedule{
+		Timezone: "America/New_York",
+		Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start:    "09:00",
+		End:      "17:00",
+		Severity: 5,
+	}
+	nights := notifiers.Schedule{
+		Days:  []time.Weekday{time.Friday},
+		Start: "22:00",
+		End:   "06:00",
+	}
+
+	cases := []struct {
+		desc     string
+		schedule notifiers.Schedule
+		time     time.Time
+		severity int
+		allowed  bool
+	}{
+		{
+			desc:     "within work hours",
+			schedule: workHours,
+			time:     time.Date(2025, 1, 6, 10, 0, 0, 0, loc),
+			allowed:  true,
+		},
+		{
+			desc:     "within work hours in UTC",
+			schedule: workHours,
+			time:     time.Date(2025, 1, 6, 15, 0, 0, 0, time.UTC),
+			allowed:  true,
+		},
+		{
+			desc:     "outside of work hours in subscription timezone",
+			schedule: workHours,
+			time:     time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC),
+			allowed:  false,
+		},
+		{
+			desc:     "at the end of work hours",
+			schedule: workHours,
+			time:     time.Date(2025, 1, 6, 17, 0, 0, 0, loc),
+			allowed:  false,
+		},
+		{
+			desc:     "on weekend",
+			schedule: workHours,
+			time:     time.Date(2025, 1, 4, 10, 0, 0, 0, loc),
+			allowed:  false,
+		},
+		{
+			desc:     "outside of work hours with breaking severity",
+			schedule: workHours,
+			time:     time.Date(2025, 1, 6, 3, 0, 0, 0, loc),
+			severity: 5,
+			allowed:  true,
+		},
+		{
+			desc:     "outside of work hours with lower severity",
+			schedule: workHours,
+			time:     time.Date(2025, 1, 6, 3, 0, 0, 0, loc),
+			severity: 4,
+			allowed:  false,
+		},
+		{
+			desc:     "before midnight of window spanning midnight",
+			schedule: nights,
+			time:     time.Date(2025, 1, 3, 23, 0, 0, 0, time.UTC),
+			allowed:  true,
+		},
+		{
+			desc:     "after midnight of window spanning midnight",
+			schedule: nights,
+			time:     time.Date(2025, 1, 4, 5, 0, 0, 0, time.UTC),
+			allowed:  true,
+		},
+		{
+			desc:     "after midnight of window starting on not allowed day",
+			schedule: nights,
+			time:     time.Date(2025, 1, 3, 5, 0, 0, 0, time.UTC),
+			allowed:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		allowed := tc.schedule.Allows(tc.time, tc.severity)
+		assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.allowed, allowed))
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	schedule := notifiers.Schedule{
+		Days:  []time.Weekday{time.Monday, time.Wednesday},
+		Start: "09:00",
+		End:   "17:00",
+	}
+
+	cases := []struct {
+		desc string
+		time time.Time
+		next time.Time
+	}{
+		{
+			desc: "within allowed window",
+			time: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC),
+			next: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "before allowed window of the same day",
+			time: time.Date(2025, 1, 6, 3, 0, 0, 0, time.UTC),
+			next: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "after allowed window",
+			time: time.Date(2025, 1, 6, 18, 0, 0, 0, time.UTC),
+			next: time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "after the last allowed window of the week",
+			time: time.Date(2025, 1, 8, 18, 0, 0, 0, time.UTC),
+			next: time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range cases {
+		next := schedule.Next(tc.time)
+		assert.Equal(t, tc.next, next, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.next, next))
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	cases := []struct {
+		desc     string
+		payload  string
+		severity int
+	}{
+		{
+			desc:     "severity of JSON object",
+			payload:  `{"severity": 3, "temperature": 80}`,
+			severity: 3,
+		},
+		{
+			desc:     "severity of SenML pack",
+			payload:  `[{"n": "temperature", "v": 80}, {"n": "severity", "v": 4}]`,
+			severity: 4,
+		},
+		{
+			desc:     "message without severity",
+			payload:  `{"temperature": 80}`,
+			severity: 0,
+		},
+		{
+			desc:     "malformed message",
+			payload:  `severity=5`,
+			severity: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		severity := notifiers.Severity(&messaging.Message{Payload: []byte(tc.payload)})
+		assert.Equal(t, tc.severity, severity, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.severity, severity))
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+// scheduledBatch is the maximal number of scheduled deliveries sent at once.
+const scheduledBatch = 100
+
+// ScheduledDelivery represents the notification of the message to the
+// Subscription which is postponed until the due time. Messages accumulated
+// by the Subscription digest are marked as Digest, and they're sent as a
+// single summary.
+type ScheduledDelivery struct {
+	ID             string
+	SubscriptionID string
+	Message        *messaging.Message
+	Digest         bool
+	DueAt          time.Time
+	CreatedAt      time.Time
+}
+
+// ScheduledRepository specifies a ScheduledDelivery persistence API.
+//
+//go:generate mockery --name ScheduledRepository --output=./mocks --filename scheduled.go --quiet --note "Copyright (c) Abstract Machines"
+type ScheduledRepository interface {
+	// Save persists the scheduled delivery.
+	Save(ctx context.Context, sd ScheduledDelivery) error
+
+	// Pop removes and returns up to limit scheduled deliveries due at the
+	// given time, ordered by the due and creation time. Each delivery is
+	// returned only once, even if multiple services pop concurrently.
+	Pop(ctx context.Context, due time.Time, limit uint64) ([]ScheduledDelivery, error)
+}
+
+// RunScheduler sends the scheduled deliveries which are due on every tick of
+// the interval until the context is canceled.
+func RunScheduler(ctx context.Context, svc Service, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := svc.DeliverScheduled(ctx); err != nil {
+				logger.Warn("Failed to deliver scheduled notifications", slog.Any("error", err))
+			}
+		}
+	}
+}
@@ -6,19 +6,25 @@ package notifiers
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/consumers"
-	notif "github.com/absmach/supermq/consumers/notifiers"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
 )
 
 // ErrMessage indicates an error converting a message to SuperMQ message.
 var ErrMessage = errors.New("failed to convert to SuperMQ message")
 
+// pruneInterval is the minimal interval between removals of expired deliveries.
+const pruneInterval = time.Minute
+
 var _ consumers.AsyncConsumer = (*notifierService)(nil)
 
 // Service reprents a notification service.
@@ -38,29 +44,56 @@ type Service interface {
 	// RemoveSubscription removes the subscription having the provided identifier.
 	RemoveSubscription(ctx context.Context, token, id string) error
 
+	// ListDeliveries lists the delivery attempts of the subscription with the
+	// given id, starting from the most recent one.
+	ListDeliveries(ctx context.Context, token, id string, pm DeliveriesPageMetadata) (DeliveriesPage, error)
+
+	// DeliverScheduled sends the scheduled deliveries which are due, that is
+	// the notifications deferred by subscription schedules and the summaries
+	// of subscription digests.
+	DeliverScheduled(ctx context.Context) error
+
 	consumers.BlockingConsumer
 }
 
 var _ Service = (*notifierService)(nil)
 
 type notifierService struct {
-	authn    smqauthn.Authentication
-	subs     SubscriptionsRepository
-	idp      supermq.IDProvider
-	notifier notif.Notifier
-	errCh    chan error
-	from     string
+	authn      smqauthn.Authentication
+	subs       SubscriptionsRepository
+	deliveries DeliveriesRepository
+	scheduled  ScheduledRepository
+	idp        supermq.IDProvider
+	notifier   Notifier
+	directory  Directory
+	counter    metrics.Counter
+	errCh      chan error
+	from       string
+	retention  time.Duration
+	mu         sync.Mutex
+	pruned     time.Time
 }
 
-// New instantiates the subscriptions service implementation.
-func New(authn smqauthn.Authentication, subs SubscriptionsRepository, idp supermq.IDProvider, notifier notif.Notifier, from string) Service {
+// New instantiates the subscriptions service implementation. Deliveries older
+// than the retention are removed, while non-positive retention keeps them
+// forever. The counter counts notifications suppressed or deferred by
+// subscription schedules and notifications accumulated by subscription digests.
+// The directory resolves subscription recipients; if it's nil, subscriptions
+// with recipients can't be created. Deferred and digested notifications are
+// stored in the scheduled repository until they're sent by DeliverScheduled.
+func New(authn smqauthn.Authentication, subs SubscriptionsRepository, deliveries DeliveriesRepository, scheduled ScheduledRepository, idp supermq.IDProvider, notifier Notifier, directory Directory, from string, retention time.Duration, counter metrics.Counter) Service {
 	return &notifierService{
-		authn:    authn,
-		subs:     subs,
-		idp:      idp,
-		notifier: notifier,
-		errCh:    make(chan error, 1),
-		from:     from,
+		authn:      authn,
+		subs:       subs,
+		deliveries: deliveries,
+		scheduled:  scheduled,
+		idp:        idp,
+		notifier:   notifier,
+		directory:  directory,
+		counter:    counter,
+		errCh:      make(chan error, 1),
+		from:       from,
+		retention:  retention,
 	}
 }
 
@@ -69,6 +102,14 @@ func (ns *notifierService) CreateSubscription(ctx context.Context, token string,
 	if err != nil {
 		return "", err
 	}
+	if sub.Recipients != nil {
+		if ns.directory == nil {
+			return "", errors.Wrap(svcerr.ErrCreateEntity, ErrRecipients)
+		}
+		recipients := *sub.Recipients
+		recipients.DomainID = session.DomainID
+		sub.Recipients = &recipients
+	}
 	sub.ID, err = ns.idp.ID()
 	if err != nil {
 		return "", err
@@ -106,6 +147,68 @@ func (ns *notifierService) RemoveSubscription(ctx context.Context, token, id str
 	return ns.subs.Remove(ctx, id)
 }
 
+func (ns *notifierService) ListDeliveries(ctx context.Context, token, id string, pm DeliveriesPageMetadata) (DeliveriesPage, error) {
+	if _, err := ns.authn.Authenticate(ctx, token); err != nil {
+		return DeliveriesPage{}, err
+	}
+	if _, err := ns.subs.Retrieve(ctx, id); err != nil {
+		return DeliveriesPage{}, err
+	}
+	pm.SubscriptionID = id
+
+	return ns.deliveries.RetrieveAll(ctx, pm)
+}
+
+func (ns *notifierService) DeliverScheduled(ctx context.Context) error {
+	var ret error
+	// Digests are summarized once all the due messages are popped.
+	digests := make(map[string]*digest)
+	subs := make(map[string]Subscription)
+	for {
+		due, err := ns.scheduled.Pop(ctx, time.Now().UTC(), scheduledBatch)
+		if err != nil {
+			ret = errors.Wrap(err, ret)
+			break
+		}
+		for _, sd := range due {
+			sub, ok := subs[sd.SubscriptionID]
+			if !ok {
+				if sub, err = ns.subs.Retrieve(ctx, sd.SubscriptionID); err != nil {
+					// Removed subscriptions are no longer notified.
+					if !errors.Contains(err, repoerr.ErrNotFound) {
+						ret = errors.Wrap(err, ret)
+					}
+					continue
+				}
+				subs[sub.ID] = sub
+			}
+			if !sd.Digest {
+				if err := ns.send(ctx, []Subscription{sub}, sd.Message); err != nil {
+					ret = errors.Wrap(errors.Wrap(ErrNotify, err), ret)
+				}
+				continue
+			}
+			d, ok := digests[sub.ID]
+			if !ok {
+				d = newDigest(sd.Message, sd.CreatedAt)
+				digests[sub.ID] = d
+			}
+			d.add(sd.Message, sd.CreatedAt)
+		}
+		if len(due) < scheduledBatch {
+			break
+		}
+	}
+
+	for id, d := range digests {
+		if err := ns.flush(ctx, subs[id], d); err != nil {
+			ret = errors.Wrap(errors.Wrap(ErrNotify, err), ret)
+		}
+	}
+
+	return ret
+}
+
 func (ns *notifierService) ConsumeBlocking(ctx context.Context, message interface{}) error {
 	msg, ok := message.(*messaging.Message)
 	if !ok {
@@ -125,15 +228,8 @@ func (ns *notifierService) ConsumeBlocking(ctx context.Context, message interfac
 		return err
 	}
 
-	var to []string
-	for _, sub := range page.Subscriptions {
-		to = append(to, sub.Contact)
-	}
-	if len(to) > 0 {
-		err := ns.notifier.Notify(ns.from, to, msg)
-		if err != nil {
-			return errors.Wrap(notif.ErrNotify, err)
-		}
+	if err := ns.notify(ctx, page.Subscriptions, msg); err != nil {
+		return errors.Wrap(ErrNotify, err)
 	}
 
 	return nil
@@ -160,17 +256,234 @@ func (ns *notifierService) ConsumeAsync(ctx context.Context, message interface{}
 		return
 	}
 
+	if err := ns.notify(ctx, page.Subscriptions, msg); err != nil {
+		ns.errCh <- errors.Wrap(ErrNotify, err)
+	}
+}
+
+func (ns *notifierService) Errors() <-chan error {
+	return ns.errCh
+}
+
+func (ns *notifierService) notify(ctx context.Context, subs []Subscription, msg *messaging.Message) error {
+	return ns.send(ctx, ns.digest(ctx, ns.schedule(ctx, subs, msg), msg), msg)
+}
+
+// schedule returns the subscriptions which are notified right away.
+// Notifications of the other subscriptions are suppressed or stored to be
+// delivered in the next window allowed by the subscription schedule.
+func (ns *notifierService) schedule(ctx context.Context, subs []Subscription, msg *messaging.Message) []Subscription {
+	now := time.Now()
+	severity := Severity(msg)
+	var allowed []Subscription
+	for _, sub := range subs {
+		if sub.Schedule == nil || sub.Schedule.Allows(now, severity) {
+			allowed = append(allowed, sub)
+			continue
+		}
+		if !sub.Schedule.Defer {
+			ns.counter.With("status", "suppressed").Add(1)
+			continue
+		}
+		ns.counter.With("status", "deferred").Add(1)
+		sd := ScheduledDelivery{
+			SubscriptionID: sub.ID,
+			Message:        msg,
+			DueAt:          sub.Schedule.Next(now),
+		}
+		if err := ns.postpone(ctx, sd); err != nil {
+			ns.report(errors.Wrap(ErrNotify, err))
+		}
+	}
+
+	return allowed
+}
+
+// postpone stores the scheduled delivery, so that it's sent by
+// DeliverScheduled once it's due.
+func (ns *notifierService) postpone(ctx context.Context, sd ScheduledDelivery) error {
+	id, err := ns.idp.ID()
+	if err != nil {
+		return err
+	}
+	sd.ID = id
+	sd.DueAt = sd.DueAt.UTC()
+	sd.CreatedAt = time.Now().UTC()
+
+	return ns.scheduled.Save(ctx, sd)
+}
+
+// digest returns the subscriptions which are notified of the message right
+// away, that is those without a digest and those whose digest is bypassed by
+// the message severity. The message is accumulated into the digests of the
+// other subscriptions, whose summary is sent at the end of the digest
+// interval.
+func (ns *notifierService) digest(ctx context.Context, subs []Subscription, msg *messaging.Message) []Subscription {
+	severity := Severity(msg)
+	var allowed []Subscription
+	for _, sub := range subs {
+		if sub.Digest == nil || sub.Digest.interval() == 0 || sub.Digest.Bypasses(severity) {
+			allowed = append(allowed, sub)
+			continue
+		}
+		ns.counter.With("status", "digested").Add(1)
+		if err := ns.accumulate(ctx, sub, msg); err != nil {
+			ns.report(errors.Wrap(ErrNotify, err))
+		}
+	}
+
+	return allowed
+}
+
+// accumulate stores the message as a part of the subscription digest, which
+// is due at the end of the current digest interval.
+func (ns *notifierService) accumulate(ctx context.Context, sub Subscription, msg *messaging.Message) error {
+	interval := sub.Digest.interval()
+	due := time.Now().Truncate(interval).Add(interval)
+
+	return ns.postpone(ctx, ScheduledDelivery{
+		SubscriptionID: sub.ID,
+		Message:        msg,
+		Digest:         true,
+		DueAt:          due,
+	})
+}
+
+// flush sends the summary of the notifications accumulated by the digest.
+func (ns *notifierService) flush(ctx context.Context, sub Subscription, d *digest) error {
+	msg, err := d.message()
+	if err != nil {
+		return err
+	}
+
+	return ns.send(ctx, []Subscription{sub}, msg)
+}
+
+// send notifies the subscriptions of the message and records the outcome of
+// each delivery.
+func (ns *notifierService) send(ctx context.Context, subs []Subscription, msg *messaging.Message) error {
+	subs, ret := ns.resolve(ctx, subs, msg)
+	if len(subs) == 0 {
+		return ret
+	}
+	if sn, ok := ns.notifier.(SubscriptionNotifier); ok {
+		// Notify subscriptions one by one to record the outcome of each delivery.
+		for _, sub := range subs {
+			err := sn.NotifySubscriptions(ctx, ns.from, []Subscription{sub}, msg)
+			ns.record(ctx, []Subscription{sub}, msg, err)
+			if err != nil {
+				ret = errors.Wrap(err, ret)
+			}
+		}
+		return ret
+	}
+
 	var to []string
-	for _, sub := range page.Subscriptions {
+	for _, sub := range subs {
 		to = append(to, sub.Contact)
 	}
-	if len(to) > 0 {
-		if err := ns.notifier.Notify(ns.from, to, msg); err != nil {
-			ns.errCh <- errors.Wrap(notif.ErrNotify, err)
+
+	err := ns.notifier.Notify(ns.from, to, msg)
+	ns.record(ctx, subs, msg, err)
+	if err != nil {
+		ret = errors.Wrap(err, ret)
+	}
+
+	return ret
+}
+
+// resolve replaces the subscriptions which define recipients with a
+// subscription per contact of the current recipients. Failed resolutions are
+// recorded as failed deliveries.
+func (ns *notifierService) resolve(ctx context.Context, subs []Subscription, msg *messaging.Message) ([]Subscription, error) {
+	var ret error
+	resolved := make([]Subscription, 0, len(subs))
+	for _, sub := range subs {
+		if sub.Recipients == nil {
+			resolved = append(resolved, sub)
+			continue
+		}
+		if ns.directory == nil {
+			ns.record(ctx, []Subscription{sub}, msg, ErrRecipients)
+			ret = errors.Wrap(ErrRecipients, ret)
+			continue
+		}
+		contacts, err := ns.directory.Contacts(ctx, *sub.Recipients)
+		if err != nil {
+			err = errors.Wrap(ErrRecipients, err)
+			ns.record(ctx, []Subscription{sub}, msg, err)
+			ret = errors.Wrap(err, ret)
+			continue
+		}
+		for _, contact := range contacts {
+			s := sub
+			s.Contact = contact
+			resolved = append(resolved, s)
+		}
+	}
+
+	return resolved, ret
+}
+
+// record saves the delivery of the message to each of the subscriptions
+// with the given outcome. Failing to record deliveries doesn't fail the
+// notification, so the errors are only reported.
+func (ns *notifierService) record(ctx context.Context, subs []Subscription, msg *messaging.Message, notifyErr error) {
+	now := time.Now().UTC()
+	status, reason := DeliverySent, ""
+	if notifyErr != nil {
+		status, reason = DeliveryFailed, notifyErr.Error()
+	}
+
+	deliveries := make([]Delivery, 0, len(subs))
+	for _, sub := range subs {
+		id, err := ns.idp.ID()
+		if err != nil {
+			ns.report(err)
+			return
 		}
+		deliveries = append(deliveries, Delivery{
+			ID:             id,
+			SubscriptionID: sub.ID,
+			Contact:        sub.Contact,
+			Channel:        msg.GetChannel(),
+			Subtopic:       msg.GetSubtopic(),
+			Status:         status,
+			Error:          reason,
+			CreatedAt:      now,
+		})
+	}
+	if err := ns.deliveries.Save(ctx, deliveries); err != nil {
+		ns.report(err)
 	}
+
+	ns.prune(ctx, now)
 }
 
-func (ns *notifierService) Errors() <-chan error {
-	return ns.errCh
+// prune removes the deliveries older than the retention. Since it's
+// called on every notification, removal is done at most once per interval.
+func (ns *notifierService) prune(ctx context.Context, now time.Time) {
+	if ns.retention <= 0 {
+		return
+	}
+	ns.mu.Lock()
+	if now.Sub(ns.pruned) < pruneInterval {
+		ns.mu.Unlock()
+		return
+	}
+	ns.pruned = now
+	ns.mu.Unlock()
+
+	if err := ns.deliveries.RemoveBefore(ctx, now.Add(-ns.retention)); err != nil {
+		ns.report(err)
+	}
+}
+
+// report passes the error to the errors channel without blocking,
+// since the channel is read only by asynchronous consumers.
+func (ns *notifierService) report(err error) {
+	select {
+	case ns.errCh <- err:
+	default:
+	}
 }
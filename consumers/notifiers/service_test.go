@@ -5,12 +5,14 @@ package notifiers_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/magistrala/consumers/notifiers/mocks"
 	"github.com/absmach/magistrala/internal/testsutil"
-	"github.com/absmach/supermq/consumers/notifiers"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
 	"github.com/absmach/supermq/pkg/errors"
@@ -18,6 +20,7 @@ import (
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/go-kit/kit/metrics/discard"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -27,19 +30,21 @@ const (
 	exampleUser1 = "token1"
 	exampleUser2 = "token2"
 	validID      = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
+	retention    = 24 * time.Hour
 )
 
-func newService() (notifiers.Service, *authnmocks.Authentication, *mocks.SubscriptionsRepository) {
+func newService() (notifiers.Service, *authnmocks.Authentication, *mocks.SubscriptionsRepository, *mocks.DeliveriesRepository) {
 	repo := new(mocks.SubscriptionsRepository)
+	deliveries := new(mocks.DeliveriesRepository)
 	auth := new(authnmocks.Authentication)
 	notifier := new(mocks.Notifier)
 	idp := uuid.NewMock()
 	from := "exampleFrom"
-	return notifiers.New(auth, repo, idp, notifier, from), auth, repo
+	return notifiers.New(auth, repo, deliveries, new(mocks.ScheduledRepository), idp, notifier, nil, from, retention, discard.NewCounter()), auth, repo, deliveries
 }
 
 func TestCreateSubscription(t *testing.T) {
-	svc, auth, repo := newService()
+	svc, auth, repo, _ := newService()
 
 	cases := []struct {
 		desc            string
@@ -68,6 +73,15 @@ func TestCreateSubscription(t *testing.T) {
 			authenticateErr: nil,
 			userID:          validID,
 		},
+		{
+			desc:            "test with recipients without directory",
+			token:           exampleUser1,
+			sub:             notifiers.Subscription{Topic: "valid.topic", Recipients: &notifiers.Recipients{GroupID: validID, RoleID: validID}},
+			id:              "",
+			err:             notifiers.ErrRecipients,
+			authenticateErr: nil,
+			userID:          validID,
+		},
 		{
 			desc:            "test with empty token",
 			token:           "",
@@ -90,7 +104,7 @@ func TestCreateSubscription(t *testing.T) {
 }
 
 func TestViewSubscription(t *testing.T) {
-	svc, auth, repo := newService()
+	svc, auth, repo, _ := newService()
 	sub := notifiers.Subscription{
 		Contact: exampleUser1,
 		Topic:   "valid.topic",
@@ -147,7 +161,7 @@ func TestViewSubscription(t *testing.T) {
 }
 
 func TestListSubscriptions(t *testing.T) {
-	svc, auth, repo := newService()
+	svc, auth, repo, _ := newService()
 	sub := notifiers.Subscription{Contact: exampleUser1, OwnerID: exampleUser1}
 	topic := "topic.subtopic"
 	var subs []notifiers.Subscription
@@ -274,7 +288,7 @@ func TestListSubscriptions(t *testing.T) {
 }
 
 func TestRemoveSubscription(t *testing.T) {
-	svc, auth, repo := newService()
+	svc, auth, repo, _ := newService()
 	sub := notifiers.Subscription{
 		ID: testsutil.GenerateUUID(t),
 	}
@@ -322,8 +336,85 @@ func TestRemoveSubscription(t *testing.T) {
 	}
 }
 
+func TestListDeliveries(t *testing.T) {
+	svc, auth, repo, deliveries := newService()
+
+	sub := notifiers.Subscription{
+		ID:      testsutil.GenerateUUID(t),
+		OwnerID: validID,
+		Contact: exampleUser1,
+		Topic:   "valid.topic",
+	}
+	page := notifiers.DeliveriesPage{
+		DeliveriesPageMetadata: notifiers.DeliveriesPageMetadata{SubscriptionID: sub.ID, Limit: 10},
+		Total:                  1,
+		Deliveries: []notifiers.Delivery{
+			{
+				ID:             testsutil.GenerateUUID(t),
+				SubscriptionID: sub.ID,
+				Contact:        sub.Contact,
+				Channel:        "valid",
+				Subtopic:       "topic",
+				Status:         notifiers.DeliveryFailed,
+				Error:          "failed to deliver notification",
+				CreatedAt:      time.Now().UTC(),
+			},
+		},
+	}
+
+	cases := []struct {
+		desc            string
+		token           string
+		id              string
+		pm              notifiers.DeliveriesPageMetadata
+		page            notifiers.DeliveriesPage
+		authenticateErr error
+		retrieveErr     error
+		err             error
+	}{
+		{
+			desc:  "list deliveries successfully",
+			token: exampleUser1,
+			id:    sub.ID,
+			pm:    notifiers.DeliveriesPageMetadata{Limit: 10},
+			page:  page,
+			err:   nil,
+		},
+		{
+			desc:        "list deliveries of not existing subscription",
+			token:       exampleUser1,
+			id:          "not_exist",
+			pm:          notifiers.DeliveriesPageMetadata{Limit: 10},
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:            "list deliveries with invalid token",
+			token:           exampleUser2,
+			id:              sub.ID,
+			pm:              notifiers.DeliveriesPageMetadata{Limit: 10},
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Authenticate", context.Background(), tc.token).Return(smqauthn.Session{UserID: validID}, tc.authenticateErr)
+		repoCall := repo.On("Retrieve", context.Background(), tc.id).Return(sub, tc.retrieveErr)
+		pm := tc.pm
+		pm.SubscriptionID = tc.id
+		deliveriesCall := deliveries.On("RetrieveAll", context.Background(), pm).Return(tc.page, nil)
+		page, err := svc.ListDeliveries(context.Background(), tc.token, tc.id, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.page, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.page, page))
+		authCall.Unset()
+		repoCall.Unset()
+		deliveriesCall.Unset()
+	}
+}
+
 func TestConsume(t *testing.T) {
-	svc, _, repo := newService()
+	svc, _, repo, _ := newService()
 	msg := messaging.Message{
 		Channel:  "topic",
 		Subtopic: "subtopic",
@@ -357,3 +448,355 @@ func TestConsume(t *testing.T) {
 		repoCall.Unset()
 	}
 }
+
+func TestConsumeWithSchedule(t *testing.T) {
+	repo := new(mocks.SubscriptionsRepository)
+	deliveries := new(mocks.DeliveriesRepository)
+	scheduled := new(mocks.ScheduledRepository)
+	notifier := new(mocks.Notifier)
+	svc := notifiers.New(new(authnmocks.Authentication), repo, deliveries, scheduled, uuid.NewMock(), notifier, nil, "exampleFrom", 0, discard.NewCounter())
+
+	// Schedule which allows notifications only in a few days.
+	later := notifiers.Schedule{
+		Days:     []time.Weekday{time.Now().UTC().AddDate(0, 0, 3).Weekday()},
+		Start:    "00:00",
+		End:      "00:00",
+		Severity: 5,
+	}
+	allowed := notifiers.Subscription{ID: "allowed", Contact: "allowed@example.com", Topic: "topic"}
+	suppressed := notifiers.Subscription{ID: "suppressed", Contact: "suppressed@example.com", Topic: "topic", Schedule: &later}
+	deferLater := later
+	deferLater.Defer = true
+	deferred := notifiers.Subscription{ID: "deferred", Contact: "deferred@example.com", Topic: "topic", Schedule: &deferLater}
+
+	cases := []struct {
+		desc     string
+		payload  string
+		subs     []notifiers.Subscription
+		to       []string
+		deferred bool
+	}{
+		{
+			desc:    "notify subscription without schedule",
+			payload: `{"severity": 1}`,
+			subs:    []notifiers.Subscription{allowed, suppressed},
+			to:      []string{allowed.Contact},
+		},
+		{
+			desc:    "notify subscription outside of schedule with breaking severity",
+			payload: `{"severity": 5}`,
+			subs:    []notifiers.Subscription{allowed, suppressed},
+			to:      []string{allowed.Contact, suppressed.Contact},
+		},
+		{
+			desc:    "suppress subscription outside of schedule",
+			payload: `{"severity": 2}`,
+			subs:    []notifiers.Subscription{suppressed},
+			to:      nil,
+		},
+		{
+			desc:     "defer subscription outside of schedule",
+			payload:  `{"severity": 2}`,
+			subs:     []notifiers.Subscription{deferred},
+			to:       nil,
+			deferred: true,
+		},
+	}
+
+	for _, tc := range cases {
+		msg := &messaging.Message{Channel: "topic", Payload: []byte(tc.payload)}
+		repoCall := repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: tc.subs}, nil)
+		notifierCall := notifier.On("Notify", "exampleFrom", tc.to, msg).Return(nil)
+		deliveriesCall := deliveries.On("Save", context.TODO(), mock.Anything).Return(nil)
+		scheduledCall := scheduled.On("Save", context.TODO(), mock.Anything).Return(nil)
+		err := svc.ConsumeBlocking(context.TODO(), msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		if tc.to == nil {
+			notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, msg)
+		} else {
+			notifier.AssertCalled(t, "Notify", "exampleFrom", tc.to, msg)
+		}
+		if tc.deferred {
+			scheduled.AssertCalled(t, "Save", context.TODO(), mock.MatchedBy(func(sd notifiers.ScheduledDelivery) bool {
+				return sd.SubscriptionID == deferred.ID && sd.Message == msg && sd.DueAt.After(time.Now())
+			}))
+		}
+		repoCall.Unset()
+		notifierCall.Unset()
+		deliveriesCall.Unset()
+		scheduledCall.Unset()
+	}
+}
+
+func TestDeliverScheduled(t *testing.T) {
+	repo := new(mocks.SubscriptionsRepository)
+	deliveries := new(mocks.DeliveriesRepository)
+	scheduled := new(mocks.ScheduledRepository)
+	notifier := new(mocks.Notifier)
+	svc := notifiers.New(new(authnmocks.Authentication), repo, deliveries, scheduled, uuid.NewMock(), notifier, nil, "exampleFrom", 0, discard.NewCounter())
+
+	sub := notifiers.Subscription{ID: testsutil.GenerateUUID(t), Contact: "user@example.com", Topic: "topic"}
+	msg := &messaging.Message{Channel: "topic", Payload: []byte(`{"severity": 1}`)}
+	due := []notifiers.ScheduledDelivery{
+		{ID: testsutil.GenerateUUID(t), SubscriptionID: sub.ID, Message: msg},
+		{ID: testsutil.GenerateUUID(t), SubscriptionID: "removed", Message: msg},
+	}
+
+	cases := []struct {
+		desc      string
+		scheduled []notifiers.ScheduledDelivery
+		popErr    error
+		notifyErr error
+		to        []string
+		err       error
+	}{
+		{
+			desc:      "deliver due scheduled notifications",
+			scheduled: due,
+			to:        []string{sub.Contact},
+			err:       nil,
+		},
+		{
+			desc:      "deliver no scheduled notifications",
+			scheduled: []notifiers.ScheduledDelivery{},
+			err:       nil,
+		},
+		{
+			desc:      "deliver scheduled notifications with failed notification",
+			scheduled: due[:1],
+			notifyErr: notifiers.ErrNotify,
+			to:        []string{sub.Contact},
+			err:       notifiers.ErrNotify,
+		},
+		{
+			desc:   "deliver scheduled notifications with failed pop",
+			popErr: repoerr.ErrRemoveEntity,
+			err:    repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		popCall := scheduled.On("Pop", context.TODO(), mock.Anything, uint64(100)).Return(tc.scheduled, tc.popErr)
+		repoCall := repo.On("Retrieve", context.TODO(), sub.ID).Return(sub, nil)
+		repoCall1 := repo.On("Retrieve", context.TODO(), "removed").Return(notifiers.Subscription{}, repoerr.ErrNotFound)
+		notifierCall := notifier.On("Notify", "exampleFrom", tc.to, msg).Return(tc.notifyErr)
+		deliveriesCall := deliveries.On("Save", context.TODO(), mock.Anything).Return(nil)
+		err := svc.DeliverScheduled(context.TODO())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.to != nil {
+			notifier.AssertCalled(t, "Notify", "exampleFrom", tc.to, msg)
+		}
+		popCall.Unset()
+		repoCall.Unset()
+		repoCall1.Unset()
+		notifierCall.Unset()
+		deliveriesCall.Unset()
+	}
+}
+
+func TestConsumeRecordsDeliveries(t *testing.T) {
+	repo := new(mocks.SubscriptionsRepository)
+	deliveries := new(mocks.DeliveriesRepository)
+	notifier := new(mocks.Notifier)
+	svc := notifiers.New(new(authnmocks.Authentication), repo, deliveries, new(mocks.ScheduledRepository), uuid.NewMock(), notifier, nil, "exampleFrom", retention, discard.NewCounter())
+
+	sub := notifiers.Subscription{ID: testsutil.GenerateUUID(t), Contact: "user@example.com", Topic: "topic.subtopic"}
+
+	cases := []struct {
+		desc      string
+		notifyErr error
+		status    notifiers.DeliveryStatus
+		err       error
+	}{
+		{
+			desc:   "record sent delivery",
+			status: notifiers.DeliverySent,
+			err:    nil,
+		},
+		{
+			desc:      "record failed delivery",
+			notifyErr: errors.New("failed to send email"),
+			status:    notifiers.DeliveryFailed,
+			err:       notifiers.ErrNotify,
+		},
+	}
+
+	for _, tc := range cases {
+		msg := &messaging.Message{Channel: "topic", Subtopic: "subtopic", Payload: []byte(`{"temperature": 20}`)}
+		saved := mock.MatchedBy(func(ds []notifiers.Delivery) bool {
+			return len(ds) == 1 &&
+				ds[0].SubscriptionID == sub.ID &&
+				ds[0].Contact == sub.Contact &&
+				ds[0].Channel == msg.Channel &&
+				ds[0].Subtopic == msg.Subtopic &&
+				ds[0].Status == tc.status
+		})
+		repoCall := repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: []notifiers.Subscription{sub}}, nil)
+		notifierCall := notifier.On("Notify", "exampleFrom", []string{sub.Contact}, msg).Return(tc.notifyErr)
+		deliveriesCall := deliveries.On("Save", context.TODO(), mock.Anything).Return(nil)
+		deliveriesCall1 := deliveries.On("RemoveBefore", context.TODO(), mock.Anything).Return(nil)
+		err := svc.ConsumeBlocking(context.TODO(), msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		deliveries.AssertCalled(t, "Save", context.TODO(), saved)
+		repoCall.Unset()
+		notifierCall.Unset()
+		deliveriesCall.Unset()
+		deliveriesCall1.Unset()
+	}
+	// Deliveries are pruned at most once per interval.
+	deliveries.AssertNumberOfCalls(t, "RemoveBefore", 1)
+}
+
+func TestConsumeWithDigest(t *testing.T) {
+	repo := new(mocks.SubscriptionsRepository)
+	deliveries := new(mocks.DeliveriesRepository)
+	scheduled := new(mocks.ScheduledRepository)
+	notifier := new(mocks.Notifier)
+	svc := notifiers.New(new(authnmocks.Authentication), repo, deliveries, scheduled, uuid.NewMock(), notifier, nil, "exampleFrom", 0, discard.NewCounter())
+
+	sub := notifiers.Subscription{ID: "digest", Contact: "digest@example.com", Topic: "topic", Digest: &notifiers.Digest{Interval: "1h", Severity: 5}}
+	repoCall := repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: []notifiers.Subscription{sub}}, nil)
+	deliveriesCall := deliveries.On("Save", mock.Anything, mock.Anything).Return(nil)
+	scheduledCall := scheduled.On("Save", context.TODO(), mock.Anything).Return(nil)
+	defer repoCall.Unset()
+	defer deliveriesCall.Unset()
+	defer scheduledCall.Unset()
+
+	// Critical message bypasses the digest.
+	critical := &messaging.Message{Channel: "topic", Payload: []byte(`{"severity": 5}`)}
+	notifierCall := notifier.On("Notify", "exampleFrom", []string{sub.Contact}, critical).Return(nil)
+	err := svc.ConsumeBlocking(context.TODO(), critical)
+	assert.Nil(t, err, fmt.Sprintf("notifying critical message: unexpected error %s\n", err))
+	notifier.AssertCalled(t, "Notify", "exampleFrom", []string{sub.Contact}, critical)
+	scheduled.AssertNotCalled(t, "Save", context.TODO(), mock.Anything)
+	notifierCall.Unset()
+
+	msg := &messaging.Message{Channel: "topic", Payload: []byte(`{"severity": 1}`)}
+	err = svc.ConsumeBlocking(context.TODO(), msg)
+	assert.Nil(t, err, fmt.Sprintf("accumulating message: unexpected error %s\n", err))
+	notifier.AssertNumberOfCalls(t, "Notify", 1)
+	scheduled.AssertCalled(t, "Save", context.TODO(), mock.MatchedBy(func(sd notifiers.ScheduledDelivery) bool {
+		// Digest is due at the end of the current interval.
+		aligned := sd.DueAt.Equal(sd.DueAt.Truncate(time.Hour))
+		return sd.SubscriptionID == sub.ID && sd.Digest && sd.Message == msg && aligned && time.Until(sd.DueAt) <= time.Hour
+	}))
+}
+
+func TestDeliverScheduledDigest(t *testing.T) {
+	repo := new(mocks.SubscriptionsRepository)
+	deliveries := new(mocks.DeliveriesRepository)
+	scheduled := new(mocks.ScheduledRepository)
+	notifier := new(mocks.Notifier)
+	svc := notifiers.New(new(authnmocks.Authentication), repo, deliveries, scheduled, uuid.NewMock(), notifier, nil, "exampleFrom", 0, discard.NewCounter())
+
+	sub := notifiers.Subscription{ID: "digest", Contact: "digest@example.com", Topic: "topic", Digest: &notifiers.Digest{Interval: "1h"}}
+	first := time.Now().UTC().Add(-time.Hour)
+	payloads := []string{`{"severity": 1}`, `{"severity": 2}`, `{"severity": 3}`, `{"severity": 4}`}
+	var due []notifiers.ScheduledDelivery
+	for i, p := range payloads {
+		due = append(due, notifiers.ScheduledDelivery{
+			ID:             testsutil.GenerateUUID(t),
+			SubscriptionID: sub.ID,
+			Message:        &messaging.Message{Channel: "topic", Payload: []byte(p)},
+			Digest:         true,
+			CreatedAt:      first.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	popCall := scheduled.On("Pop", context.TODO(), mock.Anything, uint64(100)).Return(due, nil)
+	repoCall := repo.On("Retrieve", context.TODO(), sub.ID).Return(sub, nil)
+	deliveriesCall := deliveries.On("Save", context.TODO(), mock.Anything).Return(nil)
+	var summary notifiers.DigestSummary
+	notifierCall := notifier.On("Notify", "exampleFrom", []string{sub.Contact}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		msg := args.Get(2).(*messaging.Message)
+		assert.Equal(t, "topic", msg.Channel, fmt.Sprintf("expected digest channel topic got %s\n", msg.Channel))
+		err := json.Unmarshal(msg.Payload, &summary)
+		assert.Nil(t, err, fmt.Sprintf("decoding digest summary: unexpected error %s\n", err))
+	})
+	defer popCall.Unset()
+	defer repoCall.Unset()
+	defer deliveriesCall.Unset()
+	defer notifierCall.Unset()
+
+	err := svc.DeliverScheduled(context.TODO())
+	assert.Nil(t, err, fmt.Sprintf("delivering digest: unexpected error %s\n", err))
+	notifier.AssertNumberOfCalls(t, "Notify", 1)
+	assert.Equal(t, uint64(len(payloads)), summary.Count, fmt.Sprintf("expected count %d got %d\n", len(payloads), summary.Count))
+	assert.Equal(t, payloads[:3], summary.Sample, fmt.Sprintf("expected sample %v got %v\n", payloads[:3], summary.Sample))
+	assert.True(t, first.Equal(summary.First), fmt.Sprintf("expected first %s got %s\n", first, summary.First))
+	assert.True(t, due[len(due)-1].CreatedAt.Equal(summary.Last), fmt.Sprintf("expected last %s got %s\n", due[len(due)-1].CreatedAt, summary.Last))
+}
+
+func TestConsumeWithRecipients(t *testing.T) {
+	repo := new(mocks.SubscriptionsRepository)
+	deliveries := new(mocks.DeliveriesRepository)
+	notifier := new(mocks.Notifier)
+	directory := new(mocks.Directory)
+	svc := notifiers.New(new(authnmocks.Authentication), repo, deliveries, new(mocks.ScheduledRepository), uuid.NewMock(), notifier, directory, "exampleFrom", 0, discard.NewCounter())
+
+	recipients := notifiers.Recipients{DomainID: testsutil.GenerateUUID(t), GroupID: testsutil.GenerateUUID(t), RoleID: "on-call"}
+	static := notifiers.Subscription{ID: "static", Contact: "static@example.com", Topic: "topic"}
+	group := notifiers.Subscription{ID: "group", Topic: "topic", Recipients: &recipients}
+
+	cases := []struct {
+		desc       string
+		subs       []notifiers.Subscription
+		contacts   []string
+		resolveErr error
+		to         []string
+		failed     bool
+		err        error
+	}{
+		{
+			desc:     "notify recipients",
+			subs:     []notifiers.Subscription{group},
+			contacts: []string{"user1@example.com", "user2@example.com"},
+			to:       []string{"user1@example.com", "user2@example.com"},
+		},
+		{
+			desc:     "notify recipients and contact",
+			subs:     []notifiers.Subscription{static, group},
+			contacts: []string{"user1@example.com"},
+			to:       []string{static.Contact, "user1@example.com"},
+		},
+		{
+			desc:     "notify recipients without members",
+			subs:     []notifiers.Subscription{group},
+			contacts: []string{},
+			to:       nil,
+		},
+		{
+			desc:       "notify recipients with failed resolution",
+			subs:       []notifiers.Subscription{static, group},
+			resolveErr: svcerr.ErrAuthorization,
+			to:         []string{static.Contact},
+			failed:     true,
+			err:        notifiers.ErrRecipients,
+		},
+	}
+
+	for _, tc := range cases {
+		msg := &messaging.Message{Channel: "topic", Publisher: tc.desc, Payload: []byte(`{"temperature": 20}`)}
+		failed := mock.MatchedBy(func(ds []notifiers.Delivery) bool {
+			return len(ds) == 1 && ds[0].SubscriptionID == group.ID && ds[0].Status == notifiers.DeliveryFailed
+		})
+		repoCall := repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: tc.subs}, nil)
+		dirCall := directory.On("Contacts", context.TODO(), recipients).Return(tc.contacts, tc.resolveErr)
+		notifierCall := notifier.On("Notify", "exampleFrom", tc.to, msg).Return(nil)
+		deliveriesCall := deliveries.On("Save", context.TODO(), mock.Anything).Return(nil)
+		err := svc.ConsumeBlocking(context.TODO(), msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.to == nil {
+			notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, msg)
+		} else {
+			notifier.AssertCalled(t, "Notify", "exampleFrom", tc.to, msg)
+		}
+		if tc.failed {
+			deliveries.AssertCalled(t, "Save", context.TODO(), failed)
+		}
+		repoCall.Unset()
+		dirCall.Unset()
+		notifierCall.Unset()
+		deliveriesCall.Unset()
+	}
+}
@@ -4,9 +4,10 @@
 package smpp
 
 import (
+	"context"
 	"time"
 
-	"github.com/absmach/supermq/consumers/notifiers"
+	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/transformers"
 	"github.com/absmach/supermq/pkg/transformers/json"
@@ -15,7 +16,13 @@ import (
 	"github.com/fiorix/go-smpp/smpp/pdu/pdutext"
 )
 
-var _ notifiers.Notifier = (*notifier)(nil)
+// defaultTemplate keeps the raw message payload as SMS content.
+const defaultTemplate = "{{.Payload}}"
+
+var (
+	_ notifiers.Notifier             = (*notifier)(nil)
+	_ notifiers.SubscriptionNotifier = (*notifier)(nil)
+)
 
 type notifier struct {
 	transmitter   *smpp.Transmitter
@@ -48,6 +55,25 @@ func New(cfg Config) notifiers.Notifier {
 }
 
 func (n *notifier) Notify(from string, to []string, msg *messaging.Message) error {
+	return n.send(from, to, "", msg)
+}
+
+func (n *notifier) NotifySubscriptions(_ context.Context, from string, subs []notifiers.Subscription, msg *messaging.Message) error {
+	for tmpl, to := range notifiers.GroupByTemplate(subs) {
+		if err := n.send(from, to, tmpl, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *notifier) send(from string, to []string, tmpl string, msg *messaging.Message) error {
+	text, err := notifiers.Render(tmpl, defaultTemplate, msg)
+	if err != nil {
+		return err
+	}
+
 	send := &smpp.ShortMessage{
 		Src:           from,
 		DstList:       to,
@@ -56,11 +82,10 @@ func (n *notifier) Notify(from string, to []string, msg *messaging.Message) erro
 		DestAddrTON:   n.destAddrTON,
 		SourceAddrNPI: n.sourceAddrNPI,
 		DestAddrNPI:   n.destAddrNPI,
-		Text:          pdutext.Raw(msg.GetPayload()),
+		Text:          pdutext.Raw(text),
 		Register:      pdufield.NoDeliveryReceipt,
 	}
-	_, err := n.transmitter.Submit(send)
-	if err != nil {
+	if _, err := n.transmitter.Submit(send); err != nil {
 		return err
 	}
 	return nil
@@ -4,37 +4,58 @@
 package smtp
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/magistrala/internal/email"
-	"github.com/absmach/supermq/consumers/notifiers"
 	"github.com/absmach/supermq/pkg/messaging"
 )
 
 const (
 	footer          = "Sent by SuperMQ SMTP Notification"
-	contentTemplate = "A publisher with an id %s sent the message over %s with the following values \n %s"
+	contentTemplate = "A publisher with an id {{.Publisher}} sent the message over {{.Protocol}} with the following values \n {{.Payload}}"
 )
 
-var _ notifiers.Notifier = (*notifier)(nil)
+var (
+	_ notifiers.Notifier             = (*notifier)(nil)
+	_ notifiers.SubscriptionNotifier = (*notifier)(nil)
+)
 
 type notifier struct {
-	agent *email.Agent
+	agent email.Sender
 }
 
-// New instantiates SMTP message notifier.
-func New(agent *email.Agent) notifiers.Notifier {
+// New instantiates SMTP message notifier. The agent is usually created with
+// email.NewSender, so notifications are sent through the e-mail queue.
+func New(agent email.Sender) notifiers.Notifier {
 	return &notifier{agent: agent}
 }
 
 func (n *notifier) Notify(from string, to []string, msg *messaging.Message) error {
+	return n.send(from, to, "", msg)
+}
+
+func (n *notifier) NotifySubscriptions(_ context.Context, from string, subs []notifiers.Subscription, msg *messaging.Message) error {
+	for tmpl, to := range notifiers.GroupByTemplate(subs) {
+		if err := n.send(from, to, tmpl, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *notifier) send(from string, to []string, tmpl string, msg *messaging.Message) error {
 	subject := fmt.Sprintf(`Notification for Channel %s`, msg.GetChannel())
 	if msg.GetSubtopic() != "" {
 		subject = fmt.Sprintf("%s and subtopic %s", subject, msg.GetSubtopic())
 	}
 
-	values := string(msg.GetPayload())
-	content := fmt.Sprintf(contentTemplate, msg.GetPublisher(), msg.GetProtocol(), values)
+	content, err := notifiers.Render(tmpl, contentTemplate, msg)
+	if err != nil {
+		return err
+	}
 
 	return n.agent.Send(to, from, subject, "", "", content, footer)
 }
@@ -5,12 +5,21 @@ package notifiers
 
 import "context"
 
-// Subscription represents a user Subscription.
+// Subscription represents a user Subscription. For webhook based notifiers,
+// Contact holds the destination URL and Template the optional message template.
+// Optional Schedule restricts the hours in which notifications are sent, while
+// optional Digest batches notifications into periodic summaries. Instead of
+// the Contact, the subscription may define Recipients resolved on each
+// notification.
 type Subscription struct {
-	ID      string
-	OwnerID string
-	Contact string
-	Topic   string
+	ID         string
+	OwnerID    string
+	Contact    string
+	Topic      string
+	Template   string
+	Schedule   *Schedule
+	Digest     *Digest
+	Recipients *Recipients
 }
 
 // Page represents page metadata with content.
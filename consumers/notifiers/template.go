@@ -0,0 +1,103 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"text/template"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+// ErrTemplate indicates that subscription message template is invalid.
+var ErrTemplate = errors.New("invalid message template")
+
+// TemplateData represents the message fields available to subscription templates.
+type TemplateData struct {
+	Channel   string
+	Subtopic  string
+	Topic     string
+	Publisher string
+	Protocol  string
+	Created   time.Time
+	Payload   string
+}
+
+// sampleData is used to execute templates on validation, so that templates
+// referring to unknown fields or using fields of the wrong type are rejected.
+var sampleData = TemplateData{
+	Channel:   "channel",
+	Subtopic:  "subtopic",
+	Topic:     "channel.subtopic",
+	Publisher: "publisher",
+	Protocol:  "http",
+	Created:   time.Unix(0, 0),
+	Payload:   `{"value":1}`,
+}
+
+// ValidateTemplate checks that the provided subscription template can be parsed
+// and executed. An empty template is valid and means that the notifier default
+// format is used.
+func ValidateTemplate(tmpl string) error {
+	if tmpl == "" {
+		return nil
+	}
+	t, err := template.New("notification").Parse(tmpl)
+	if err != nil {
+		return errors.Wrap(ErrTemplate, err)
+	}
+	if err := t.Execute(io.Discard, sampleData); err != nil {
+		return errors.Wrap(ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// Render renders the notification content for the given message using the
+// subscription template, or the default template if the former is empty.
+func Render(tmpl, defaultTmpl string, msg *messaging.Message) (string, error) {
+	if tmpl == "" {
+		tmpl = defaultTmpl
+	}
+	t, err := template.New("notification").Parse(tmpl)
+	if err != nil {
+		return "", errors.Wrap(ErrTemplate, err)
+	}
+
+	topic := msg.GetChannel()
+	if msg.GetSubtopic() != "" {
+		topic = fmt.Sprintf("%s.%s", msg.GetChannel(), msg.GetSubtopic())
+	}
+	data := TemplateData{
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Topic:     topic,
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Created:   time.Unix(0, msg.GetCreated()),
+		Payload:   string(msg.GetPayload()),
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(ErrTemplate, err)
+	}
+
+	return buf.String(), nil
+}
+
+// GroupByTemplate groups subscription contacts by the subscription template,
+// so that notifiers sending one notification to many contacts render each
+// template only once.
+func GroupByTemplate(subs []Subscription) map[string][]string {
+	groups := make(map[string][]string)
+	for _, sub := range subs {
+		groups[sub.Template] = append(groups[sub.Template], sub.Contact)
+	}
+
+	return groups
+}
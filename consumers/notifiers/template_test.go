@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	msg := &messaging.Message{
+		Channel:   "channel",
+		Subtopic:  "subtopic",
+		Publisher: "publisher",
+		Protocol:  "mqtt",
+		Payload:   []byte(`{"v":1}`),
+	}
+	defaultTmpl := "{{.Publisher}}: {{.Payload}}"
+
+	cases := []struct {
+		desc     string
+		template string
+		content  string
+		err      error
+	}{
+		{
+			desc:     "render with subscription template",
+			template: "{{.Topic}} over {{.Protocol}}",
+			content:  "channel.subtopic over mqtt",
+			err:      nil,
+		},
+		{
+			desc:     "render with empty template",
+			template: "",
+			content:  `publisher: {"v":1}`,
+			err:      nil,
+		},
+		{
+			desc:     "render with invalid template",
+			template: "{{.Topic",
+			content:  "",
+			err:      notifiers.ErrTemplate,
+		},
+		{
+			desc:     "render with unknown field",
+			template: "{{.Unknown}}",
+			content:  "",
+			err:      notifiers.ErrTemplate,
+		},
+	}
+
+	for _, tc := range cases {
+		content, err := notifiers.Render(tc.template, defaultTmpl, msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.content, content, fmt.Sprintf("%s: expected content %s got %s\n", tc.desc, tc.content, content))
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	cases := []struct {
+		desc     string
+		template string
+		err      error
+	}{
+		{
+			desc:     "validate valid template",
+			template: "{{.Channel}}: {{.Payload}}",
+			err:      nil,
+		},
+		{
+			desc:     "validate empty template",
+			template: "",
+			err:      nil,
+		},
+		{
+			desc:     "validate invalid template",
+			template: "{{.Channel",
+			err:      notifiers.ErrTemplate,
+		},
+		{
+			desc:     "validate template with unknown field",
+			template: "{{.Value}}",
+			err:      notifiers.ErrTemplate,
+		},
+		{
+			desc:     "validate template with field of wrong type",
+			template: "{{.Payload.Value}}",
+			err:      notifiers.ErrTemplate,
+		},
+	}
+
+	for _, tc := range cases {
+		err := notifiers.ValidateTemplate(tc.template)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	saveDeliveriesOp        = "save_deliveries_op"
+	retrieveAllDeliveriesOp = "retrieve_all_deliveries_op"
+	removeDeliveriesOp      = "remove_deliveries_op"
+)
+
+var _ notifiers.DeliveriesRepository = (*delRepositoryMiddleware)(nil)
+
+type delRepositoryMiddleware struct {
+	tracer trace.Tracer
+	repo   notifiers.DeliveriesRepository
+}
+
+// NewDeliveriesRepository instantiates a new Deliveries repository that
+// tracks request and their latency, and adds spans to context.
+func NewDeliveriesRepository(tracer trace.Tracer, repo notifiers.DeliveriesRepository) notifiers.DeliveriesRepository {
+	return delRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+// Save traces the "Save" operation of the wrapped Deliveries repository.
+func (drm delRepositoryMiddleware) Save(ctx context.Context, deliveries []notifiers.Delivery) error {
+	ctx, span := drm.tracer.Start(ctx, saveDeliveriesOp, trace.WithAttributes(
+		attribute.Int("deliveries", len(deliveries)),
+	))
+	defer span.End()
+
+	return drm.repo.Save(ctx, deliveries)
+}
+
+// RetrieveAll traces the "RetrieveAll" operation of the wrapped Deliveries repository.
+func (drm delRepositoryMiddleware) RetrieveAll(ctx context.Context, pm notifiers.DeliveriesPageMetadata) (notifiers.DeliveriesPage, error) {
+	ctx, span := drm.tracer.Start(ctx, retrieveAllDeliveriesOp, trace.WithAttributes(
+		attribute.String("subscription_id", pm.SubscriptionID),
+	))
+	defer span.End()
+
+	return drm.repo.RetrieveAll(ctx, pm)
+}
+
+// RemoveBefore traces the "RemoveBefore" operation of the wrapped Deliveries repository.
+func (drm delRepositoryMiddleware) RemoveBefore(ctx context.Context, before time.Time) error {
+	ctx, span := drm.tracer.Start(ctx, removeDeliveriesOp, trace.WithAttributes(
+		attribute.String("before", before.Format(time.RFC3339)),
+	))
+	defer span.End()
+
+	return drm.repo.RemoveBefore(ctx, before)
+}
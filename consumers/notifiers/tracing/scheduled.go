@@ -0,0 +1,56 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	saveScheduledOp = "save_scheduled_delivery_op"
+	popScheduledOp  = "pop_scheduled_deliveries_op"
+)
+
+var _ notifiers.ScheduledRepository = (*schedRepositoryMiddleware)(nil)
+
+type schedRepositoryMiddleware struct {
+	tracer trace.Tracer
+	repo   notifiers.ScheduledRepository
+}
+
+// NewScheduledRepository instantiates a new Scheduled deliveries repository
+// that tracks request and their latency, and adds spans to context.
+func NewScheduledRepository(tracer trace.Tracer, repo notifiers.ScheduledRepository) notifiers.ScheduledRepository {
+	return schedRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+// Save traces the "Save" operation of the wrapped Scheduled deliveries repository.
+func (srm schedRepositoryMiddleware) Save(ctx context.Context, sd notifiers.ScheduledDelivery) error {
+	ctx, span := srm.tracer.Start(ctx, saveScheduledOp, trace.WithAttributes(
+		attribute.String("subscription_id", sd.SubscriptionID),
+		attribute.String("due_at", sd.DueAt.Format(time.RFC3339)),
+	))
+	defer span.End()
+
+	return srm.repo.Save(ctx, sd)
+}
+
+// Pop traces the "Pop" operation of the wrapped Scheduled deliveries repository.
+func (srm schedRepositoryMiddleware) Pop(ctx context.Context, due time.Time, limit uint64) ([]notifiers.ScheduledDelivery, error) {
+	ctx, span := srm.tracer.Start(ctx, popScheduledOp, trace.WithAttributes(
+		attribute.String("due", due.Format(time.RFC3339)),
+		attribute.Int64("limit", int64(limit)),
+	))
+	defer span.End()
+
+	return srm.repo.Pop(ctx, due, limit)
+}
@@ -0,0 +1,15 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import "time"
+
+// Config represents webhook notifier configuration.
+type Config struct {
+	Timeout          time.Duration `env:"SMQ_WEBHOOK_TIMEOUT"            envDefault:"5s"`
+	MaxRetries       uint          `env:"SMQ_WEBHOOK_MAX_RETRIES"        envDefault:"3"`
+	RetryInterval    time.Duration `env:"SMQ_WEBHOOK_RETRY_INTERVAL"     envDefault:"500ms"`
+	MaxRetryInterval time.Duration `env:"SMQ_WEBHOOK_MAX_RETRY_INTERVAL" envDefault:"10s"`
+	AllowPrivate     bool          `env:"SMQ_WEBHOOK_ALLOW_PRIVATE"      envDefault:"false"`
+}
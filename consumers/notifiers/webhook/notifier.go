@@ -0,0 +1,264 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	kindSlack   = "slack"
+	kindWebhook = "webhook"
+
+	statusSent   = "sent"
+	statusRetry  = "retry"
+	statusFailed = "failed"
+
+	defaultTemplate = "A publisher with an id {{.Publisher}} sent the message over {{.Protocol}} to {{.Topic}} with the following values \n {{.Payload}}"
+)
+
+var (
+	// ErrDelivery indicates that the webhook request failed after all retries.
+	ErrDelivery = errors.New("failed to deliver webhook notification")
+
+	// ErrInvalidURL indicates that the webhook URL is not a valid HTTP(S) URL.
+	ErrInvalidURL = errors.New("invalid webhook URL")
+
+	// ErrForbiddenHost indicates that the webhook URL points to a loopback,
+	// link-local or private address.
+	ErrForbiddenHost = errors.New("webhook host is not allowed")
+
+	errStatus = errors.New("unexpected webhook response status")
+)
+
+var (
+	_ notifiers.Notifier             = (*notifier)(nil)
+	_ notifiers.SubscriptionNotifier = (*notifier)(nil)
+)
+
+type encodeFunc func(msg *messaging.Message, text string) ([]byte, error)
+
+type notifier struct {
+	kind    string
+	client  *http.Client
+	cfg     Config
+	counter metrics.Counter
+	encode  encodeFunc
+}
+
+// NewSlack instantiates Slack notifier which posts messages to
+// Slack incoming webhook URLs stored as subscription contacts.
+func NewSlack(cfg Config, counter metrics.Counter) notifiers.Notifier {
+	return newNotifier(kindSlack, cfg, counter, encodeSlack)
+}
+
+// New instantiates generic webhook notifier which posts JSON encoded
+// messages to the URLs stored as subscription contacts.
+func New(cfg Config, counter metrics.Counter) notifiers.Notifier {
+	return newNotifier(kindWebhook, cfg, counter, encodeJSON)
+}
+
+func newNotifier(kind string, cfg Config, counter metrics.Counter, encode encodeFunc) *notifier {
+	n := &notifier{
+		kind:    kind,
+		cfg:     cfg,
+		counter: counter,
+		encode:  encode,
+	}
+	// Addresses are checked again when dialing, so redirects and DNS
+	// changes can't be used to reach the forbidden hosts.
+	dialer := &net.Dialer{Timeout: cfg.Timeout, Control: n.control}
+	n.client = &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+
+	return n
+}
+
+func (n *notifier) Notify(from string, to []string, msg *messaging.Message) error {
+	subs := make([]notifiers.Subscription, len(to))
+	for i, contact := range to {
+		subs[i] = notifiers.Subscription{Contact: contact}
+	}
+
+	return n.NotifySubscriptions(context.Background(), from, subs, msg)
+}
+
+func (n *notifier) NotifySubscriptions(ctx context.Context, _ string, subs []notifiers.Subscription, msg *messaging.Message) error {
+	var ret error
+	for _, sub := range subs {
+		if err := n.notify(ctx, sub, msg); err != nil {
+			ret = errors.Wrap(err, ret)
+		}
+	}
+
+	return ret
+}
+
+func (n *notifier) notify(ctx context.Context, sub notifiers.Subscription, msg *messaging.Message) error {
+	text, err := notifiers.Render(sub.Template, defaultTemplate, msg)
+	if err != nil {
+		n.counter.With("kind", n.kind, "status", statusFailed).Add(1)
+		return err
+	}
+	body, err := n.encode(msg, text)
+	if err != nil {
+		n.counter.With("kind", n.kind, "status", statusFailed).Add(1)
+		return err
+	}
+
+	if err := n.validate(ctx, sub.Contact); err != nil {
+		n.counter.With("kind", n.kind, "status", statusFailed).Add(1)
+		return errors.Wrap(ErrDelivery, err)
+	}
+
+	interval := n.cfg.RetryInterval
+	for attempt := uint(0); ; attempt++ {
+		retry, err := n.post(ctx, sub.Contact, body)
+		if err == nil {
+			n.counter.With("kind", n.kind, "status", statusSent).Add(1)
+			return nil
+		}
+		if !retry || attempt >= n.cfg.MaxRetries {
+			n.counter.With("kind", n.kind, "status", statusFailed).Add(1)
+			return errors.Wrap(ErrDelivery, err)
+		}
+		n.counter.With("kind", n.kind, "status", statusRetry).Add(1)
+		select {
+		case <-ctx.Done():
+			n.counter.With("kind", n.kind, "status", statusFailed).Add(1)
+			return errors.Wrap(ErrDelivery, ctx.Err())
+		case <-time.After(interval):
+		}
+		interval *= 2
+		if n.cfg.MaxRetryInterval > 0 && interval > n.cfg.MaxRetryInterval {
+			interval = n.cfg.MaxRetryInterval
+		}
+	}
+}
+
+// validate checks that the URL uses HTTP(S) and that its host resolves
+// only to the allowed addresses.
+func (n *notifier) validate(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(ErrInvalidURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidURL
+	}
+	if n.cfg.AllowPrivate {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return errors.Wrap(ErrInvalidURL, err)
+	}
+	for _, addr := range addrs {
+		if forbidden(addr.IP) {
+			return ErrForbiddenHost
+		}
+	}
+
+	return nil
+}
+
+func (n *notifier) control(_, address string, _ syscall.RawConn) error {
+	if n.cfg.AllowPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || forbidden(ip) {
+		return ErrForbiddenHost
+	}
+
+	return nil
+}
+
+func forbidden(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast()
+}
+
+// post sends the request and reports whether a failed request may be retried.
+func (n *notifier) post(ctx context.Context, rawURL string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	switch {
+	case res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices:
+		return false, nil
+	case res.StatusCode == http.StatusTooManyRequests, res.StatusCode >= http.StatusInternalServerError:
+		return true, errors.Wrap(errStatus, fmt.Errorf("status %d", res.StatusCode))
+	default:
+		return false, errors.Wrap(errStatus, fmt.Errorf("status %d", res.StatusCode))
+	}
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func encodeSlack(_ *messaging.Message, text string) ([]byte, error) {
+	return json.Marshal(slackMessage{Text: text})
+}
+
+type webhookMessage struct {
+	Channel   string          `json:"channel"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Publisher string          `json:"publisher"`
+	Protocol  string          `json:"protocol"`
+	Created   int64           `json:"created"`
+	Payload   json.RawMessage `json:"payload"`
+	Text      string          `json:"text"`
+}
+
+func encodeJSON(msg *messaging.Message, text string) ([]byte, error) {
+	payload := json.RawMessage(msg.GetPayload())
+	if !json.Valid(payload) {
+		p, err := json.Marshal(string(msg.GetPayload()))
+		if err != nil {
+			return nil, err
+		}
+		payload = p
+	}
+
+	return json.Marshal(webhookMessage{
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Created:   msg.GetCreated(),
+		Payload:   payload,
+		Text:      text,
+	})
+}
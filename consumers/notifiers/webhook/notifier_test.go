@@ -0,0 +1,207 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/magistrala/consumers/notifiers/webhook"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/stretchr/testify/assert"
+)
+
+var cfg = webhook.Config{
+	Timeout:          time.Second,
+	MaxRetries:       2,
+	RetryInterval:    time.Millisecond,
+	MaxRetryInterval: 5 * time.Millisecond,
+	AllowPrivate:     true,
+}
+
+func newMessage() *messaging.Message {
+	return &messaging.Message{
+		Channel:   "channel",
+		Subtopic:  "subtopic",
+		Publisher: "publisher",
+		Protocol:  "http",
+		Payload:   []byte(`{"temperature":21}`),
+	}
+}
+
+func TestSlackNotify(t *testing.T) {
+	var text string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		text = body["text"]
+	}))
+	defer srv.Close()
+
+	n := webhook.NewSlack(cfg, discard.NewCounter())
+	sn := n.(notifiers.SubscriptionNotifier)
+
+	cases := []struct {
+		desc     string
+		template string
+		text     string
+		err      error
+	}{
+		{
+			desc:     "notify with template",
+			template: "{{.Topic}} from {{.Publisher}}: {{.Payload}}",
+			text:     `channel.subtopic from publisher: {"temperature":21}`,
+			err:      nil,
+		},
+		{
+			desc:     "notify with invalid template",
+			template: "{{.Topic",
+			text:     "",
+			err:      notifiers.ErrTemplate,
+		},
+	}
+
+	for _, tc := range cases {
+		text = ""
+		subs := []notifiers.Subscription{{Contact: srv.URL, Template: tc.template}}
+		err := sn.NotifySubscriptions(context.Background(), "", subs, newMessage())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.text, text, fmt.Sprintf("%s: expected text %s got %s\n", tc.desc, tc.text, text))
+	}
+}
+
+func TestWebhookNotify(t *testing.T) {
+	cases := []struct {
+		desc     string
+		statuses []int
+		attempts int32
+		err      error
+	}{
+		{
+			desc:     "notify successfully",
+			statuses: []int{http.StatusOK},
+			attempts: 1,
+			err:      nil,
+		},
+		{
+			desc:     "notify after retry",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			attempts: 2,
+			err:      nil,
+		},
+		{
+			desc:     "notify with retries exhausted",
+			statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			attempts: 3,
+			err:      webhook.ErrDelivery,
+		},
+		{
+			desc:     "notify with client error",
+			statuses: []int{http.StatusBadRequest},
+			attempts: 1,
+			err:      webhook.ErrDelivery,
+		},
+	}
+
+	for _, tc := range cases {
+		var attempts int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			i := atomic.AddInt32(&attempts, 1) - 1
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "channel", body["channel"], fmt.Sprintf("%s: unexpected channel", tc.desc))
+			w.WriteHeader(tc.statuses[i])
+		}))
+
+		n := webhook.New(cfg, discard.NewCounter())
+		err := n.Notify("", []string{srv.URL}, newMessage())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.attempts, atomic.LoadInt32(&attempts), fmt.Sprintf("%s: expected %d attempts got %d\n", tc.desc, tc.attempts, attempts))
+		srv.Close()
+	}
+}
+
+func TestWebhookURL(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+	}))
+	defer srv.Close()
+
+	restricted := cfg
+	restricted.AllowPrivate = false
+
+	cases := []struct {
+		desc string
+		cfg  webhook.Config
+		url  string
+		err  error
+	}{
+		{
+			desc: "notify URL with invalid scheme",
+			cfg:  cfg,
+			url:  "file:///etc/passwd",
+			err:  webhook.ErrInvalidURL,
+		},
+		{
+			desc: "notify URL without host",
+			cfg:  cfg,
+			url:  "http://",
+			err:  webhook.ErrInvalidURL,
+		},
+		{
+			desc: "notify loopback URL",
+			cfg:  restricted,
+			url:  srv.URL,
+			err:  webhook.ErrForbiddenHost,
+		},
+		{
+			desc: "notify private URL",
+			cfg:  restricted,
+			url:  "http://10.0.0.1/hook",
+			err:  webhook.ErrForbiddenHost,
+		},
+		{
+			desc: "notify link-local URL",
+			cfg:  restricted,
+			url:  "http://169.254.169.254/latest/meta-data",
+			err:  webhook.ErrForbiddenHost,
+		},
+	}
+
+	for _, tc := range cases {
+		n := webhook.New(tc.cfg, discard.NewCounter())
+		err := n.Notify("", []string{tc.url}, newMessage())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&attempts), fmt.Sprintf("expected no requests got %d\n", attempts))
+}
+
+func TestWebhookNotifyCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	slow := cfg
+	slow.RetryInterval = time.Hour
+	slow.MaxRetryInterval = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	n := webhook.New(slow, discard.NewCounter())
+	sn := n.(notifiers.SubscriptionNotifier)
+	err := sn.NotifySubscriptions(ctx, "", []notifiers.Subscription{{Contact: srv.URL}}, newMessage())
+	assert.True(t, errors.Contains(err, webhook.ErrDelivery), fmt.Sprintf("expected %s got %s\n", webhook.ErrDelivery, err))
+}
@@ -0,0 +1,186 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package writers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	statusDeadLettered = "dead_lettered"
+	statusDropped      = "dropped"
+	statusRetried      = "retried"
+
+	deadLetterProtocol = "dead-letter"
+)
+
+var (
+	// ErrDeadLetter indicates that an unwriteable message could not be dead-lettered.
+	ErrDeadLetter = errors.New("failed to publish message to dead-letter subject")
+
+	// ErrMalformedMessage indicates that the message can't be written because
+	// of its content, so writing it again can't succeed.
+	ErrMalformedMessage = errors.New("malformed message")
+
+	// The SenML transformer errors are not exported, but the errors are
+	// compared by their messages.
+	errDecodeSenML    = errors.New("failed to decode senml")
+	errNormalizeSenML = errors.New("failed to normalize senml")
+
+	// permanentErrs are the errors which are dead-lettered, since the message
+	// can't be written regardless of how many times it's redelivered.
+	permanentErrs = []error{
+		ErrMalformedMessage,
+		errDecodeSenML,
+		errNormalizeSenML,
+		smqjson.ErrTransform,
+		smqjson.ErrInvalidKey,
+		smqjson.ErrInvalidTimeField,
+	}
+)
+
+var (
+	_ messaging.Subscriber     = (*deadLetter)(nil)
+	_ messaging.MessageHandler = (*deadLetterHandler)(nil)
+)
+
+// DeadLetter represents the message published to the dead-letter subject
+// for each message that the writer failed to persist.
+type DeadLetter struct {
+	Error     string          `json:"error"`
+	Channel   string          `json:"channel"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Publisher string          `json:"publisher"`
+	Protocol  string          `json:"protocol"`
+	Created   int64           `json:"created"`
+	Failed    int64           `json:"failed"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type deadLetter struct {
+	messaging.Subscriber
+	pub     messaging.Publisher
+	subject string
+	counter metrics.Counter
+	logger  *slog.Logger
+}
+
+// NewDeadLetter wraps the subscriber so that messages which fail to be
+// transformed or persisted are counted and, if the subject is not empty and
+// the failure is permanent, republished to the dead-letter subject together
+// with the error. Transient failures, such as database outages, are returned
+// to the broker so the message is redelivered. Successfully written messages
+// are handled unchanged.
+func NewDeadLetter(sub messaging.Subscriber, pub messaging.Publisher, subject string, counter metrics.Counter, logger *slog.Logger) messaging.Subscriber {
+	return &deadLetter{
+		Subscriber: sub,
+		pub:        pub,
+		subject:    subject,
+		counter:    counter,
+		logger:     logger,
+	}
+}
+
+func (dl *deadLetter) Subscribe(ctx context.Context, cfg messaging.SubscriberConfig) error {
+	cfg.Handler = &deadLetterHandler{
+		ctx:     ctx,
+		handler: cfg.Handler,
+		dl:      dl,
+	}
+
+	return dl.Subscriber.Subscribe(ctx, cfg)
+}
+
+type deadLetterHandler struct {
+	ctx     context.Context
+	handler messaging.MessageHandler
+	dl      *deadLetter
+}
+
+func (h *deadLetterHandler) Handle(msg *messaging.Message) error {
+	err := h.handler.Handle(msg)
+	if err == nil {
+		return nil
+	}
+	if !permanent(err) {
+		h.dl.counter.With("status", statusRetried).Add(1)
+		return err
+	}
+	// Never dead-letter messages coming from the dead-letter subject to avoid loops.
+	if h.dl.subject == "" || msg.GetChannel() == h.dl.subject {
+		h.dl.counter.With("status", statusDropped).Add(1)
+		return err
+	}
+	if pubErr := h.dl.publish(h.ctx, msg, err); pubErr != nil {
+		h.dl.counter.With("status", statusDropped).Add(1)
+		h.dl.logger.Warn("Failed to publish message to dead-letter subject",
+			slog.String("subject", h.dl.subject),
+			slog.Any("error", pubErr),
+		)
+		return err
+	}
+	h.dl.counter.With("status", statusDeadLettered).Add(1)
+
+	return nil
+}
+
+func (h *deadLetterHandler) Cancel() error {
+	return h.handler.Cancel()
+}
+
+func permanent(err error) bool {
+	for _, e := range permanentErrs {
+		if errors.Contains(err, e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (dl *deadLetter) publish(ctx context.Context, msg *messaging.Message, cause error) error {
+	payload := json.RawMessage(msg.GetPayload())
+	if !json.Valid(payload) {
+		// Invalid JSON payload is kept as its (base64 encoded) raw bytes.
+		p, err := json.Marshal(msg.GetPayload())
+		if err != nil {
+			return errors.Wrap(ErrDeadLetter, err)
+		}
+		payload = p
+	}
+	data, err := json.Marshal(DeadLetter{
+		Error:     cause.Error(),
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Created:   msg.GetCreated(),
+		Failed:    time.Now().UnixNano(),
+		Payload:   payload,
+	})
+	if err != nil {
+		return errors.Wrap(ErrDeadLetter, err)
+	}
+
+	m := &messaging.Message{
+		Channel:   dl.subject,
+		Publisher: msg.GetPublisher(),
+		Protocol:  deadLetterProtocol,
+		Created:   time.Now().UnixNano(),
+		Payload:   data,
+	}
+	if err := dl.pub.Publish(ctx, dl.subject, m); err != nil {
+		return errors.Wrap(ErrDeadLetter, err)
+	}
+
+	return nil
+}
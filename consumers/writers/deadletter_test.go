@@ -0,0 +1,186 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package writers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/consumers/writers"
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const subject = "dead-letter"
+
+var (
+	errWrite     = errors.New("failed to write message")
+	errMalformed = errors.Wrap(writers.ErrMalformedMessage, errors.New("invalid value"))
+	errPublish   = errors.New("failed to publish message")
+)
+
+type handler struct {
+	err error
+}
+
+func (h handler) Handle(msg *messaging.Message) error {
+	return h.err
+}
+
+func (h handler) Cancel() error {
+	return nil
+}
+
+type subscriber struct {
+	messaging.Subscriber
+	handler messaging.MessageHandler
+}
+
+func (s *subscriber) Subscribe(ctx context.Context, cfg messaging.SubscriberConfig) error {
+	s.handler = cfg.Handler
+	return nil
+}
+
+type publisher struct {
+	messaging.Publisher
+	topic string
+	msg   *messaging.Message
+	err   error
+}
+
+func (p *publisher) Publish(ctx context.Context, topic string, msg *messaging.Message) error {
+	p.topic = topic
+	p.msg = msg
+	return p.err
+}
+
+// counter sums the values added to the counters of all the label values,
+// since the generic counter doesn't propagate them.
+type counter struct {
+	value *float64
+}
+
+func (c counter) With(labelValues ...string) metrics.Counter {
+	return c
+}
+
+func (c counter) Add(delta float64) {
+	*c.value += delta
+}
+
+func TestDeadLetter(t *testing.T) {
+	msg := &messaging.Message{
+		Channel:   "channel",
+		Publisher: "publisher",
+		Protocol:  "http",
+		Payload:   []byte(`{"n":"temperature","v":21}`),
+	}
+
+	cases := []struct {
+		desc      string
+		subject   string
+		msg       *messaging.Message
+		writeErr  error
+		pubErr    error
+		err       error
+		published bool
+		failed    float64
+	}{
+		{
+			desc:      "handle successfully written message",
+			subject:   subject,
+			msg:       msg,
+			writeErr:  nil,
+			err:       nil,
+			published: false,
+			failed:    0,
+		},
+		{
+			desc:      "dead-letter message that failed to be written",
+			subject:   subject,
+			msg:       msg,
+			writeErr:  errMalformed,
+			err:       nil,
+			published: true,
+			failed:    1,
+		},
+		{
+			desc:      "dead-letter message that failed to be transformed",
+			subject:   subject,
+			msg:       msg,
+			writeErr:  errors.Wrap(smqjson.ErrTransform, errors.New("invalid character")),
+			err:       nil,
+			published: true,
+			failed:    1,
+		},
+		{
+			desc:      "redeliver message that failed to be written with transient error",
+			subject:   subject,
+			msg:       msg,
+			writeErr:  errWrite,
+			err:       errWrite,
+			published: false,
+			failed:    1,
+		},
+		{
+			desc:      "drop message with dead-letter disabled",
+			subject:   "",
+			msg:       msg,
+			writeErr:  errMalformed,
+			err:       writers.ErrMalformedMessage,
+			published: false,
+			failed:    1,
+		},
+		{
+			desc:      "drop message with failed dead-letter publish",
+			subject:   subject,
+			msg:       msg,
+			writeErr:  errMalformed,
+			pubErr:    errPublish,
+			err:       writers.ErrMalformedMessage,
+			published: true,
+			failed:    1,
+		},
+		{
+			desc:      "drop message from dead-letter subject",
+			subject:   subject,
+			msg:       &messaging.Message{Channel: subject},
+			writeErr:  errMalformed,
+			err:       writers.ErrMalformedMessage,
+			published: false,
+			failed:    1,
+		},
+	}
+
+	for _, tc := range cases {
+		sub := &subscriber{}
+		pub := &publisher{err: tc.pubErr}
+		failed := counter{value: new(float64)}
+		dl := writers.NewDeadLetter(sub, pub, tc.subject, failed, smqlog.NewMock())
+
+		err := dl.Subscribe(context.Background(), messaging.SubscriberConfig{Handler: handler{err: tc.writeErr}})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected subscribe error: %s", tc.desc, err))
+
+		err = sub.handler.Handle(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.published, pub.msg != nil, fmt.Sprintf("%s: expected published %t got %t\n", tc.desc, tc.published, pub.msg != nil))
+		assert.Equal(t, tc.failed, *failed.value, fmt.Sprintf("%s: expected %v failed writes got %v\n", tc.desc, tc.failed, *failed.value))
+		if tc.published && tc.pubErr == nil {
+			var dlMsg writers.DeadLetter
+			err := json.Unmarshal(pub.msg.GetPayload(), &dlMsg)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected decode error: %s", tc.desc, err))
+			assert.Equal(t, subject, pub.topic, fmt.Sprintf("%s: expected topic %s got %s\n", tc.desc, subject, pub.topic))
+			assert.Equal(t, tc.writeErr.Error(), dlMsg.Error, fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.writeErr, dlMsg.Error))
+			assert.Equal(t, tc.msg.GetChannel(), dlMsg.Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, tc.msg.GetChannel(), dlMsg.Channel))
+			assert.JSONEq(t, string(tc.msg.GetPayload()), string(dlMsg.Payload), fmt.Sprintf("%s: unexpected payload\n", tc.desc))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package writers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const (
+	// maxTableLen is the maximal length of the table name, as limited by
+	// PostgreSQL identifiers.
+	maxTableLen = 63
+	// hashLen is the length of the hash suffix of truncated table names.
+	hashLen = 8
+)
+
+// Naming is the strategy which determines the table (measurement) the
+// messages are written to.
+type Naming string
+
+const (
+	// SingleNaming writes all the messages of the same content type to a
+	// single table.
+	SingleNaming Naming = "single"
+	// ChannelNaming writes messages to a separate table per channel.
+	ChannelNaming Naming = "channel"
+	// SubtopicNaming writes messages to a separate table per subtopic.
+	// Messages without subtopic are written to the single table.
+	SubtopicNaming Naming = "subtopic"
+)
+
+// ErrInvalidNaming indicates unknown table naming strategy.
+var ErrInvalidNaming = errors.New("invalid table naming strategy")
+
+// ParseNaming returns the naming strategy of the given name. Empty name
+// selects the single table strategy.
+func ParseNaming(name string) (Naming, error) {
+	switch n := Naming(name); n {
+	case "":
+		return SingleNaming, nil
+	case SingleNaming, ChannelNaming, SubtopicNaming:
+		return n, nil
+	default:
+		return "", ErrInvalidNaming
+	}
+}
+
+// Table returns the name of the table the message of the given channel and
+// subtopic is written to and read from, where table is the name of the single
+// table of the message content type. Stream tables are named
+// "<table>_<stream>", where the stream is the channel or the subtopic and
+// characters other than letters and digits are replaced with underscores.
+// Names longer than 63 characters are truncated and suffixed with the hash of
+// the full name, so that distinct streams don't share the table.
+func (n Naming) Table(table, channel, subtopic string) string {
+	var stream string
+	switch n {
+	case ChannelNaming:
+		stream = channel
+	case SubtopicNaming:
+		stream = subtopic
+	}
+	if stream == "" {
+		return table
+	}
+
+	name := table + "_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '_'
+		}
+	}, stream)
+	if len(name) > maxTableLen {
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		name = fmt.Sprintf("%s_%0*x", name[:maxTableLen-hashLen-1], hashLen, h.Sum32())
+	}
+
+	return name
+}
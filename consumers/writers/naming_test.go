@@ -0,0 +1,147 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package writers_test
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/consumers/writers"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNaming(t *testing.T) {
+	cases := []struct {
+		desc   string
+		name   string
+		naming writers.Naming
+		err    error
+	}{
+		{
+			desc:   "parse empty naming",
+			name:   "",
+			naming: writers.SingleNaming,
+		},
+		{
+			desc:   "parse single naming",
+			name:   "single",
+			naming: writers.SingleNaming,
+		},
+		{
+			desc:   "parse channel naming",
+			name:   "channel",
+			naming: writers.ChannelNaming,
+		},
+		{
+			desc:   "parse subtopic naming",
+			name:   "subtopic",
+			naming: writers.SubtopicNaming,
+		},
+		{
+			desc: "parse invalid naming",
+			name: "publisher",
+			err:  writers.ErrInvalidNaming,
+		},
+	}
+
+	for _, tc := range cases {
+		naming, err := writers.ParseNaming(tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.naming, naming, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.naming, naming))
+	}
+}
+
+func TestNamingTable(t *testing.T) {
+	channel := "8B3E7E6C-5e4a-4c53-8b0a-2f5c1e0d9a11"
+	long := strings.Repeat("a", 100)
+
+	cases := []struct {
+		desc     string
+		naming   writers.Naming
+		table    string
+		channel  string
+		subtopic string
+		expected string
+	}{
+		{
+			desc:     "name table of single naming",
+			naming:   writers.SingleNaming,
+			table:    "messages",
+			channel:  channel,
+			subtopic: "temperature",
+			expected: "messages",
+		},
+		{
+			desc:     "name table of channel naming",
+			naming:   writers.ChannelNaming,
+			table:    "messages",
+			channel:  channel,
+			expected: "messages_8b3e7e6c_5e4a_4c53_8b0a_2f5c1e0d9a11",
+		},
+		{
+			desc:     "name table of channel naming without channel",
+			naming:   writers.ChannelNaming,
+			table:    "some_json",
+			channel:  "",
+			expected: "some_json",
+		},
+		{
+			desc:     "name table of long channel",
+			naming:   writers.ChannelNaming,
+			table:    "messages",
+			channel:  long,
+			expected: "messages_" + long[:45] + "_" + hash("messages_"+long),
+		},
+		{
+			desc:     "name table of subtopic naming",
+			naming:   writers.SubtopicNaming,
+			table:    "some_json",
+			channel:  channel,
+			subtopic: "Building.Floor-1",
+			expected: "some_json_building_floor_1",
+		},
+		{
+			desc:     "name table of subtopic naming without subtopic",
+			naming:   writers.SubtopicNaming,
+			table:    "messages",
+			channel:  channel,
+			subtopic: "",
+			expected: "messages",
+		},
+		{
+			desc:     "name table of long subtopic",
+			naming:   writers.SubtopicNaming,
+			table:    "messages",
+			channel:  channel,
+			subtopic: long,
+			expected: "messages_" + long[:45] + "_" + hash("messages_"+long),
+		},
+	}
+
+	for _, tc := range cases {
+		table := tc.naming.Table(tc.table, tc.channel, tc.subtopic)
+		assert.Equal(t, tc.expected, table, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.expected, table))
+		assert.LessOrEqual(t, len(table), 63, fmt.Sprintf("%s: expected table name of at most 63 characters got %d\n", tc.desc, len(table)))
+	}
+}
+
+func TestNamingTableCollision(t *testing.T) {
+	prefix := strings.Repeat("a", 60)
+	first := writers.ChannelNaming.Table("messages", prefix+"first", "")
+	second := writers.ChannelNaming.Table("messages", prefix+"second", "")
+	assert.NotEqual(t, first, second, fmt.Sprintf("expected distinct tables of long channels got %s\n", first))
+
+	first = writers.SubtopicNaming.Table("messages", "", prefix+"first")
+	second = writers.SubtopicNaming.Table("messages", "", prefix+"second")
+	assert.NotEqual(t, first, second, fmt.Sprintf("expected distinct tables of long subtopics got %s\n", first))
+}
+
+func hash(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
@@ -7,7 +7,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 
+	"github.com/absmach/magistrala/consumers/writers"
 	"github.com/absmach/supermq/consumers"
 	"github.com/absmach/supermq/pkg/errors"
 	smqjson "github.com/absmach/supermq/pkg/transformers/json"
@@ -25,15 +27,24 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
+const defTable = "messages"
+
 var _ consumers.BlockingConsumer = (*postgresRepo)(nil)
 
 type postgresRepo struct {
-	db *sqlx.DB
+	db     *sqlx.DB
+	naming writers.Naming
+	tables *sync.Map
 }
 
 // New returns new PostgreSQL writer.
-func New(db *sqlx.DB) consumers.BlockingConsumer {
-	return &postgresRepo{db: db}
+// Messages are written to the tables determined by the naming strategy.
+func New(db *sqlx.DB, naming writers.Naming) consumers.BlockingConsumer {
+	return &postgresRepo{
+		db:     db,
+		naming: naming,
+		tables: &sync.Map{},
+	}
 }
 
 func (pr postgresRepo) ConsumeBlocking(ctx context.Context, message interface{}) (err error) {
@@ -50,7 +61,7 @@ func (pr postgresRepo) saveSenml(ctx context.Context, messages interface{}) (err
 	if !ok {
 		return errSaveMessage
 	}
-	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
+	q := `INSERT INTO %s (id, channel, subtopic, publisher, protocol,
           name, unit, value, string_value, bool_value, data_value, sum,
           time, update_time)
           VALUES (:id, :channel, :subtopic, :publisher, :protocol, :name, :unit,
@@ -79,12 +90,19 @@ func (pr postgresRepo) saveSenml(ctx context.Context, messages interface{}) (err
 		if err != nil {
 			return err
 		}
+		table := pr.naming.Table(defTable, msg.Channel, msg.Subtopic)
+		if err := pr.createSenmlTable(ctx, table); err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
 		m := senmlMessage{Message: msg, ID: id.String()}
-		if _, err := tx.NamedExec(q, m); err != nil {
+		if _, err := tx.NamedExec(fmt.Sprintf(q, table), m); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				if pgErr.Code == pgerrcode.InvalidTextRepresentation {
-					return errors.Wrap(errSaveMessage, errInvalidMessage)
+					return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, errInvalidMessage))
+				}
+				if malformed(pgErr) {
+					return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, err))
 				}
 			}
 
@@ -95,19 +113,33 @@ func (pr postgresRepo) saveSenml(ctx context.Context, messages interface{}) (err
 }
 
 func (pr postgresRepo) saveJSON(ctx context.Context, msgs smqjson.Messages) error {
-	if err := pr.insertJSON(ctx, msgs); err != nil {
+	tables := make(map[string][]smqjson.Message)
+	for _, m := range msgs.Data {
+		table := pr.naming.Table(msgs.Format, m.Channel, m.Subtopic)
+		tables[table] = append(tables[table], m)
+	}
+	for table, data := range tables {
+		if err := pr.saveJSONTable(ctx, table, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pr postgresRepo) saveJSONTable(ctx context.Context, table string, msgs []smqjson.Message) error {
+	if err := pr.insertJSON(ctx, table, msgs); err != nil {
 		if err == errNoTable {
-			if err := pr.createTable(msgs.Format); err != nil {
+			if err := pr.createTable(table); err != nil {
 				return err
 			}
-			return pr.insertJSON(ctx, msgs)
+			return pr.insertJSON(ctx, table, msgs)
 		}
 		return err
 	}
 	return nil
 }
 
-func (pr postgresRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) error {
+func (pr postgresRepo) insertJSON(ctx context.Context, table string, msgs []smqjson.Message) error {
 	tx, err := pr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(errSaveMessage, err)
@@ -127,23 +159,25 @@ func (pr postgresRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) er
 
 	q := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload)
           VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload);`
-	q = fmt.Sprintf(q, msgs.Format)
+	q = fmt.Sprintf(q, table)
 
-	for _, m := range msgs.Data {
+	for _, m := range msgs {
 		var dbmsg jsonMessage
 		dbmsg, err = toJSONMessage(m)
 		if err != nil {
-			return errors.Wrap(errSaveMessage, err)
+			return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, err))
 		}
 
 		if _, err = tx.NamedExec(q, dbmsg); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
-				switch pgErr.Code {
-				case pgerrcode.InvalidTextRepresentation:
-					return errors.Wrap(errSaveMessage, errInvalidMessage)
-				case pgerrcode.UndefinedTable:
+				switch {
+				case pgErr.Code == pgerrcode.InvalidTextRepresentation:
+					return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, errInvalidMessage))
+				case pgErr.Code == pgerrcode.UndefinedTable:
 					return errNoTable
+				case malformed(pgErr):
+					return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, err))
 				}
 			}
 			return err
@@ -169,6 +203,24 @@ func (pr postgresRepo) createTable(name string) error {
 	return err
 }
 
+// createSenmlTable creates the stream table of SenML messages, with the
+// schema of the single messages table. Each table is created once.
+func (pr postgresRepo) createSenmlTable(ctx context.Context, table string) error {
+	if table == defTable {
+		return nil
+	}
+	if _, ok := pr.tables.Load(table); ok {
+		return nil
+	}
+	q := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (LIKE %s INCLUDING ALL)`, table, defTable)
+	if _, err := pr.db.ExecContext(ctx, q); err != nil {
+		return err
+	}
+	pr.tables.Store(table, struct{}{})
+
+	return nil
+}
+
 type senmlMessage struct {
 	senml.Message
 	ID string `db:"id"`
@@ -184,6 +236,13 @@ type jsonMessage struct {
 	Payload   []byte `db:"payload"`
 }
 
+// malformed reports whether the error is caused by the message content, such
+// as invalid values or constraint violations, so retrying the write can't
+// succeed.
+func malformed(pgErr *pgconn.PgError) bool {
+	return pgerrcode.IsDataException(pgErr.Code) || pgerrcode.IsIntegrityConstraintViolation(pgErr.Code)
+}
+
 func toJSONMessage(msg smqjson.Message) (jsonMessage, error) {
 	id, err := uuid.NewV4()
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/absmach/magistrala/consumers/writers"
 	"github.com/absmach/magistrala/consumers/writers/postgres"
 	"github.com/absmach/supermq/pkg/transformers/json"
 	"github.com/absmach/supermq/pkg/transformers/senml"
@@ -31,7 +32,7 @@ var (
 )
 
 func TestSaveSenml(t *testing.T) {
-	repo := postgres.New(db)
+	repo := postgres.New(db, writers.SingleNaming)
 
 	chid, err := uuid.NewV4()
 	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -71,8 +72,70 @@ func TestSaveSenml(t *testing.T) {
 	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
 }
 
+func TestSaveSenmlByChannel(t *testing.T) {
+	repo := postgres.New(db, writers.ChannelNaming)
+
+	chid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var msgs []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Subtopic:  subtopic,
+			Value:     &v,
+			Time:      float64(now + int64(i)),
+		}
+		msgs = append(msgs, msg)
+	}
+
+	err = repo.ConsumeBlocking(context.TODO(), msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	table := writers.ChannelNaming.Table("messages", chid.String(), subtopic)
+	var count int
+	err = db.Get(&count, fmt.Sprintf("SELECT COUNT(*) FROM %s", table))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	assert.Equal(t, msgsNum, count, fmt.Sprintf("expected %d messages in %s got %d\n", msgsNum, table, count))
+}
+
+func TestSaveSenmlBySubtopic(t *testing.T) {
+	repo := postgres.New(db, writers.SubtopicNaming)
+
+	chid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var msgs []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Subtopic:  subtopic,
+			Value:     &v,
+			Time:      float64(now + int64(i)),
+		}
+		msgs = append(msgs, msg)
+	}
+
+	err = repo.ConsumeBlocking(context.TODO(), msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	table := writers.SubtopicNaming.Table("messages", chid.String(), subtopic)
+	var count int
+	err = db.Get(&count, fmt.Sprintf("SELECT COUNT(*) FROM %s", table))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	assert.Equal(t, msgsNum, count, fmt.Sprintf("expected %d messages in %s got %d\n", msgsNum, table, count))
+}
+
 func TestSaveJSON(t *testing.T) {
-	repo := postgres.New(db)
+	repo := postgres.New(db, writers.SingleNaming)
 
 	chid, err := uuid.NewV4()
 	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -7,7 +7,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 
+	"github.com/absmach/magistrala/consumers/writers"
 	"github.com/absmach/supermq/consumers"
 	"github.com/absmach/supermq/pkg/errors"
 	smqjson "github.com/absmach/supermq/pkg/transformers/json"
@@ -24,15 +26,24 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
+const defTable = "messages"
+
 var _ consumers.BlockingConsumer = (*timescaleRepo)(nil)
 
 type timescaleRepo struct {
-	db *sqlx.DB
+	db     *sqlx.DB
+	naming writers.Naming
+	tables *sync.Map
 }
 
 // New returns new TimescaleSQL writer.
-func New(db *sqlx.DB) consumers.BlockingConsumer {
-	return &timescaleRepo{db: db}
+// Messages are written to the tables determined by the naming strategy.
+func New(db *sqlx.DB, naming writers.Naming) consumers.BlockingConsumer {
+	return &timescaleRepo{
+		db:     db,
+		naming: naming,
+		tables: &sync.Map{},
+	}
 }
 
 func (tr *timescaleRepo) ConsumeBlocking(ctx context.Context, message interface{}) (err error) {
@@ -49,7 +60,7 @@ func (tr timescaleRepo) saveSenml(ctx context.Context, messages interface{}) (er
 	if !ok {
 		return errSaveMessage
 	}
-	q := `INSERT INTO messages (channel, subtopic, publisher, protocol,
+	q := `INSERT INTO %s (channel, subtopic, publisher, protocol,
           name, unit, value, string_value, bool_value, data_value, sum,
           time, update_time)
           VALUES (:channel, :subtopic, :publisher, :protocol, :name, :unit,
@@ -74,12 +85,19 @@ func (tr timescaleRepo) saveSenml(ctx context.Context, messages interface{}) (er
 	}()
 
 	for _, msg := range msgs {
+		table := tr.naming.Table(defTable, msg.Channel, msg.Subtopic)
+		if err := tr.createSenmlTable(ctx, table); err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
 		m := senmlMessage{Message: msg}
-		if _, err := tx.NamedExec(q, m); err != nil {
+		if _, err := tx.NamedExec(fmt.Sprintf(q, table), m); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				if pgErr.Code == pgerrcode.InvalidTextRepresentation {
-					return errors.Wrap(errSaveMessage, errInvalidMessage)
+					return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, errInvalidMessage))
+				}
+				if malformed(pgErr) {
+					return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, err))
 				}
 			}
 
@@ -90,19 +108,33 @@ func (tr timescaleRepo) saveSenml(ctx context.Context, messages interface{}) (er
 }
 
 func (tr timescaleRepo) saveJSON(ctx context.Context, msgs smqjson.Messages) error {
-	if err := tr.insertJSON(ctx, msgs); err != nil {
+	tables := make(map[string][]smqjson.Message)
+	for _, m := range msgs.Data {
+		table := tr.naming.Table(msgs.Format, m.Channel, m.Subtopic)
+		tables[table] = append(tables[table], m)
+	}
+	for table, data := range tables {
+		if err := tr.saveJSONTable(ctx, table, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tr timescaleRepo) saveJSONTable(ctx context.Context, table string, msgs []smqjson.Message) error {
+	if err := tr.insertJSON(ctx, table, msgs); err != nil {
 		if err == errNoTable {
-			if err := tr.createTable(msgs.Format); err != nil {
+			if err := tr.createTable(table); err != nil {
 				return err
 			}
-			return tr.insertJSON(ctx, msgs)
+			return tr.insertJSON(ctx, table, msgs)
 		}
 		return err
 	}
 	return nil
 }
 
-func (tr timescaleRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) error {
+func (tr timescaleRepo) insertJSON(ctx context.Context, table string, msgs []smqjson.Message) error {
 	tx, err := tr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(errSaveMessage, err)
@@ -122,22 +154,24 @@ func (tr timescaleRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) e
 
 	q := `INSERT INTO %s (channel, created, subtopic, publisher, protocol, payload)
           VALUES (:channel, :created, :subtopic, :publisher, :protocol, :payload);`
-	q = fmt.Sprintf(q, msgs.Format)
+	q = fmt.Sprintf(q, table)
 
-	for _, m := range msgs.Data {
+	for _, m := range msgs {
 		var dbmsg jsonMessage
 		dbmsg, err = toJSONMessage(m)
 		if err != nil {
-			return errors.Wrap(errSaveMessage, err)
+			return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, err))
 		}
 		if _, err = tx.NamedExec(q, dbmsg); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
-				switch pgErr.Code {
-				case pgerrcode.InvalidTextRepresentation:
-					return errors.Wrap(errSaveMessage, errInvalidMessage)
-				case pgerrcode.UndefinedTable:
+				switch {
+				case pgErr.Code == pgerrcode.InvalidTextRepresentation:
+					return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, errInvalidMessage))
+				case pgErr.Code == pgerrcode.UndefinedTable:
 					return errNoTable
+				case malformed(pgErr):
+					return errors.Wrap(errSaveMessage, errors.Wrap(writers.ErrMalformedMessage, err))
 				}
 			}
 			return err
@@ -162,6 +196,26 @@ func (tr timescaleRepo) createTable(name string) error {
 	return err
 }
 
+// createSenmlTable creates the stream hypertable of SenML messages, with
+// the schema of the single messages table. Each table is created once.
+func (tr timescaleRepo) createSenmlTable(ctx context.Context, table string) error {
+	if table == defTable {
+		return nil
+	}
+	if _, ok := tr.tables.Load(table); ok {
+		return nil
+	}
+	q := `CREATE TABLE IF NOT EXISTS %s (LIKE %s INCLUDING ALL);
+          SELECT create_hypertable('%s', 'time', create_default_indexes => FALSE, chunk_time_interval => 86400000, if_not_exists => TRUE);`
+	q = fmt.Sprintf(q, table, defTable, table)
+	if _, err := tr.db.ExecContext(ctx, q); err != nil {
+		return err
+	}
+	tr.tables.Store(table, struct{}{})
+
+	return nil
+}
+
 type senmlMessage struct {
 	senml.Message
 }
@@ -175,6 +229,13 @@ type jsonMessage struct {
 	Payload   []byte `db:"payload"`
 }
 
+// malformed reports whether the error is caused by the message content, such
+// as invalid values or constraint violations, so retrying the write can't
+// succeed.
+func malformed(pgErr *pgconn.PgError) bool {
+	return pgerrcode.IsDataException(pgErr.Code) || pgerrcode.IsIntegrityConstraintViolation(pgErr.Code)
+}
+
 func toJSONMessage(msg smqjson.Message) (jsonMessage, error) {
 	data := []byte("{}")
 	if msg.Payload != nil {
@@ -45,6 +45,7 @@ require (
 	github.com/Azure/go-ansiterm v0.0.0-20230124172434-306776ec8161 // indirect
 	github.com/Microsoft/go-winio v0.6.2 // indirect
 	github.com/Nvveen/Gotty v0.0.0-20120604004816-cd527374f1e5 // indirect
+	github.com/VividCortex/gohistogram v1.0.0 // indirect
 	github.com/absmach/certs v0.0.0-20241209153600-91270de67b5a // indirect
 	github.com/absmach/senml v1.0.6 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
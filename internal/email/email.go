@@ -6,12 +6,10 @@ package email
 import (
 	"bytes"
 	"net/mail"
-	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/absmach/magistrala/pkg/errors"
-	"gopkg.in/gomail.v2"
 )
 
 var (
@@ -35,32 +33,36 @@ type email struct {
 
 // Config email agent configuration.
 type Config struct {
-	Host        string `env:"MG_EMAIL_HOST"         envDefault:"localhost"`
-	Port        string `env:"MG_EMAIL_PORT"         envDefault:"25"`
-	Username    string `env:"MG_EMAIL_USERNAME"     envDefault:"root"`
-	Password    string `env:"MG_EMAIL_PASSWORD"     envDefault:""`
-	FromAddress string `env:"MG_EMAIL_FROM_ADDRESS" envDefault:""`
-	FromName    string `env:"MG_EMAIL_FROM_NAME"    envDefault:""`
-	Template    string `env:"MG_EMAIL_TEMPLATE"     envDefault:"email.tmpl"`
+	Provider           string `env:"MG_EMAIL_PROVIDER"              envDefault:"smtp"`
+	Host               string `env:"MG_EMAIL_HOST"                  envDefault:"localhost"`
+	Port               string `env:"MG_EMAIL_PORT"                  envDefault:"25"`
+	Username           string `env:"MG_EMAIL_USERNAME"              envDefault:"root"`
+	Password           string `env:"MG_EMAIL_PASSWORD"              envDefault:""`
+	FromAddress        string `env:"MG_EMAIL_FROM_ADDRESS"          envDefault:""`
+	FromName           string `env:"MG_EMAIL_FROM_NAME"             envDefault:""`
+	Template           string `env:"MG_EMAIL_TEMPLATE"              envDefault:"email.tmpl"`
+	SendGridAPIKey     string `env:"MG_EMAIL_SENDGRID_API_KEY"      envDefault:""`
+	SESRegion          string `env:"MG_EMAIL_SES_REGION"            envDefault:"us-east-1"`
+	SESAccessKeyID     string `env:"MG_EMAIL_SES_ACCESS_KEY_ID"     envDefault:""`
+	SESSecretAccessKey string `env:"MG_EMAIL_SES_SECRET_ACCESS_KEY" envDefault:""`
 }
 
 // Agent for mailing.
 type Agent struct {
-	conf *Config
-	tmpl *template.Template
-	dial *gomail.Dialer
+	conf     *Config
+	tmpl     *template.Template
+	provider Provider
 }
 
 // New creates new email agent.
 func New(c *Config) (*Agent, error) {
 	a := &Agent{}
 	a.conf = c
-	port, err := strconv.Atoi(c.Port)
+	p, err := NewProvider(c)
 	if err != nil {
 		return a, err
 	}
-	d := gomail.NewDialer(c.Host, port, c.Username, c.Password)
-	a.dial = d
+	a.provider = p
 
 	tmpl, err := template.ParseFiles(c.Template)
 	if err != nil {
@@ -96,13 +98,7 @@ func (a *Agent) Send(to []string, from, subject, header, user, content, footer s
 		return errors.Wrap(errExecTemplate, err)
 	}
 
-	m := gomail.NewMessage()
-	m.SetHeader("From", e.From)
-	m.SetHeader("To", to...)
-	m.SetHeader("Subject", subject)
-	m.SetBody("text/plain", buff.String())
-
-	if err := a.dial.DialAndSend(m); err != nil {
+	if err := a.provider.Send(e.From, to, subject, buff.String()); err != nil {
 		return errors.Wrap(errSendMail, err)
 	}
 
@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"strconv"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	// ProviderSMTP sends e-mails using the configured SMTP server.
+	ProviderSMTP = "smtp"
+	// ProviderSendGrid sends e-mails using the SendGrid HTTP API.
+	ProviderSendGrid = "sendgrid"
+	// ProviderSES sends e-mails using the AWS SES HTTP API.
+	ProviderSES = "ses"
+)
+
+var errUnknownProvider = errors.New("Unknown e-mail provider")
+
+// Provider represents an API for delivering already rendered e-mails.
+type Provider interface {
+	// Send delivers the e-mail with the given subject and plain text body.
+	Send(from string, to []string, subject, body string) error
+}
+
+// NewProvider creates the e-mail provider selected by the configuration.
+func NewProvider(c *Config) (Provider, error) {
+	switch c.Provider {
+	case ProviderSMTP, "":
+		return newSMTP(c)
+	case ProviderSendGrid:
+		return newSendGrid(c), nil
+	case ProviderSES:
+		return newSES(c), nil
+	default:
+		return nil, errors.Wrap(errUnknownProvider, errors.New(c.Provider))
+	}
+}
+
+type smtpProvider struct {
+	dial *gomail.Dialer
+}
+
+func newSMTP(c *Config) (Provider, error) {
+	port, err := strconv.Atoi(c.Port)
+	if err != nil {
+		return nil, err
+	}
+
+	return &smtpProvider{dial: gomail.NewDialer(c.Host, port, c.Username, c.Password)}, nil
+}
+
+func (p *smtpProvider) Send(from string, to []string, subject, body string) error {
+	m := gomail.NewMessage()
+	m.SetHeader("From", from)
+	m.SetHeader("To", to...)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/plain", body)
+
+	return p.dial.DialAndSend(m)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	from    = "Magistrala <noreply@example.com>"
+	to      = "user@example.com"
+	subject = "Password reset"
+	body    = "Reset your password"
+)
+
+func TestNewProvider(t *testing.T) {
+	cases := []struct {
+		desc     string
+		provider string
+		err      error
+	}{
+		{desc: "create default provider", provider: "", err: nil},
+		{desc: "create SMTP provider", provider: ProviderSMTP, err: nil},
+		{desc: "create SendGrid provider", provider: ProviderSendGrid, err: nil},
+		{desc: "create SES provider", provider: ProviderSES, err: nil},
+		{desc: "create unknown provider", provider: "unknown", err: errUnknownProvider},
+	}
+
+	for _, tc := range cases {
+		_, err := NewProvider(&Config{Provider: tc.provider, Port: "25"})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSendGridSend(t *testing.T) {
+	var auth string
+	var msg sendGridMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	p := newSendGrid(&Config{SendGridAPIKey: "key"}).(*sendGridProvider)
+	p.url = srv.URL
+
+	err := p.Send(from, []string{to}, subject, body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, "Bearer key", auth)
+	assert.Equal(t, "noreply@example.com", msg.From.Email)
+	assert.Equal(t, "Magistrala", msg.From.Name)
+	assert.Equal(t, to, msg.Personalizations[0].To[0].Email)
+	assert.Equal(t, subject, msg.Subject)
+	assert.Equal(t, body, msg.Content[0].Value)
+}
+
+func TestSESSend(t *testing.T) {
+	var auth, path string
+	var msg sesMessage
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	p := newSES(&Config{SESRegion: "eu-west-1", SESAccessKeyID: "AKID", SESSecretAccessKey: "secret"}).(*sesProvider)
+	p.endpoint = srv.URL
+	p.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	err := p.Send(from, []string{to}, subject, body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, sesPath, path)
+	assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20240102/eu-west-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature="), fmt.Sprintf("unexpected authorization header %s", auth))
+	assert.Equal(t, from, msg.FromEmailAddress)
+	assert.Equal(t, []string{to}, msg.Destination.ToAddresses)
+	assert.Equal(t, body, msg.Content.Simple.Body.Text.Data)
+
+	status = http.StatusBadRequest
+	err = p.Send(from, []string{to}, subject, body)
+	assert.NotNil(t, err, "expected error on bad request status")
+}
@@ -0,0 +1,259 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/go-kit/kit/metrics"
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	statusSent   = "sent"
+	statusRetry  = "retry"
+	statusFailed = "failed"
+
+	// defPopInterval is the initial interval between reads of the store
+	// after a failed read, if the retry interval is not set.
+	defPopInterval = 100 * time.Millisecond
+)
+
+var (
+	errQueueFull = errors.New("E-mail queue is full")
+	errEnqueue   = errors.New("Enqueue e-mail failed")
+)
+
+// Sender represents an API for sending e-mails.
+type Sender interface {
+	// Send sends e-mail with the given content to the provided recipients.
+	Send(to []string, from, subject, header, user, content, footer string) error
+}
+
+var (
+	_ Sender = (*Agent)(nil)
+	_ Sender = (*Queue)(nil)
+)
+
+// Message represents an e-mail pending to be sent.
+type Message struct {
+	ID       string   `json:"id"`
+	To       []string `json:"to"`
+	From     string   `json:"from"`
+	Subject  string   `json:"subject"`
+	Header   string   `json:"header"`
+	User     string   `json:"user"`
+	Content  string   `json:"content"`
+	Footer   string   `json:"footer"`
+	Attempts uint     `json:"attempts"`
+}
+
+// Store represents a storage of pending e-mails.
+type Store interface {
+	// Push stores the pending e-mail.
+	Push(ctx context.Context, msg Message) error
+
+	// Retry stores the pending e-mail which failed to be sent, so that it's
+	// popped again once the due time passes.
+	Retry(ctx context.Context, msg Message, due time.Time) error
+
+	// Pop blocks until a pending e-mail is available and removes it from the store.
+	Pop(ctx context.Context) (Message, error)
+}
+
+// QueueConfig represents e-mail queue configuration.
+type QueueConfig struct {
+	Enabled          bool          `env:"MG_EMAIL_QUEUE_ENABLED"            envDefault:"true"`
+	Size             uint          `env:"MG_EMAIL_QUEUE_SIZE"               envDefault:"1024"`
+	Workers          uint          `env:"MG_EMAIL_QUEUE_WORKERS"            envDefault:"1"`
+	MaxRetries       uint          `env:"MG_EMAIL_QUEUE_MAX_RETRIES"        envDefault:"5"`
+	RetryInterval    time.Duration `env:"MG_EMAIL_QUEUE_RETRY_INTERVAL"     envDefault:"1s"`
+	MaxRetryInterval time.Duration `env:"MG_EMAIL_QUEUE_MAX_RETRY_INTERVAL" envDefault:"1m"`
+	RedisURL         string        `env:"MG_EMAIL_QUEUE_REDIS_URL"          envDefault:""`
+}
+
+// Queue sends e-mails asynchronously. Send returns as soon as the e-mail is
+// queued, while the workers send queued e-mails and retry the failed ones
+// with exponential backoff.
+type Queue struct {
+	ctx     context.Context
+	sender  Sender
+	store   Store
+	cfg     QueueConfig
+	counter metrics.Counter
+	logger  *slog.Logger
+}
+
+// NewQueue creates new e-mail queue and starts its workers. Workers are
+// stopped when the context is canceled.
+func NewQueue(ctx context.Context, sender Sender, store Store, cfg QueueConfig, counter metrics.Counter, logger *slog.Logger) *Queue {
+	q := &Queue{
+		ctx:     ctx,
+		sender:  sender,
+		store:   store,
+		cfg:     cfg,
+		counter: counter,
+		logger:  logger,
+	}
+	workers := cfg.Workers
+	if workers == 0 {
+		workers = 1
+	}
+	for i := uint(0); i < workers; i++ {
+		go q.work()
+	}
+
+	return q
+}
+
+// NewSender creates the e-mail agent. If the queue is enabled, the agent is
+// wrapped with the e-mail queue, so that e-mails are sent asynchronously.
+func NewSender(ctx context.Context, cfg *Config, qcfg QueueConfig, counter metrics.Counter, logger *slog.Logger) (Sender, error) {
+	agent, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !qcfg.Enabled {
+		return agent, nil
+	}
+	store, err := NewStore(qcfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewQueue(ctx, agent, store, qcfg, counter, logger), nil
+}
+
+// Send queues e-mail.
+func (q *Queue) Send(to []string, from, subject, header, user, content, footer string) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(errEnqueue, err)
+	}
+	msg := Message{
+		ID:      id.String(),
+		To:      to,
+		From:    from,
+		Subject: subject,
+		Header:  header,
+		User:    user,
+		Content: content,
+		Footer:  footer,
+	}
+	if err := q.store.Push(q.ctx, msg); err != nil {
+		return errors.Wrap(errEnqueue, err)
+	}
+
+	return nil
+}
+
+func (q *Queue) work() {
+	interval := q.cfg.RetryInterval
+	if interval <= 0 {
+		interval = defPopInterval
+	}
+	wait := interval
+	for {
+		msg, err := q.store.Pop(q.ctx)
+		if err != nil {
+			if q.ctx.Err() != nil {
+				return
+			}
+			q.logger.Warn("Failed to read queued e-mail", slog.Any("error", err), slog.Duration("retry_in", wait))
+			// Back off while the store is unavailable.
+			select {
+			case <-q.ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+			wait *= 2
+			if q.cfg.MaxRetryInterval > 0 && wait > q.cfg.MaxRetryInterval {
+				wait = q.cfg.MaxRetryInterval
+			}
+			continue
+		}
+		wait = interval
+		q.send(msg)
+	}
+}
+
+func (q *Queue) send(msg Message) {
+	err := q.sender.Send(msg.To, msg.From, msg.Subject, msg.Header, msg.User, msg.Content, msg.Footer)
+	if err == nil {
+		q.counter.With("status", statusSent).Add(1)
+		return
+	}
+
+	msg.Attempts++
+	if msg.Attempts > q.cfg.MaxRetries {
+		q.counter.With("status", statusFailed).Add(1)
+		q.logger.Error("Failed to send e-mail after max retries",
+			slog.Any("to", msg.To),
+			slog.String("subject", msg.Subject),
+			slog.Uint64("attempts", uint64(msg.Attempts)),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	q.counter.With("status", statusRetry).Add(1)
+	if err := q.store.Retry(q.ctx, msg, time.Now().Add(q.backoff(msg.Attempts))); err != nil {
+		q.counter.With("status", statusFailed).Add(1)
+		q.logger.Error("Failed to requeue e-mail", slog.String("subject", msg.Subject), slog.Any("error", err))
+	}
+}
+
+func (q *Queue) backoff(attempts uint) time.Duration {
+	d := q.cfg.RetryInterval
+	for i := uint(1); i < attempts; i++ {
+		d *= 2
+		if q.cfg.MaxRetryInterval > 0 && d >= q.cfg.MaxRetryInterval {
+			return q.cfg.MaxRetryInterval
+		}
+	}
+
+	return d
+}
+
+type memoryStore struct {
+	msgs chan Message
+}
+
+// NewMemoryStore creates in-memory store of pending e-mails with the given
+// capacity. Pending e-mails, including the scheduled retries, are lost on
+// restart.
+func NewMemoryStore(size uint) Store {
+	return &memoryStore{msgs: make(chan Message, size)}
+}
+
+func (s *memoryStore) Push(ctx context.Context, msg Message) error {
+	select {
+	case s.msgs <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return errQueueFull
+	}
+}
+
+func (s *memoryStore) Retry(ctx context.Context, msg Message, due time.Time) error {
+	time.AfterFunc(time.Until(due), func() {
+		_ = s.Push(ctx, msg)
+	})
+
+	return nil
+}
+
+func (s *memoryStore) Pop(ctx context.Context) (Message, error) {
+	select {
+	case msg := <-s.msgs:
+		return msg, nil
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
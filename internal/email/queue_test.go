@@ -0,0 +1,178 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package email_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/email"
+	"github.com/absmach/magistrala/pkg/errors"
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+var errSend = errors.New("failed to send")
+
+type sender struct {
+	mu       sync.Mutex
+	failures int
+	max      int
+	attempts int
+	done     chan struct{}
+}
+
+func (s *sender) Send(to []string, from, subject, header, user, content, footer string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts > s.failures {
+		close(s.done)
+		return nil
+	}
+	if s.attempts == s.max {
+		close(s.done)
+	}
+	return errSend
+}
+
+// statusCounter counts the metered attempts regardless of their status.
+type statusCounter struct {
+	mu    sync.Mutex
+	value float64
+}
+
+func (c *statusCounter) With(labelValues ...string) metrics.Counter {
+	return c
+}
+
+func (c *statusCounter) Add(delta float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value += delta
+}
+
+func (c *statusCounter) Value() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value
+}
+
+func TestQueueSend(t *testing.T) {
+	cfg := email.QueueConfig{
+		Workers:          1,
+		MaxRetries:       3,
+		RetryInterval:    time.Millisecond,
+		MaxRetryInterval: 5 * time.Millisecond,
+	}
+
+	cases := []struct {
+		desc     string
+		failures int
+		attempts int
+	}{
+		{
+			desc:     "send successfully",
+			failures: 0,
+			attempts: 1,
+		},
+		{
+			desc:     "send after transient failures",
+			failures: 2,
+			attempts: 3,
+		},
+		{
+			desc:     "send with retries exhausted",
+			failures: 10,
+			attempts: 4,
+		},
+	}
+
+	for _, tc := range cases {
+		ctx, cancel := context.WithCancel(context.Background())
+		s := &sender{failures: tc.failures, max: int(cfg.MaxRetries) + 1, done: make(chan struct{})}
+		counter := &statusCounter{}
+		q := email.NewQueue(ctx, s, email.NewMemoryStore(10), cfg, counter, smqlog.NewMock())
+
+		err := q.Send([]string{"user@example.com"}, "", "subject", "header", "user", "content", "footer")
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		select {
+		case <-s.done:
+		case <-time.After(time.Second):
+			t.Errorf("%s: e-mail was not sent in time", tc.desc)
+		}
+		// Give the queue time to perform any unexpected additional attempt.
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+
+		s.mu.Lock()
+		assert.Equal(t, tc.attempts, s.attempts, fmt.Sprintf("%s: expected %d attempts got %d\n", tc.desc, tc.attempts, s.attempts))
+		s.mu.Unlock()
+		// Each attempt is metered as sent, retry or failed.
+		assert.Equal(t, float64(tc.attempts), counter.Value(), fmt.Sprintf("%s: expected %d metered attempts got %v\n", tc.desc, tc.attempts, counter.Value()))
+	}
+}
+
+func TestQueueFull(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := email.NewMemoryStore(1)
+	err := store.Push(ctx, email.Message{Subject: "first"})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = store.Push(ctx, email.Message{Subject: "second"})
+	assert.NotNil(t, err, "expected error pushing to full store")
+}
+
+// failingStore fails to read the pending e-mails and counts the reads.
+type failingStore struct {
+	email.Store
+	mu    sync.Mutex
+	reads int
+}
+
+func (s *failingStore) Pop(ctx context.Context) (email.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reads++
+	return email.Message{}, errSend
+}
+
+func TestQueueStoreBackoff(t *testing.T) {
+	cfg := email.QueueConfig{
+		Workers:          1,
+		RetryInterval:    10 * time.Millisecond,
+		MaxRetryInterval: 20 * time.Millisecond,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	store := &failingStore{}
+	email.NewQueue(ctx, &sender{}, store, cfg, &statusCounter{}, smqlog.NewMock())
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	assert.LessOrEqual(t, store.reads, 10, fmt.Sprintf("expected at most 10 reads of the failing store got %d\n", store.reads))
+}
+
+func TestMemoryStoreRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := email.NewMemoryStore(1)
+	err := store.Retry(ctx, email.Message{Subject: "retry"}, time.Now().Add(10*time.Millisecond))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	popCtx, popCancel := context.WithTimeout(ctx, time.Second)
+	defer popCancel()
+	msg, err := store.Pop(popCtx)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, "retry", msg.Subject, fmt.Sprintf("expected subject retry got %s\n", msg.Subject))
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	redisclient "github.com/absmach/magistrala/internal/clients/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defRedisKey = "magistrala.email.queue"
+
+	// retryKeySuffix is appended to the queue key to get the key of the
+	// sorted set of scheduled retries, scored by their due time.
+	retryKeySuffix = ".retry"
+
+	// pollInterval bounds how long Pop blocks before checking for due retries.
+	pollInterval = time.Second
+)
+
+// promoteScript atomically moves the retries which are due to the queue.
+var promoteScript = redis.NewScript(`
+local msgs = redis.call("ZRANGEBYSCORE", KEYS[2], "-inf", ARGV[1], "LIMIT", 0, 100)
+for _, msg in ipairs(msgs) do
+	redis.call("RPUSH", KEYS[1], msg)
+	redis.call("ZREM", KEYS[2], msg)
+end
+return #msgs
+`)
+
+// NewStore creates the store of pending e-mails selected by the queue
+// configuration: Redis if the Redis URL is set, in-memory otherwise.
+func NewStore(cfg QueueConfig) (Store, error) {
+	if cfg.RedisURL == "" {
+		return NewMemoryStore(cfg.Size), nil
+	}
+	client, err := redisclient.Connect(cfg.RedisURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRedisStore(client, ""), nil
+}
+
+type redisStore struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisStore creates Redis backed store of pending e-mails, so that
+// queued e-mails and scheduled retries survive service restarts.
+func NewRedisStore(client *redis.Client, key string) Store {
+	if key == "" {
+		key = defRedisKey
+	}
+
+	return &redisStore{client: client, key: key}
+}
+
+func (s *redisStore) Push(ctx context.Context, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return s.client.LPush(ctx, s.key, data).Err()
+}
+
+func (s *redisStore) Retry(ctx context.Context, msg Message, due time.Time) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return s.client.ZAdd(ctx, s.key+retryKeySuffix, redis.Z{Score: float64(due.UnixMilli()), Member: data}).Err()
+}
+
+func (s *redisStore) Pop(ctx context.Context) (Message, error) {
+	for {
+		keys := []string{s.key, s.key + retryKeySuffix}
+		if err := promoteScript.Run(ctx, s.client, keys, time.Now().UnixMilli()).Err(); err != nil {
+			return Message{}, err
+		}
+		res, err := s.client.BRPop(ctx, pollInterval, s.key).Result()
+		switch {
+		case err == redis.Nil:
+			continue
+		case err != nil:
+			return Message{}, err
+		}
+		// BRPOP returns the key name followed by the popped value.
+		var msg Message
+		if err := json.Unmarshal([]byte(res[1]), &msg); err != nil {
+			return Message{}, err
+		}
+
+		return msg, nil
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+)
+
+const sendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+type sendGridProvider struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func newSendGrid(c *Config) Provider {
+	return &sendGridProvider{
+		url:    sendGridURL,
+		apiKey: c.SendGridAPIKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridMessage struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+func (p *sendGridProvider) Send(from string, to []string, subject, body string) error {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return err
+	}
+	msg := sendGridMessage{
+		From:    sendGridAddress{Email: sender.Address, Name: sender.Name},
+		Subject: subject,
+		Content: []sendGridContent{{Type: "text/plain", Value: body}},
+	}
+	var rcpts []sendGridAddress
+	for _, addr := range to {
+		rcpts = append(rcpts, sendGridAddress{Email: addr})
+	}
+	msg.Personalizations = []sendGridPersonalization{{To: rcpts}}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusMultipleChoices {
+		b, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("sendgrid responded with status %d: %s", res.StatusCode, b)
+	}
+
+	return nil
+}
@@ -29,3 +29,16 @@ func MakeMetrics(namespace, subsystem string) (*kitprometheus.Counter, *kitprome
 
 	return counter, latency
 }
+
+// MakeCounter returns an instance of Prometheus counter with the given name
+// and label names.
+//
+//	counter := metrics.MakeCounter("demo-service", "api", "failed_count", "Number of failures.", "status")
+func MakeCounter(namespace, subsystem, name, help string, labels ...string) *kitprometheus.Counter {
+	return kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit provides the HTTP middleware which limits the API
+// requests per domain, so a single tenant can't degrade the service for
+// the others.
+package ratelimit
@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// ErrRateLimited indicates that the domain exceeded its request limit.
+var ErrRateLimited = errors.New("too many requests")
+
+// Config represents the request limits. Each domain may send up to the
+// given number of requests per period, and the limit of the domain listed
+// in Domains overrides the default limit. The limit of zero disables rate
+// limiting. If PerUser is set, the limit applies to each user of the domain.
+type Config struct {
+	Requests int            `env:"REQUESTS" envDefault:"0"`
+	Period   time.Duration  `env:"PERIOD"   envDefault:"1s"`
+	Domains  map[string]int `env:"DOMAINS"`
+	PerUser  bool           `env:"PER_USER" envDefault:"false"`
+}
+
+func (cfg Config) limit(domainID string) int {
+	if requests, ok := cfg.Domains[domainID]; ok {
+		return requests
+	}
+
+	return cfg.Requests
+}
+
+// Store keeps the token buckets of the limited keys.
+//
+//go:generate mockery --name Store --output=./mocks --filename store.go --quiet --note "Copyright (c) Abstract Machines"
+type Store interface {
+	// Take takes a token from the bucket of the key. The bucket holds up
+	// to requests tokens and is refilled at the rate of requests per period.
+	// If the bucket is empty, Take returns the time after which the next
+	// token is available.
+	Take(ctx context.Context, key string, requests int, period time.Duration) (time.Duration, error)
+}
+
+// Middleware returns the HTTP middleware which limits the requests of the
+// authenticated session, so it has to follow the authentication middleware.
+// Requests exceeding the limit are rejected with 429 status and Retry-After
+// header. Requests aren't limited while the store is unavailable.
+func Middleware(store Store, cfg Config, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session, ok := r.Context().Value(api.SessionKey).(authn.Session)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			requests := cfg.limit(session.DomainID)
+			if requests <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := session.DomainID
+			if cfg.PerUser {
+				key = session.DomainID + ":" + session.UserID
+			}
+			wait, err := store.Take(r.Context(), key, requests, cfg.Period)
+			if err != nil {
+				logger.Warn("failed to take rate limit token", slog.String("domain_id", session.DomainID), slog.Any("error", err))
+				next.ServeHTTP(w, r)
+				return
+			}
+			if wait > 0 {
+				encodeRateLimited(w, wait)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func encodeRateLimited(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Content-Type", api.ContentType)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(apiutil.ErrorRes{Msg: ErrRateLimited.Error()})
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/ratelimit"
+	"github.com/absmach/magistrala/pkg/ratelimit/mocks"
+	api "github.com/absmach/supermq/api/http"
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	domainID      = "b4d7d79e-fd99-4c2b-ac09-524e43df6888"
+	otherDomainID = "3a1bd6b5-9b4f-4d1f-9d35-6b7e2b0b8f0e"
+	userID        = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
+)
+
+func TestMiddleware(t *testing.T) {
+	cfg := ratelimit.Config{
+		Requests: 10,
+		Period:   time.Second,
+		Domains:  map[string]int{otherDomainID: 0},
+	}
+
+	cases := []struct {
+		desc       string
+		cfg        ratelimit.Config
+		session    *authn.Session
+		key        string
+		requests   int
+		wait       time.Duration
+		takeErr    error
+		status     int
+		retryAfter string
+	}{
+		{
+			desc:     "request within limit",
+			cfg:      cfg,
+			session:  &authn.Session{DomainID: domainID, UserID: userID},
+			key:      domainID,
+			requests: 10,
+			status:   http.StatusOK,
+		},
+		{
+			desc:       "request exceeding limit",
+			cfg:        cfg,
+			session:    &authn.Session{DomainID: domainID, UserID: userID},
+			key:        domainID,
+			requests:   10,
+			wait:       1500 * time.Millisecond,
+			status:     http.StatusTooManyRequests,
+			retryAfter: "2",
+		},
+		{
+			desc: "request exceeding user limit",
+			cfg: ratelimit.Config{
+				Requests: 10,
+				Period:   time.Second,
+				PerUser:  true,
+			},
+			session:    &authn.Session{DomainID: domainID, UserID: userID},
+			key:        domainID + ":" + userID,
+			requests:   10,
+			wait:       100 * time.Millisecond,
+			status:     http.StatusTooManyRequests,
+			retryAfter: "1",
+		},
+		{
+			desc: "request within domain limit",
+			cfg: ratelimit.Config{
+				Requests: 10,
+				Period:   time.Second,
+				Domains:  map[string]int{domainID: 100},
+			},
+			session:  &authn.Session{DomainID: domainID, UserID: userID},
+			key:      domainID,
+			requests: 100,
+			status:   http.StatusOK,
+		},
+		{
+			desc:    "request of unlimited domain",
+			cfg:     cfg,
+			session: &authn.Session{DomainID: otherDomainID, UserID: userID},
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "request with disabled limit",
+			cfg:     ratelimit.Config{},
+			session: &authn.Session{DomainID: domainID, UserID: userID},
+			status:  http.StatusOK,
+		},
+		{
+			desc:   "request without session",
+			cfg:    cfg,
+			status: http.StatusOK,
+		},
+		{
+			desc:     "request with failed store",
+			cfg:      cfg,
+			session:  &authn.Session{DomainID: domainID, UserID: userID},
+			key:      domainID,
+			requests: 10,
+			takeErr:  errors.New("store unavailable"),
+			status:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := new(mocks.Store)
+			storeCall := store.On("Take", mock.Anything, tc.key, tc.requests, tc.cfg.Period).Return(tc.wait, tc.takeErr)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := ratelimit.Middleware(store, tc.cfg, smqlog.NewMock())(next)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.session != nil {
+				req = req.WithContext(context.WithValue(req.Context(), api.SessionKey, *tc.session))
+			}
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.status, res.Code, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.Code))
+			retryAfter := res.Header().Get("Retry-After")
+			assert.Equal(t, tc.retryAfter, retryAfter, fmt.Sprintf("%s: expected Retry-After %s got %s", tc.desc, tc.retryAfter, retryAfter))
+			if tc.requests == 0 {
+				store.AssertNotCalled(t, "Take", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			storeCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains Redis implementation of the rate limit store.
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "ratelimit"
+
+// take refills the bucket by the time elapsed since the last request and
+// takes a token from it. It returns the number of milliseconds after which
+// the next token is available, or zero if the token is taken. Redis server
+// time is used, so the buckets are consistent across service instances.
+var take = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1]) or capacity
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + (now - ts) * capacity / period)
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * period / capacity)
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], period)
+return wait
+`)
+
+var _ ratelimit.Store = (*store)(nil)
+
+type store struct {
+	client *redis.Client
+}
+
+// NewStore returns Redis store of token buckets. Buckets expire once they
+// are refilled, so idle keys don't occupy the memory.
+func NewStore(client *redis.Client) ratelimit.Store {
+	return &store{client: client}
+}
+
+func (s *store) Take(ctx context.Context, key string, requests int, period time.Duration) (time.Duration, error) {
+	k := fmt.Sprintf("%s.%s", keyPrefix, key)
+	wait, err := take.Run(ctx, s.client, []string{k}, requests, period.Milliseconds()).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(wait) * time.Millisecond, nil
+}
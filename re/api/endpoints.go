@@ -92,7 +92,7 @@ func listRulesEndpoint(s re.Service) endpoint.Endpoint {
 	}
 }
 
-func upadateRuleStatusEndpoint(s re.Service) endpoint.Endpoint {
+func enableRuleEndpoint(s re.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		session, ok := ctx.Value(api.SessionKey).(authn.Session)
 		if !ok {
@@ -101,12 +101,139 @@ func upadateRuleStatusEndpoint(s re.Service) endpoint.Endpoint {
 
 		req := request.(changeRuleStatusReq)
 		if err := req.validate(); err != nil {
-			return updateRoleStatusRes{}, err
+			return changeRuleStatusRes{}, err
 		}
-		err := s.RemoveRule(ctx, session, req.id)
+		rule, err := s.EnableRule(ctx, session, req.id)
 		if err != nil {
-			return updateRoleStatusRes{false}, err
+			return changeRuleStatusRes{}, err
 		}
-		return updateRoleStatusRes{true}, nil
+		return changeRuleStatusRes{Rule: rule}, nil
+	}
+}
+
+func disableRuleEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(changeRuleStatusReq)
+		if err := req.validate(); err != nil {
+			return changeRuleStatusRes{}, err
+		}
+		rule, err := s.DisableRule(ctx, session, req.id)
+		if err != nil {
+			return changeRuleStatusRes{}, err
+		}
+		return changeRuleStatusRes{Rule: rule}, nil
+	}
+}
+
+// updateRuleStatusEndpoint is kept for the clients of the former status API,
+// and it enables or disables the Rule depending on the requested status.
+func updateRuleStatusEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(updateRuleStatusReq)
+		if err := req.validate(); err != nil {
+			return changeRuleStatusRes{}, err
+		}
+		var rule re.Rule
+		var err error
+		switch req.status {
+		case re.EnabledStatus:
+			rule, err = s.EnableRule(ctx, session, req.id)
+		default:
+			rule, err = s.DisableRule(ctx, session, req.id)
+		}
+		if err != nil {
+			return changeRuleStatusRes{}, err
+		}
+		return changeRuleStatusRes{Rule: rule}, nil
+	}
+}
+
+func deleteRuleEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(viewRuleReq)
+		if err := req.validate(); err != nil {
+			return deleteRuleRes{}, err
+		}
+		if err := s.RemoveRule(ctx, session, req.id); err != nil {
+			return deleteRuleRes{}, err
+		}
+		return deleteRuleRes{}, nil
+	}
+}
+
+func ruleStatsEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(viewRuleReq)
+		if err := req.validate(); err != nil {
+			return ruleStatsRes{}, err
+		}
+		stats, err := s.RuleStats(ctx, session, req.id)
+		if err != nil {
+			return ruleStatsRes{}, err
+		}
+		return ruleStatsRes{Stats: stats}, nil
+	}
+}
+
+func replayRuleEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(replayRuleReq)
+		if err := req.validate(); err != nil {
+			return replayRes{}, err
+		}
+		replay := re.Replay{
+			From:   req.From,
+			To:     req.To,
+			DryRun: req.DryRun,
+		}
+		replay, err := s.ReplayRule(ctx, session, req.ClientSecret, req.id, replay)
+		if err != nil {
+			return replayRes{}, err
+		}
+		return replayRes{Replay: replay, started: true}, nil
+	}
+}
+
+func viewReplayEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(viewRuleReq)
+		if err := req.validate(); err != nil {
+			return replayRes{}, err
+		}
+		replay, err := s.ViewReplay(ctx, session, req.id)
+		if err != nil {
+			return replayRes{}, err
+		}
+		return replayRes{Replay: replay}, nil
 	}
 }
@@ -4,9 +4,13 @@
 package api
 
 import (
+	"time"
+
 	"github.com/absmach/magistrala/re"
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 )
 
 const maxLimitSize = 1000
@@ -16,7 +20,7 @@ type addRuleReq struct {
 }
 
 func (req addRuleReq) validate() error {
-	return nil
+	return validateWindow(req.Window)
 }
 
 type viewRuleReq struct {
@@ -58,18 +62,66 @@ func (req updateRuleReq) validate() error {
 		return apiutil.ErrEmptyList
 	}
 
-	return nil
+	return validateWindow(req.Rule.Window)
 }
 
 type changeRuleStatusReq struct {
+	id string
+}
+
+func (req changeRuleStatusReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type updateRuleStatusReq struct {
 	id     string
 	status re.Status
 }
 
-func (req changeRuleStatusReq) validate() error {
+func (req updateRuleStatusReq) validate() error {
 	if req.id == "" {
 		return apiutil.ErrMissingID
 	}
+	if req.status != re.EnabledStatus && req.status != re.DisabledStatus {
+		return svcerr.ErrInvalidStatus
+	}
+
+	return nil
+}
+
+type replayRuleReq struct {
+	id           string
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	DryRun       bool      `json:"dry_run"`
+	ClientSecret string    `json:"client_secret"`
+}
+
+func (req replayRuleReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.ClientSecret == "" {
+		return apiutil.ErrMissingSecret
+	}
+	if err := (re.Replay{From: req.From, To: req.To}).Validate(); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
+
+func validateWindow(w *re.Window) error {
+	if w == nil {
+		return nil
+	}
+	if err := w.Validate(); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
 
 	return nil
 }
@@ -17,7 +17,9 @@ var (
 	_ supermq.Response = (*changeRuleStatusRes)(nil)
 	_ supermq.Response = (*rulesPageRes)(nil)
 	_ supermq.Response = (*updateRuleRes)(nil)
-	_ supermq.Response = (*updateRoleStatusRes)(nil)
+	_ supermq.Response = (*deleteRuleRes)(nil)
+	_ supermq.Response = (*ruleStatsRes)(nil)
+	_ supermq.Response = (*replayRes)(nil)
 )
 
 type pageRes struct {
@@ -118,22 +120,53 @@ func (res changeRuleStatusRes) Empty() bool {
 	return false
 }
 
-type updateRoleStatusRes struct {
-	deleted bool
+type deleteRuleRes struct{}
+
+func (res deleteRuleRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res deleteRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteRuleRes) Empty() bool {
+	return true
+}
+
+type ruleStatsRes struct {
+	re.Stats `json:",inline"`
+}
+
+func (res ruleStatsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res ruleStatsRes) Headers() map[string]string {
+	return map[string]string{}
 }
 
-func (res updateRoleStatusRes) Code() int {
-	if res.deleted {
-		return http.StatusNoContent
+func (res ruleStatsRes) Empty() bool {
+	return false
+}
+
+type replayRes struct {
+	re.Replay `json:",inline"`
+	started   bool
+}
+
+func (res replayRes) Code() int {
+	if res.started {
+		return http.StatusAccepted
 	}
 
 	return http.StatusOK
 }
 
-func (res updateRoleStatusRes) Headers() map[string]string {
+func (res replayRes) Headers() map[string]string {
 	return map[string]string{}
 }
 
-func (res updateRoleStatusRes) Empty() bool {
-	return true
+func (res replayRes) Empty() bool {
+	return false
 }
@@ -29,14 +29,16 @@ const (
 	statusKey        = "status"
 )
 
-// MakeHandler creates an HTTP handler for the service endpoints.
-func MakeHandler(svc re.Service, authn mgauthn.Authentication, logger *slog.Logger, instanceID string) http.Handler {
+// MakeHandler creates an HTTP handler for the service endpoints. Requests of
+// the authenticated users are passed through the rate limit middleware.
+func MakeHandler(svc re.Service, authn mgauthn.Authentication, rateLimit func(http.Handler) http.Handler, logger *slog.Logger, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
 	}
 	mux := chi.NewRouter()
 	mux.Group(func(r chi.Router) {
 		r.Use(api.AuthenticateMiddleware(authn, true))
+		r.Use(rateLimit)
 		r.Route("/{domainID}/rules", func(r chi.Router) {
 			r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
 				addRuleEndpoint(svc),
@@ -66,12 +68,54 @@ func MakeHandler(svc re.Service, authn mgauthn.Authentication, logger *slog.Logg
 				opts...,
 			), "update_rule").ServeHTTP)
 
+			r.Delete("/{ruleID}", otelhttp.NewHandler(kithttp.NewServer(
+				deleteRuleEndpoint(svc),
+				decodeViewRuleRequest,
+				api.EncodeResponse,
+				opts...,
+			), "delete_rule").ServeHTTP)
+
+			r.Post("/{ruleID}/enable", otelhttp.NewHandler(kithttp.NewServer(
+				enableRuleEndpoint(svc),
+				decodeChangeRuleStatusRequest,
+				api.EncodeResponse,
+				opts...,
+			), "enable_rule").ServeHTTP)
+
+			r.Post("/{ruleID}/disable", otelhttp.NewHandler(kithttp.NewServer(
+				disableRuleEndpoint(svc),
+				decodeChangeRuleStatusRequest,
+				api.EncodeResponse,
+				opts...,
+			), "disable_rule").ServeHTTP)
+
 			r.Put("/{ruleID}/status", otelhttp.NewHandler(kithttp.NewServer(
-				upadateRuleStatusEndpoint(svc),
+				updateRuleStatusEndpoint(svc),
 				decodeUpdateRuleStatusRequest,
 				api.EncodeResponse,
 				opts...,
 			), "update_rule_status").ServeHTTP)
+
+			r.Get("/{ruleID}/stats", otelhttp.NewHandler(kithttp.NewServer(
+				ruleStatsEndpoint(svc),
+				decodeViewRuleRequest,
+				api.EncodeResponse,
+				opts...,
+			), "view_rule_stats").ServeHTTP)
+
+			r.Post("/{ruleID}/replay", otelhttp.NewHandler(kithttp.NewServer(
+				replayRuleEndpoint(svc),
+				decodeReplayRuleRequest,
+				api.EncodeResponse,
+				opts...,
+			), "replay_rule").ServeHTTP)
+
+			r.Get("/{ruleID}/replay", otelhttp.NewHandler(kithttp.NewServer(
+				viewReplayEndpoint(svc),
+				decodeViewRuleRequest,
+				api.EncodeResponse,
+				opts...,
+			), "view_rule_replay").ServeHTTP)
 		})
 	})
 
@@ -122,25 +166,52 @@ func decodeListRulesRequest(_ context.Context, r *http.Request) (interface{}, er
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	s, err := apiutil.ReadStringQuery(r, statusKey, re.Enabled)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := re.ToStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	return listRulesReq{
 		PageMeta: re.PageMeta{
 			Offset:        offset,
 			Limit:         limit,
 			InputChannel:  ic,
 			OutputChannel: oc,
+			Status:        status,
 		},
 	}, nil
 }
 
+func decodeChangeRuleStatusRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id := chi.URLParam(r, idKey)
+	return changeRuleStatusReq{id: id}, nil
+}
+
 func decodeUpdateRuleStatusRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	id := r.URL.Query().Get(idKey)
-	status, err := apiutil.ReadStringQuery(r, statusKey, re.AllStatus.String())
+	id := chi.URLParam(r, idKey)
+	s, err := apiutil.ReadStringQuery(r, statusKey, re.AllStatus.String())
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	s, err := re.ToStatus(status)
+	status, err := re.ToStatus(s)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	return changeRuleStatusReq{id: id, status: s}, nil
+	return updateRuleStatusReq{id: id, status: status}, nil
+}
+
+func decodeReplayRuleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+	req := replayRuleReq{
+		id: chi.URLParam(r, idKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	return req, nil
 }
@@ -0,0 +1,28 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// DefMaxHops is the default maximum number of Rules a message can pass through.
+const DefMaxHops = 8
+
+// publisher is the publisher of the messages published by Rules.
+const publisher = "magistrala.re"
+
+// ErrMaxHops indicates that the message passed through more Rules than allowed,
+// which is usually caused by Rules publishing to each other in a loop.
+var ErrMaxHops = errors.New("message exceeded max rule hops")
+
+// Chain is the sequence of IDs of the Rules which produced the message.
+type Chain []string
+
+// String returns the chain in the "first -> ... -> last" form.
+func (c Chain) String() string {
+	return strings.Join(c, " -> ")
+}
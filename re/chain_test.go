@@ -0,0 +1,183 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type chainRepository struct {
+	Repository
+	rules map[string]Rule
+}
+
+func (repo chainRepository) ListRules(_ context.Context, pm PageMeta) (Page, error) {
+	if r, ok := repo.rules[pm.InputChannel]; ok {
+		return Page{PageMeta: PageMeta{Total: 1}, Rules: []Rule{r}}, nil
+	}
+	return Page{}, nil
+}
+
+// chainStats guards the Stats recorded by the concurrently dispatched Rules.
+type chainStats struct {
+	mu sync.Mutex
+	memoryStats
+}
+
+func (cs *chainStats) Record(ctx context.Context, ruleID string, run Run) (Stats, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.memoryStats.Record(ctx, ruleID, run)
+}
+
+func TestPublishChain(t *testing.T) {
+	pubSub := new(mocks.PubSub)
+	pubSub.On("Publish", context.Background(), mock.Anything, mock.Anything).Return(nil)
+	logic := Script{Value: "return 1"}
+	repo := chainRepository{rules: map[string]Rule{
+		"stage2": {ID: "rule2", InputChannel: "stage2", OutputChannel: "stage3", Logic: logic},
+		"loop":   {ID: "loop", InputChannel: "loop", OutputChannel: "loop", Logic: logic},
+	}}
+	stats := &chainStats{memoryStats: memoryStats{}}
+	svc := NewService(repo, nil, pubSub, nil, stats, nil, discard.NewCounter(), 3, 0, smqlog.NewMock()).(*re)
+
+	cases := []struct {
+		desc       string
+		rule       Rule
+		chain      Chain
+		published  []string
+		dispatched int
+		err        error
+		runErr     error
+	}{
+		{
+			desc:      "publish message of client to external channel",
+			rule:      Rule{ID: "rule1", OutputChannel: "external"},
+			published: []string{"external"},
+		},
+		{
+			desc:       "publish message of client to chained rule",
+			rule:       Rule{ID: "rule1", OutputChannel: "stage2"},
+			published:  []string{"stage2", "stage3"},
+			dispatched: 1,
+		},
+		{
+			desc:  "publish message of dry run rule",
+			rule:  Rule{ID: "rule1", OutputChannel: "stage2", DryRun: true},
+			chain: Chain{"rule3"},
+		},
+		{
+			desc:  "publish message exceeding max hops",
+			rule:  Rule{ID: "rule1", OutputChannel: "stage2"},
+			chain: Chain{"rule1", "rule2", "rule3"},
+			err:   ErrMaxHops,
+		},
+		{
+			desc:       "publish message of rule loop",
+			rule:       Rule{ID: "loop", OutputChannel: "loop"},
+			published:  []string{"loop", "loop", "loop"},
+			dispatched: 3,
+			runErr:     ErrMaxHops,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.publish(context.Background(), tc.rule, &messaging.Message{Protocol: "mqtt"}, tc.chain, []byte("1"))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		var runErr error
+		for i := 0; i < tc.dispatched; i++ {
+			select {
+			case runErr = <-svc.Errors():
+			case <-time.After(time.Second):
+				require.Fail(t, fmt.Sprintf("%s: chained rule was not run", tc.desc))
+			}
+		}
+		assert.True(t, errors.Contains(runErr, tc.runErr), fmt.Sprintf("%s: expected run error %s got %s\n", tc.desc, tc.runErr, runErr))
+		for _, channel := range tc.published {
+			published := mock.MatchedBy(func(m *messaging.Message) bool {
+				return m.Channel == channel && m.Protocol == "mqtt" && m.Publisher == publisher
+			})
+			pubSub.AssertCalled(t, "Publish", context.Background(), channel, published)
+		}
+	}
+	pubSub.AssertNumberOfCalls(t, "Publish", 6)
+}
+
+func TestConsumeChained(t *testing.T) {
+	repo := chainRepository{rules: map[string]Rule{
+		"stage2": {ID: "rule2", InputChannel: "stage2"},
+	}}
+	svc := NewService(repo, nil, nil, nil, nil, nil, discard.NewCounter(), 0, 0, smqlog.NewMock()).(*re)
+
+	svc.ConsumeAsync(context.Background(), &messaging.Message{Channel: "stage2", Publisher: publisher})
+	select {
+	case err := <-svc.Errors():
+		assert.Fail(t, fmt.Sprintf("message published by rule must not be consumed, got %v", err))
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// chainWindows records the samples added to the windows of chained Rules.
+type chainWindows struct {
+	WindowStore
+	mu      sync.Mutex
+	samples []Sample
+	before  []int64
+}
+
+func (cw *chainWindows) Add(ctx context.Context, key string, s Sample, before int64, ttl time.Duration) ([]Sample, []Sample, error) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	cw.samples = append(cw.samples, s)
+	cw.before = append(cw.before, before)
+	return nil, []Sample{s}, nil
+}
+
+func TestPublishChainWindow(t *testing.T) {
+	pubSub := new(mocks.PubSub)
+	pubSub.On("Publish", context.Background(), mock.Anything, mock.Anything).Return(nil)
+	repo := chainRepository{rules: map[string]Rule{
+		"stage2": {
+			ID:           "rule2",
+			InputChannel: "stage2",
+			Logic:        Script{Value: "return nil"},
+			Window:       &Window{Type: TumblingWindow, Aggregation: CountAggregation, Duration: "1m", Field: "temperature"},
+		},
+	}}
+	windows := &chainWindows{}
+	stats := &chainStats{memoryStats: memoryStats{}}
+	svc := NewService(repo, nil, pubSub, windows, stats, nil, discard.NewCounter(), 0, 0, smqlog.NewMock()).(*re)
+
+	before := time.Now()
+	err := svc.publish(context.Background(), Rule{ID: "rule1", OutputChannel: "stage2"}, &messaging.Message{}, nil, []byte(`{"temperature":21}`))
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	select {
+	case err = <-svc.Errors():
+		assert.Nil(t, err, fmt.Sprintf("unexpected chained rule error %s", err))
+	case <-time.After(time.Second):
+		require.Fail(t, "chained rule was not run")
+	}
+	after := time.Now()
+
+	windows.mu.Lock()
+	defer windows.mu.Unlock()
+	require.Len(t, windows.samples, 1, "expected sample of chained message in rule window")
+	created := windows.samples[0].Time
+	assert.True(t, created >= before.UnixNano() && created <= after.UnixNano(), fmt.Sprintf("expected sample time between %d and %d got %d", before.UnixNano(), after.UnixNano(), created))
+	start := time.Unix(0, created).Truncate(time.Minute).UnixNano()
+	assert.Equal(t, start, windows.before[0], fmt.Sprintf("expected window start %d got %d", start, windows.before[0]))
+}
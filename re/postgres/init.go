@@ -40,6 +40,55 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP TABLE IF EXISTS rules`,
 				},
 			},
+			{
+				Id: "rules_02",
+				Up: []string{
+					`ALTER TABLE rules ADD COLUMN window_type VARCHAR(16)`,
+					`ALTER TABLE rules ADD COLUMN window_aggregation VARCHAR(16)`,
+					`ALTER TABLE rules ADD COLUMN window_duration VARCHAR(32)`,
+					`ALTER TABLE rules ADD COLUMN window_field TEXT`,
+					`ALTER TABLE rules ADD COLUMN window_group_by VARCHAR(16)`,
+				},
+				Down: []string{
+					`ALTER TABLE rules DROP COLUMN window_type`,
+					`ALTER TABLE rules DROP COLUMN window_aggregation`,
+					`ALTER TABLE rules DROP COLUMN window_duration`,
+					`ALTER TABLE rules DROP COLUMN window_field`,
+					`ALTER TABLE rules DROP COLUMN window_group_by`,
+				},
+			},
+			{
+				Id: "rules_03",
+				Up: []string{
+					`ALTER TABLE rules ADD COLUMN dry_run BOOLEAN NOT NULL DEFAULT FALSE`,
+				},
+				Down: []string{
+					`ALTER TABLE rules DROP COLUMN dry_run`,
+				},
+			},
+			{
+				Id: "rules_04",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS replays (
+						rule_id		VARCHAR(36) PRIMARY KEY REFERENCES rules (id) ON DELETE CASCADE,
+						from_time	TIMESTAMP NOT NULL,
+						to_time		TIMESTAMP NOT NULL,
+						dry_run		BOOLEAN NOT NULL DEFAULT FALSE,
+						status		VARCHAR(16) NOT NULL,
+						total		BIGINT NOT NULL DEFAULT 0,
+						processed	BIGINT NOT NULL DEFAULT 0,
+						matches		BIGINT NOT NULL DEFAULT 0,
+						failures	BIGINT NOT NULL DEFAULT 0,
+						error		TEXT,
+						started_at	TIMESTAMP NOT NULL,
+						updated_at	TIMESTAMP NOT NULL,
+						finished_at	TIMESTAMP
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS replays`,
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,151 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+)
+
+const (
+	// Replays which aren't running, or which weren't updated since the stale
+	// time, are replaced by the new replay of the Rule.
+	startReplayQuery = `
+		INSERT INTO replays (rule_id, from_time, to_time, dry_run, status, total, processed,
+			matches, failures, error, started_at, updated_at, finished_at)
+		VALUES (:rule_id, :from_time, :to_time, :dry_run, :status, :total, :processed,
+			:matches, :failures, :error, :started_at, :updated_at, :finished_at)
+		ON CONFLICT (rule_id) DO UPDATE
+		SET from_time = EXCLUDED.from_time, to_time = EXCLUDED.to_time, dry_run = EXCLUDED.dry_run,
+			status = EXCLUDED.status, total = EXCLUDED.total, processed = EXCLUDED.processed,
+			matches = EXCLUDED.matches, failures = EXCLUDED.failures, error = EXCLUDED.error,
+			started_at = EXCLUDED.started_at, updated_at = EXCLUDED.updated_at,
+			finished_at = EXCLUDED.finished_at
+		WHERE replays.status <> :running OR replays.updated_at < :stale;
+	`
+
+	updateReplayQuery = `
+		UPDATE replays
+		SET status = :status, processed = :processed, matches = :matches, failures = :failures,
+			error = :error, updated_at = :updated_at, finished_at = :finished_at
+		WHERE rule_id = :rule_id AND started_at = :started_at;
+	`
+
+	viewReplayQuery = `
+		SELECT rule_id, from_time, to_time, dry_run, status, total, processed, matches, failures,
+			error, started_at, updated_at, finished_at
+		FROM replays
+		WHERE rule_id = $1;
+	`
+)
+
+func (repo *PostgresRepository) StartReplay(ctx context.Context, rp re.Replay, stale time.Time) error {
+	dbrp := dbStartReplay{
+		dbReplay: replayToDb(rp),
+		Running:  re.ReplayRunning,
+		Stale:    stale,
+	}
+	result, err := repo.DB.NamedExecContext(ctx, startReplayQuery, dbrp)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return repoerr.ErrConflict
+	}
+
+	return nil
+}
+
+// UpdateReplay saves the progress of the replay. Progress of the replay which
+// was replaced by a later replay of the Rule is discarded.
+func (repo *PostgresRepository) UpdateReplay(ctx context.Context, rp re.Replay) error {
+	result, err := repo.DB.NamedExecContext(ctx, updateReplayQuery, replayToDb(rp))
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *PostgresRepository) ViewReplay(ctx context.Context, ruleID string) (re.Replay, error) {
+	row := repo.DB.QueryRowxContext(ctx, viewReplayQuery, ruleID)
+	var dbrp dbReplay
+	if err := row.StructScan(&dbrp); err != nil {
+		if errors.Contains(err, sql.ErrNoRows) {
+			return re.Replay{}, repoerr.ErrNotFound
+		}
+		return re.Replay{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return dbToReplay(dbrp), nil
+}
+
+// dbReplay represents the database structure for a Rule replay.
+type dbReplay struct {
+	RuleID     string          `db:"rule_id"`
+	From       time.Time       `db:"from_time"`
+	To         time.Time       `db:"to_time"`
+	DryRun     bool            `db:"dry_run"`
+	Status     re.ReplayStatus `db:"status"`
+	Total      uint64          `db:"total"`
+	Processed  uint64          `db:"processed"`
+	Matches    uint64          `db:"matches"`
+	Failures   uint64          `db:"failures"`
+	Error      sql.NullString  `db:"error"`
+	StartedAt  time.Time       `db:"started_at"`
+	UpdatedAt  time.Time       `db:"updated_at"`
+	FinishedAt sql.NullTime    `db:"finished_at"`
+}
+
+type dbStartReplay struct {
+	dbReplay
+	Running re.ReplayStatus `db:"running"`
+	Stale   time.Time       `db:"stale"`
+}
+
+// replayToDb truncates the start time to the microsecond precision of the
+// stored time, since the replay progress is identified by it.
+func replayToDb(rp re.Replay) dbReplay {
+	return dbReplay{
+		RuleID:     rp.RuleID,
+		From:       rp.From,
+		To:         rp.To,
+		DryRun:     rp.DryRun,
+		Status:     rp.Status,
+		Total:      rp.Total,
+		Processed:  rp.Processed,
+		Matches:    rp.Matches,
+		Failures:   rp.Failures,
+		Error:      sql.NullString{String: rp.Error, Valid: rp.Error != ""},
+		StartedAt:  rp.StartedAt.Truncate(time.Microsecond),
+		UpdatedAt:  rp.UpdatedAt,
+		FinishedAt: sql.NullTime{Time: rp.FinishedAt, Valid: !rp.FinishedAt.IsZero()},
+	}
+}
+
+func dbToReplay(dbrp dbReplay) re.Replay {
+	return re.Replay{
+		RuleID:     dbrp.RuleID,
+		From:       dbrp.From.UTC(),
+		To:         dbrp.To.UTC(),
+		DryRun:     dbrp.DryRun,
+		Status:     dbrp.Status,
+		Total:      dbrp.Total,
+		Processed:  dbrp.Processed,
+		Matches:    dbrp.Matches,
+		Failures:   dbrp.Failures,
+		Error:      dbrp.Error.String,
+		StartedAt:  dbrp.StartedAt.UTC(),
+		UpdatedAt:  dbrp.UpdatedAt.UTC(),
+		FinishedAt: dbrp.FinishedAt.Time.UTC(),
+	}
+}
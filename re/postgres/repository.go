@@ -18,15 +18,18 @@ import (
 const (
 	addRuleQuery = `
 		INSERT INTO rules (id, domain_id, input_channel, input_topic, logic_type, logic_value,
-			output_channel, output_topic, recurring_time, recurring_type, recurring_period, status)
+			output_channel, output_topic, recurring_time, recurring_type, recurring_period, status, dry_run,
+			window_type, window_aggregation, window_duration, window_field, window_group_by)
 		VALUES (:id, :domain_id, :input_channel, :input_topic, :logic_type, :logic_value,
-			:output_channel, :output_topic, :recurring_time, :recurring_type, :recurring_period, :status)
+			:output_channel, :output_topic, :recurring_time, :recurring_type, :recurring_period, :status, :dry_run,
+			:window_type, :window_aggregation, :window_duration, :window_field, :window_group_by)
 		RETURNING id;
 	`
 
 	viewRuleQuery = `
 		SELECT id, domain_id, input_channel, input_topic, logic_type, logic_value, output_channel, 
-			output_topic, recurring_time, recurring_type, recurring_period, status
+			output_topic, recurring_time, recurring_type, recurring_period, status, dry_run,
+			window_type, window_aggregation, window_duration, window_field, window_group_by
 		FROM rules
 		WHERE id = $1;
 	`
@@ -36,10 +39,22 @@ const (
 		SET input_channel = :input_channel, input_topic = :input_topic, logic_type = :logic_type, 
 			logic_value = :logic_value, output_channel = :output_channel, output_topic = :output_topic, 
 			recurring_time = :recurring_time, recurring_type = :recurring_type, 
-			recurring_period = :recurring_period, status = :status
+			recurring_period = :recurring_period, status = :status, dry_run = :dry_run, window_type = :window_type,
+			window_aggregation = :window_aggregation, window_duration = :window_duration,
+			window_field = :window_field, window_group_by = :window_group_by
 		WHERE id = :id;
 	`
 
+	updateRuleStatusQuery = `
+		UPDATE rules
+		SET status = :status, updated_at = :updated_at, updated_by = :updated_by
+		WHERE id = :id
+		RETURNING id, domain_id, input_channel, input_topic, logic_type, logic_value, output_channel,
+			output_topic, recurring_time, recurring_type, recurring_period, status, dry_run,
+			window_type, window_aggregation, window_duration, window_field, window_group_by,
+			updated_at, updated_by;
+	`
+
 	removeRuleQuery = `
 		DELETE FROM rules
 		WHERE id = $1;
@@ -47,7 +62,8 @@ const (
 
 	listRulesQuery = `
 		SELECT id, domain_id, input_channel, input_topic, logic_type, logic_value, output_channel, 
-			output_topic, recurring_time, recurring_type, recurring_period, status
+			output_topic, recurring_time, recurring_type, recurring_period, status, dry_run,
+			window_type, window_aggregation, window_duration, window_field, window_group_by
 		FROM rules r %s %s; 
 	`
 
@@ -100,6 +116,25 @@ func (repo *PostgresRepository) UpdateRule(ctx context.Context, r re.Rule) (re.R
 	return r, nil
 }
 
+func (repo *PostgresRepository) UpdateRuleStatus(ctx context.Context, r re.Rule) (re.Rule, error) {
+	dbr := ruleToDb(r)
+	rows, err := repo.DB.NamedQueryContext(ctx, updateRuleStatusQuery, dbr)
+	if err != nil {
+		return re.Rule{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return re.Rule{}, repoerr.ErrNotFound
+	}
+	dbr = dbRule{}
+	if err := rows.StructScan(&dbr); err != nil {
+		return re.Rule{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return dbToRule(dbr), nil
+}
+
 func (repo *PostgresRepository) RemoveRule(ctx context.Context, id string) error {
 	result, err := repo.DB.ExecContext(ctx, removeRuleQuery, id)
 	if err != nil {
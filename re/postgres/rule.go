@@ -25,6 +25,12 @@ type dbRule struct {
 	RecurringType   re.ReccuringType      `db:"recurring_type"`
 	RecurringPeriod uint                  `db:"recurring_period"`
 	Status          re.Status             `db:"status"`
+	DryRun          bool                  `db:"dry_run"`
+	WindowType      sql.NullString        `db:"window_type"`
+	WindowAgg       sql.NullString        `db:"window_aggregation"`
+	WindowDuration  sql.NullString        `db:"window_duration"`
+	WindowField     sql.NullString        `db:"window_field"`
+	WindowGroupBy   sql.NullString        `db:"window_group_by"`
 	CreatedAt       time.Time             `db:"created_at"`
 	CreatedBy       string                `db:"created_by"`
 	UpdatedAt       time.Time             `db:"updated_at"`
@@ -32,6 +38,10 @@ type dbRule struct {
 }
 
 func ruleToDb(r re.Rule) dbRule {
+	var w re.Window
+	if r.Window != nil {
+		w = *r.Window
+	}
 	return dbRule{
 		ID:              r.ID,
 		DomainID:        r.DomainID,
@@ -45,6 +55,12 @@ func ruleToDb(r re.Rule) dbRule {
 		RecurringType:   r.Schedule.RecurringType,
 		RecurringPeriod: r.Schedule.RecurringPeriod,
 		Status:          r.Status,
+		DryRun:          r.DryRun,
+		WindowType:      toNullString(string(w.Type)),
+		WindowAgg:       toNullString(string(w.Aggregation)),
+		WindowDuration:  toNullString(w.Duration),
+		WindowField:     toNullString(w.Field),
+		WindowGroupBy:   toNullString(w.GroupBy),
 		CreatedAt:       r.CreatedAt,
 		CreatedBy:       r.CreatedBy,
 		UpdatedAt:       r.UpdatedAt,
@@ -69,7 +85,9 @@ func dbToRule(dto dbRule) re.Rule {
 			RecurringType:   dto.RecurringType,
 			RecurringPeriod: dto.RecurringPeriod,
 		},
+		Window:    toWindow(dto),
 		Status:    re.Status(dto.Status),
+		DryRun:    dto.DryRun,
 		CreatedAt: dto.CreatedAt,
 		CreatedBy: dto.CreatedBy,
 		UpdatedAt: dto.UpdatedAt,
@@ -77,6 +95,19 @@ func dbToRule(dto dbRule) re.Rule {
 	}
 }
 
+func toWindow(dto dbRule) *re.Window {
+	if !dto.WindowType.Valid {
+		return nil
+	}
+	return &re.Window{
+		Type:        re.WindowType(dto.WindowType.String),
+		Aggregation: re.Aggregation(fromNullString(dto.WindowAgg)),
+		Duration:    fromNullString(dto.WindowDuration),
+		Field:       fromNullString(dto.WindowField),
+		GroupBy:     fromNullString(dto.WindowGroupBy),
+	}
+}
+
 func toNullString(value string) sql.NullString {
 	if value == "" {
 		return sql.NullString{Valid: false}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package reader contains the SDK based reading of the stored messages
+// replayed through the Rules.
+package reader
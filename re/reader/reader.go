@@ -0,0 +1,96 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/supermq/pkg/messaging"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+var _ re.MessageReader = (*reader)(nil)
+
+type reader struct {
+	sdk mgsdk.SDK
+}
+
+// New instantiates the message reader which reads the stored messages from
+// the readers service using the SuperMQ SDK. Messages are read with the
+// client secret, so that the reader doesn't depend on the user session.
+func New(sdk mgsdk.SDK) re.MessageReader {
+	return &reader{sdk: sdk}
+}
+
+func (rd *reader) ReadMessages(_ context.Context, secret, domainID, channel, subtopic string, from, to time.Time, offset, limit uint64) (re.MessagesPage, error) {
+	pm := mgsdk.MessagePageMetadata{
+		PageMetadata: mgsdk.PageMetadata{
+			Offset: offset,
+			Limit:  limit,
+		},
+		Subtopic: subtopic,
+		From:     float64(from.UnixNano()),
+		To:       float64(to.UnixNano()),
+	}
+	mp, err := rd.sdk.ReadMessages(pm, channel, domainID, mgsdk.ClientPrefix+secret)
+	if err != nil {
+		return re.MessagesPage{}, err
+	}
+
+	page := re.MessagesPage{Total: mp.Total}
+	for _, m := range mp.Messages {
+		msg, err := toMessage(m)
+		if err != nil {
+			return re.MessagesPage{}, err
+		}
+		page.Messages = append(page.Messages, msg)
+	}
+
+	return page, nil
+}
+
+// record is the SenML record of the stored message.
+type record struct {
+	Name        string   `json:"n,omitempty"`
+	Unit        string   `json:"u,omitempty"`
+	Time        float64  `json:"t,omitempty"`
+	Value       *float64 `json:"v,omitempty"`
+	StringValue *string  `json:"vs,omitempty"`
+	BoolValue   *bool    `json:"vb,omitempty"`
+	DataValue   *string  `json:"vd,omitempty"`
+	Sum         *float64 `json:"s,omitempty"`
+}
+
+// toMessage converts the stored SenML message to the message with the SenML
+// pack payload of a single record, as it was consumed. Stored time is in
+// nanoseconds, so it's the creation time of the message, while the record
+// time is in seconds, as in the consumed SenML pack.
+func toMessage(m senml.Message) (*messaging.Message, error) {
+	payload, err := json.Marshal([]record{{
+		Name:        m.Name,
+		Unit:        m.Unit,
+		Time:        m.Time / float64(time.Second),
+		Value:       m.Value,
+		StringValue: m.StringValue,
+		BoolValue:   m.BoolValue,
+		DataValue:   m.DataValue,
+		Sum:         m.Sum,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	return &messaging.Message{
+		Channel:   m.Channel,
+		Subtopic:  m.Subtopic,
+		Publisher: m.Publisher,
+		Protocol:  m.Protocol,
+		Created:   int64(m.Time),
+		Payload:   payload,
+	}, nil
+}
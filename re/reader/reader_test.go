@@ -0,0 +1,117 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package reader_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/re/reader"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+// newReadersServer serves the stored messages created in the requested time
+// range, as the readers service which stores SenML time in nanoseconds.
+func newReadersServer(t *testing.T, stored []senml.Message) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != mgsdk.ClientPrefix+secret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		from, err := strconv.ParseFloat(r.URL.Query().Get("from"), 64)
+		require.Nil(t, err, fmt.Sprintf("unexpected error parsing from: %s", err))
+		to, err := strconv.ParseFloat(r.URL.Query().Get("to"), 64)
+		require.Nil(t, err, fmt.Sprintf("unexpected error parsing to: %s", err))
+
+		page := mgsdk.MessagesPage{Messages: []senml.Message{}}
+		for _, m := range stored {
+			if m.Time >= from && m.Time < to {
+				page.Messages = append(page.Messages, m)
+			}
+		}
+		page.Total = uint64(len(page.Messages))
+		err = json.NewEncoder(w).Encode(page)
+		require.Nil(t, err, fmt.Sprintf("unexpected error encoding page: %s", err))
+	}))
+}
+
+func TestReadMessages(t *testing.T) {
+	start := time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC)
+	v := 21.0
+	stored := senml.Message{
+		Channel:   "channel",
+		Publisher: "publisher",
+		Protocol:  "mqtt",
+		Name:      "temperature",
+		Time:      float64(start.Add(30 * time.Minute).UnixNano()),
+		Value:     &v,
+	}
+	ts := newReadersServer(t, []senml.Message{stored})
+	defer ts.Close()
+	rd := reader.New(mgsdk.NewSDK(mgsdk.Config{ReaderURL: ts.URL}))
+
+	cases := []struct {
+		desc    string
+		secret  string
+		from    time.Time
+		to      time.Time
+		total   uint64
+		created int64
+		payload string
+		err     bool
+	}{
+		{
+			desc:    "read message in replay window",
+			secret:  secret,
+			from:    start,
+			to:      start.Add(time.Hour),
+			total:   1,
+			created: start.Add(30 * time.Minute).UnixNano(),
+			payload: fmt.Sprintf(`[{"n":"temperature","t":%d,"v":21}]`, start.Add(30*time.Minute).Unix()),
+		},
+		{
+			desc:   "read message outside replay window",
+			secret: secret,
+			from:   start.Add(time.Hour),
+			to:     start.Add(2 * time.Hour),
+			total:  0,
+		},
+		{
+			desc:   "read message with invalid secret",
+			secret: "invalid",
+			from:   start,
+			to:     start.Add(time.Hour),
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := rd.ReadMessages(context.Background(), tc.secret, "domain", stored.Channel, "", tc.from, tc.to, 0, 10)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+		if tc.err {
+			continue
+		}
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		require.Len(t, page.Messages, int(tc.total), fmt.Sprintf("%s: expected %d messages got %d\n", tc.desc, tc.total, len(page.Messages)))
+		if tc.total == 0 {
+			continue
+		}
+		msg := page.Messages[0]
+		assert.Equal(t, tc.created, msg.Created, fmt.Sprintf("%s: expected created %d got %d\n", tc.desc, tc.created, msg.Created))
+		assert.Equal(t, stored.Channel, msg.Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, stored.Channel, msg.Channel))
+		assert.Equal(t, stored.Protocol, msg.Protocol, fmt.Sprintf("%s: expected protocol %s got %s\n", tc.desc, stored.Protocol, msg.Protocol))
+		assert.JSONEq(t, tc.payload, string(msg.Payload), fmt.Sprintf("%s: got unexpected payload %s\n", tc.desc, msg.Payload))
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	statsPrefix = "re.stats"
+
+	evaluationsField         = "evaluations"
+	matchesField             = "matches"
+	successesField           = "successes"
+	failuresField            = "failures"
+	consecutiveFailuresField = "consecutive_failures"
+	lastRunAtField           = "last_run_at"
+	lastResultField          = "last_result"
+	lastErrorField           = "last_error"
+)
+
+var _ re.StatsStore = (*statsStore)(nil)
+
+type statsStore struct {
+	client *redis.Client
+}
+
+// NewStatsStore returns Redis store of Rule execution statistics. Statistics
+// of each Rule are kept in a hash, so they're shared by all service instances.
+func NewStatsStore(client *redis.Client) re.StatsStore {
+	return &statsStore{client: client}
+}
+
+func (ss *statsStore) Record(ctx context.Context, ruleID string, run re.Run) (re.Stats, error) {
+	k := statsKey(ruleID)
+	var stats *redis.MapStringStringCmd
+	if _, err := ss.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, k, evaluationsField, 1)
+		switch run.Result {
+		case re.SuccessResult:
+			pipe.HIncrBy(ctx, k, matchesField, 1)
+			pipe.HIncrBy(ctx, k, successesField, 1)
+			pipe.HSet(ctx, k, consecutiveFailuresField, 0)
+		case re.FailureResult:
+			pipe.HIncrBy(ctx, k, matchesField, 1)
+			pipe.HIncrBy(ctx, k, failuresField, 1)
+			pipe.HIncrBy(ctx, k, consecutiveFailuresField, 1)
+		case re.ErrorResult:
+			pipe.HIncrBy(ctx, k, failuresField, 1)
+			pipe.HIncrBy(ctx, k, consecutiveFailuresField, 1)
+		default:
+			pipe.HSet(ctx, k, consecutiveFailuresField, 0)
+		}
+		pipe.HSet(ctx, k,
+			lastRunAtField, run.Time.UnixNano(),
+			lastResultField, string(run.Result),
+			lastErrorField, run.Error,
+		)
+		stats = pipe.HGetAll(ctx, k)
+		return nil
+	}); err != nil {
+		return re.Stats{}, err
+	}
+
+	return decodeStats(ruleID, stats.Val())
+}
+
+func (ss *statsStore) Retrieve(ctx context.Context, ruleID string) (re.Stats, error) {
+	vals, err := ss.client.HGetAll(ctx, statsKey(ruleID)).Result()
+	if err != nil {
+		return re.Stats{}, err
+	}
+
+	return decodeStats(ruleID, vals)
+}
+
+func (ss *statsStore) Remove(ctx context.Context, ruleID string) error {
+	return ss.client.Del(ctx, statsKey(ruleID)).Err()
+}
+
+func statsKey(ruleID string) string {
+	return fmt.Sprintf("%s.%s", statsPrefix, ruleID)
+}
+
+func decodeStats(ruleID string, vals map[string]string) (re.Stats, error) {
+	stats := re.Stats{
+		RuleID:     ruleID,
+		LastResult: re.Result(vals[lastResultField]),
+		LastError:  vals[lastErrorField],
+	}
+	counters := map[string]*uint64{
+		evaluationsField:         &stats.Evaluations,
+		matchesField:             &stats.Matches,
+		successesField:           &stats.Successes,
+		failuresField:            &stats.Failures,
+		consecutiveFailuresField: &stats.ConsecutiveFailures,
+	}
+	for field, counter := range counters {
+		v, ok := vals[field]
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return re.Stats{}, err
+		}
+		*counter = n
+	}
+	if v, ok := vals[lastRunAtField]; ok {
+		ns, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return re.Stats{}, err
+		}
+		stats.LastRunAt = time.Unix(0, ns).UTC()
+	}
+
+	return stats, nil
+}
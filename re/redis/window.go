@@ -0,0 +1,88 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains Redis implementations of the Rule windows and
+// statistics stores.
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "re.window"
+
+var _ re.WindowStore = (*windowStore)(nil)
+
+type windowStore struct {
+	client *redis.Client
+}
+
+// NewWindowStore returns Redis store of Rule windows. Samples of each
+// window are kept in a sorted set scored by the sample time.
+func NewWindowStore(client *redis.Client) re.WindowStore {
+	return &windowStore{client: client}
+}
+
+// member is a sorted set member. Nonce keeps members with the same time and
+// value unique.
+type member struct {
+	re.Sample
+	Nonce string `json:"n"`
+}
+
+func (ws *windowStore) Add(ctx context.Context, key string, s re.Sample, before int64, ttl time.Duration) ([]re.Sample, []re.Sample, error) {
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(member{Sample: s, Nonce: hex.EncodeToString(nonce)})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	k := fmt.Sprintf("%s.%s", keyPrefix, key)
+	bound := fmt.Sprintf("(%d", before)
+	var removed, remaining *redis.StringSliceCmd
+	if _, err := ws.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, k, redis.Z{Score: float64(s.Time), Member: data})
+		removed = pipe.ZRangeByScore(ctx, k, &redis.ZRangeBy{Min: "-inf", Max: bound})
+		pipe.ZRemRangeByScore(ctx, k, "-inf", bound)
+		remaining = pipe.ZRange(ctx, k, 0, -1)
+		pipe.PExpire(ctx, k, ttl)
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	rm, err := decode(removed.Val())
+	if err != nil {
+		return nil, nil, err
+	}
+	rem, err := decode(remaining.Val())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rm, rem, nil
+}
+
+func decode(vals []string) ([]re.Sample, error) {
+	samples := make([]re.Sample, 0, len(vals))
+	for _, v := range vals {
+		var m member
+		if err := json.Unmarshal([]byte(v), &m); err != nil {
+			return nil, err
+		}
+		samples = append(samples, m.Sample)
+	}
+
+	return samples, nil
+}
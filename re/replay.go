@@ -0,0 +1,239 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+const (
+	// replayPageLimit is the number of messages read at once.
+	replayPageLimit = 100
+	// replayChunk is the time range read at once. Readers return the newest
+	// messages first, so the messages of each chunk are reversed in order to
+	// replay them in the order they were created.
+	replayChunk = time.Hour
+	// replayTimeout is the time after which the running replay which made no
+	// progress is considered abandoned, e.g. due to the service restart.
+	replayTimeout = 5 * time.Minute
+)
+
+var (
+	// ErrInvalidReplay indicates that the replay time range is invalid.
+	ErrInvalidReplay = errors.New("invalid replay time range")
+
+	// ErrReplayRunning indicates that the Rule is already being replayed.
+	ErrReplayRunning = errors.New("rule replay is already running")
+)
+
+// ReplayStatus represents the status of the Rule replay.
+type ReplayStatus string
+
+const (
+	ReplayRunning   ReplayStatus = "running"
+	ReplayCompleted ReplayStatus = "completed"
+	ReplayFailed    ReplayStatus = "failed"
+)
+
+// Replay represents the replay of the messages stored in the given time range
+// through the Rule. Results of dry replays are only logged, as the results
+// of dry run Rules. Replays don't affect the Rule windows and statistics.
+type Replay struct {
+	RuleID     string       `json:"rule_id"`
+	From       time.Time    `json:"from"`
+	To         time.Time    `json:"to"`
+	DryRun     bool         `json:"dry_run"`
+	Status     ReplayStatus `json:"status,omitempty"`
+	Total      uint64       `json:"total"`
+	Processed  uint64       `json:"processed"`
+	Matches    uint64       `json:"matches"`
+	Failures   uint64       `json:"failures"`
+	Error      string       `json:"error,omitempty"`
+	StartedAt  time.Time    `json:"started_at,omitempty"`
+	UpdatedAt  time.Time    `json:"updated_at,omitempty"`
+	FinishedAt time.Time    `json:"finished_at,omitempty"`
+}
+
+// Validate checks that the replay time range is valid.
+func (r Replay) Validate() error {
+	if r.From.IsZero() || r.To.IsZero() || !r.From.Before(r.To) {
+		return ErrInvalidReplay
+	}
+
+	return nil
+}
+
+// MessagesPage represents the page of the stored messages.
+type MessagesPage struct {
+	Total    uint64
+	Messages []*messaging.Message
+}
+
+// MessageReader reads the stored messages of the Rule input.
+type MessageReader interface {
+	// ReadMessages returns the messages of the channel and subtopic created
+	// in the given time range, newest first. The secret is the secret of the
+	// domain client which is allowed to read the channel messages.
+	ReadMessages(ctx context.Context, secret, domainID, channel, subtopic string, from, to time.Time, offset, limit uint64) (MessagesPage, error)
+}
+
+func (re *re) ReplayRule(ctx context.Context, session authn.Session, secret, id string, replay Replay) (Replay, error) {
+	if err := replay.Validate(); err != nil {
+		return Replay{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	r, err := re.repo.ViewRule(ctx, id)
+	if err != nil {
+		return Replay{}, err
+	}
+	// Total is read before the replay is started, so invalid secrets and
+	// unavailable readers fail the request.
+	page, err := re.reader.ReadMessages(ctx, secret, r.DomainID, r.InputChannel, r.InputTopic, replay.From, replay.To, 0, 1)
+	if err != nil {
+		return Replay{}, err
+	}
+
+	now := time.Now().UTC()
+	replay.RuleID = id
+	replay.Status = ReplayRunning
+	replay.Total = page.Total
+	replay.StartedAt = now
+	replay.UpdatedAt = now
+	if err := re.repo.StartReplay(ctx, replay, now.Add(-replayTimeout)); err != nil {
+		if errors.Contains(err, repoerr.ErrConflict) {
+			return Replay{}, errors.Wrap(svcerr.ErrConflict, ErrReplayRunning)
+		}
+		return Replay{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	// Replay outlives the request, so it's not canceled with the request context.
+	go re.replay(context.WithoutCancel(ctx), secret, r, replay)
+
+	return replay, nil
+}
+
+func (re *re) ViewReplay(ctx context.Context, session authn.Session, id string) (Replay, error) {
+	if _, err := re.repo.ViewRule(ctx, id); err != nil {
+		return Replay{}, err
+	}
+
+	replay, err := re.repo.ViewReplay(ctx, id)
+	if err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return Replay{}, errors.Wrap(svcerr.ErrNotFound, err)
+		}
+		return Replay{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return replay, nil
+}
+
+// replay reads the messages chunk by chunk and passes them through the Rule.
+// Rule windows are kept in memory, so replays don't interfere with the
+// windows of the messages consumed in the meantime. Progress is saved after
+// every page of messages, so that it's visible to all the service instances.
+func (re *re) replay(ctx context.Context, secret string, r Rule, replay Replay) {
+	rp := *re
+	rp.windows = newReplayWindows()
+	if replay.DryRun {
+		r.DryRun = true
+	}
+
+	var err error
+	for start := replay.From; start.Before(replay.To) && err == nil; start = start.Add(replayChunk) {
+		end := start.Add(replayChunk)
+		if end.After(replay.To) {
+			end = replay.To
+		}
+		var msgs []*messaging.Message
+		if msgs, err = re.readChunk(ctx, secret, r, start, end); err != nil {
+			break
+		}
+		for i := len(msgs) - 1; i >= 0; i-- {
+			res, perr := rp.process(ctx, r, msgs[i], nil)
+			replay.progress(res, perr)
+			if replay.Processed%replayPageLimit == 0 {
+				re.saveReplay(ctx, replay)
+			}
+		}
+	}
+
+	replay.Status = ReplayCompleted
+	replay.FinishedAt = time.Now().UTC()
+	if err != nil {
+		replay.Status = ReplayFailed
+		replay.Error = err.Error()
+		re.logger.Warn("failed to replay rule", slog.String("rule_id", r.ID), slog.Any("error", err))
+	}
+	re.saveReplay(ctx, replay)
+}
+
+// readChunk reads all the messages created in the given time range, newest
+// first.
+func (re *re) readChunk(ctx context.Context, secret string, r Rule, from, to time.Time) ([]*messaging.Message, error) {
+	var msgs []*messaging.Message
+	for {
+		page, err := re.reader.ReadMessages(ctx, secret, r.DomainID, r.InputChannel, r.InputTopic, from, to, uint64(len(msgs)), replayPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, page.Messages...)
+		if len(page.Messages) == 0 || uint64(len(msgs)) >= page.Total {
+			return msgs, nil
+		}
+	}
+}
+
+func (re *re) saveReplay(ctx context.Context, replay Replay) {
+	replay.UpdatedAt = time.Now().UTC()
+	if err := re.repo.UpdateReplay(ctx, replay); err != nil {
+		re.logger.Warn("failed to save rule replay", slog.String("rule_id", replay.RuleID), slog.Any("error", err))
+	}
+}
+
+func (r *Replay) progress(res Result, err error) {
+	r.Processed++
+	switch res {
+	case SuccessResult:
+		r.Matches++
+	case FailureResult:
+		r.Matches++
+		r.Failures++
+	case ErrorResult:
+		r.Failures++
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// replayWindows is the in-memory window store used by Rule replays.
+type replayWindows struct {
+	samples map[string][]Sample
+}
+
+func newReplayWindows() WindowStore {
+	return &replayWindows{samples: make(map[string][]Sample)}
+}
+
+func (ws *replayWindows) Add(_ context.Context, key string, s Sample, before int64, _ time.Duration) ([]Sample, []Sample, error) {
+	var removed, remaining []Sample
+	for _, sample := range append(ws.samples[key], s) {
+		if sample.Time < before {
+			removed = append(removed, sample)
+			continue
+		}
+		remaining = append(remaining, sample)
+	}
+	ws.samples[key] = remaining
+
+	return removed, remaining, nil
+}
@@ -0,0 +1,192 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/stretchr/testify/assert"
+)
+
+const replaySecret = "secret"
+
+var errReadMessages = errors.New("failed to read messages")
+
+type replayRepository struct {
+	Repository
+	rule    Rule
+	mu      *sync.Mutex
+	replays map[string]Replay
+}
+
+func newReplayRepository(rule Rule) replayRepository {
+	return replayRepository{rule: rule, mu: &sync.Mutex{}, replays: make(map[string]Replay)}
+}
+
+func (repo replayRepository) ViewRule(_ context.Context, id string) (Rule, error) {
+	if id != repo.rule.ID {
+		return Rule{}, svcerr.ErrNotFound
+	}
+	return repo.rule, nil
+}
+
+func (repo replayRepository) ListRules(_ context.Context, _ PageMeta) (Page, error) {
+	return Page{}, nil
+}
+
+func (repo replayRepository) StartReplay(_ context.Context, rp Replay, stale time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if cur, ok := repo.replays[rp.RuleID]; ok && cur.Status == ReplayRunning && !cur.UpdatedAt.Before(stale) {
+		return repoerr.ErrConflict
+	}
+	repo.replays[rp.RuleID] = rp
+	return nil
+}
+
+func (repo replayRepository) UpdateReplay(_ context.Context, rp Replay) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.replays[rp.RuleID] = rp
+	return nil
+}
+
+func (repo replayRepository) ViewReplay(_ context.Context, ruleID string) (Replay, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	rp, ok := repo.replays[ruleID]
+	if !ok {
+		return Replay{}, repoerr.ErrNotFound
+	}
+	return rp, nil
+}
+
+// messageReader reads the messages newest first, as the readers service.
+type messageReader []*messaging.Message
+
+func (mr messageReader) ReadMessages(_ context.Context, secret, _, _, _ string, from, to time.Time, offset, limit uint64) (MessagesPage, error) {
+	if secret != replaySecret {
+		return MessagesPage{}, errReadMessages
+	}
+	var msgs []*messaging.Message
+	for i := len(mr) - 1; i >= 0; i-- {
+		if mr[i].Created >= from.UnixNano() && mr[i].Created < to.UnixNano() {
+			msgs = append(msgs, mr[i])
+		}
+	}
+	page := MessagesPage{Total: uint64(len(msgs))}
+	if offset < uint64(len(msgs)) {
+		page.Messages = msgs[offset:min(offset+limit, uint64(len(msgs)))]
+	}
+
+	return page, nil
+}
+
+func TestReplayRule(t *testing.T) {
+	start := time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC)
+	msg := func(offset time.Duration) *messaging.Message {
+		return &messaging.Message{
+			Channel: "input",
+			Created: start.Add(offset).UnixNano(),
+			Payload: []byte(`[{"n":"temperature","v":21}]`),
+		}
+	}
+	// Only the first window holds two messages, so the Rule matches once
+	// if the messages are replayed in the order they were created.
+	reader := messageReader{msg(0), msg(30 * time.Second), msg(70 * time.Second), msg(90 * time.Minute)}
+	rule := Rule{
+		ID:            "rule1",
+		InputChannel:  "input",
+		OutputChannel: "output",
+		Logic:         Script{Value: "if aggregate.count == 2 then return 1 end return nil"},
+		Window:        &Window{Type: TumblingWindow, Aggregation: CountAggregation, Duration: "1m", Field: "temperature"},
+	}
+	// Publishing of dry replay results would fail, since no publish is expected.
+	pubSub := new(mocks.PubSub)
+	stats := memoryStats{}
+	repo := newReplayRepository(rule)
+	svc := NewService(repo, nil, pubSub, nil, stats, reader, discard.NewCounter(), 0, 0, smqlog.NewMock())
+
+	cases := []struct {
+		desc    string
+		secret  string
+		id      string
+		replay  Replay
+		running *Replay
+		res     Replay
+		err     error
+	}{
+		{
+			desc:   "replay rule with invalid time range",
+			secret: replaySecret,
+			id:     rule.ID,
+			replay: Replay{From: start.Add(2 * time.Hour), To: start, DryRun: true},
+			err:    ErrInvalidReplay,
+		},
+		{
+			desc:   "replay non-existing rule",
+			secret: replaySecret,
+			id:     "rule2",
+			replay: Replay{From: start, To: start.Add(2 * time.Hour), DryRun: true},
+			err:    svcerr.ErrNotFound,
+		},
+		{
+			desc:   "replay rule with invalid secret",
+			secret: "invalid",
+			id:     rule.ID,
+			replay: Replay{From: start, To: start.Add(2 * time.Hour), DryRun: true},
+			err:    errReadMessages,
+		},
+		{
+			desc:    "replay rule with running replay",
+			secret:  replaySecret,
+			id:      rule.ID,
+			replay:  Replay{From: start, To: start.Add(2 * time.Hour), DryRun: true},
+			running: &Replay{RuleID: rule.ID, Status: ReplayRunning, UpdatedAt: time.Now()},
+			err:     svcerr.ErrConflict,
+		},
+		{
+			desc:    "replay rule with abandoned replay",
+			secret:  replaySecret,
+			id:      rule.ID,
+			replay:  Replay{From: start, To: start.Add(2 * time.Hour), DryRun: true},
+			running: &Replay{RuleID: rule.ID, Status: ReplayRunning, UpdatedAt: time.Now().Add(-time.Hour)},
+			res:     Replay{RuleID: rule.ID, Status: ReplayCompleted, Total: 4, Processed: 4, Matches: 1},
+		},
+	}
+
+	for _, tc := range cases {
+		if tc.running != nil {
+			err := repo.UpdateReplay(context.Background(), *tc.running)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		}
+		rp, err := svc.ReplayRule(context.Background(), authn.Session{}, tc.secret, tc.id, tc.replay)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tc.res.Total, rp.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.res.Total, rp.Total))
+		assert.Eventually(t, func() bool {
+			rp, err = svc.ViewReplay(context.Background(), authn.Session{}, tc.id)
+			return err == nil && rp.Status != ReplayRunning
+		}, time.Second, 10*time.Millisecond, fmt.Sprintf("%s: expected replay to finish\n", tc.desc))
+		assert.Equal(t, tc.res.Status, rp.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.res.Status, rp.Status))
+		assert.Equal(t, tc.res.Processed, rp.Processed, fmt.Sprintf("%s: expected processed %d got %d\n", tc.desc, tc.res.Processed, rp.Processed))
+		assert.Equal(t, tc.res.Matches, rp.Matches, fmt.Sprintf("%s: expected matches %d got %d\n", tc.desc, tc.res.Matches, rp.Matches))
+		assert.Equal(t, tc.res.Failures, rp.Failures, fmt.Sprintf("%s: expected failures %d got %d\n", tc.desc, tc.res.Failures, rp.Failures))
+		assert.Empty(t, stats[tc.id], fmt.Sprintf("%s: expected replay not to record rule runs\n", tc.desc))
+	}
+}
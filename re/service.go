@@ -5,13 +5,18 @@ package re
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/consumers"
 	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/messaging"
 	mgjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/go-kit/kit/metrics"
 	lua "github.com/yuin/gopher-lua"
 )
 
@@ -47,7 +52,9 @@ type Rule struct {
 	OutputChannel string    `json:"output_channel,omitempty"`
 	OutputTopic   string    `json:"output_topic,omitempty"`
 	Schedule      Schedule  `json:"schedule,omitempty"`
+	Window        *Window   `json:"window,omitempty"`
 	Status        Status    `json:"status"`
+	DryRun        bool      `json:"dry_run"`
 	CreatedAt     time.Time `json:"created_at,omitempty"`
 	CreatedBy     string    `json:"created_by,omitempty"`
 	UpdatedAt     time.Time `json:"updated_at,omitempty"`
@@ -58,8 +65,16 @@ type Repository interface {
 	AddRule(ctx context.Context, r Rule) (Rule, error)
 	ViewRule(ctx context.Context, id string) (Rule, error)
 	UpdateRule(ctx context.Context, r Rule) (Rule, error)
+	UpdateRuleStatus(ctx context.Context, r Rule) (Rule, error)
 	RemoveRule(ctx context.Context, id string) error
 	ListRules(ctx context.Context, pm PageMeta) (Page, error)
+
+	// StartReplay saves the running replay of the Rule. It fails with the
+	// conflict error if another replay of the Rule is running and it was
+	// updated after the stale time.
+	StartReplay(ctx context.Context, rp Replay, stale time.Time) error
+	UpdateReplay(ctx context.Context, rp Replay) error
+	ViewReplay(ctx context.Context, ruleID string) (Replay, error)
 }
 
 // PageMeta contains page metadata that helps navigation.
@@ -86,21 +101,50 @@ type Service interface {
 	UpdateRule(ctx context.Context, session authn.Session, r Rule) (Rule, error)
 	ListRules(ctx context.Context, session authn.Session, pm PageMeta) (Page, error)
 	RemoveRule(ctx context.Context, session authn.Session, id string) error
+	EnableRule(ctx context.Context, session authn.Session, id string) (Rule, error)
+	DisableRule(ctx context.Context, session authn.Session, id string) (Rule, error)
+	RuleStats(ctx context.Context, session authn.Session, id string) (Stats, error)
+	ReplayRule(ctx context.Context, session authn.Session, secret, id string, replay Replay) (Replay, error)
+	ViewReplay(ctx context.Context, session authn.Session, id string) (Replay, error)
 }
 
 type re struct {
-	idp    supermq.IDProvider
-	repo   Repository
-	pubSub messaging.PubSub
-	errors chan error
+	idp         supermq.IDProvider
+	repo        Repository
+	pubSub      messaging.PubSub
+	windows     WindowStore
+	stats       StatsStore
+	reader      MessageReader
+	runs        metrics.Counter
+	maxHops     int
+	maxFailures int
+	logger      *slog.Logger
+	errors      chan error
 }
 
-func NewService(repo Repository, idp supermq.IDProvider, pubSub messaging.PubSub) Service {
+// NewService returns a new Rule Engine service. Messages which would pass
+// through more than maxHops Rules are dropped. Rules which failed on their
+// last maxFailures runs are flagged as failing. Runs are counted by Rule ID
+// and result. Stored messages are read for Rule replays.
+func NewService(repo Repository, idp supermq.IDProvider, pubSub messaging.PubSub, windows WindowStore, stats StatsStore, reader MessageReader, runs metrics.Counter, maxHops, maxFailures int, logger *slog.Logger) Service {
+	if maxHops <= 0 {
+		maxHops = DefMaxHops
+	}
+	if maxFailures <= 0 {
+		maxFailures = DefMaxFailures
+	}
 	return &re{
-		repo:   repo,
-		idp:    idp,
-		pubSub: pubSub,
-		errors: make(chan error),
+		repo:        repo,
+		idp:         idp,
+		pubSub:      pubSub,
+		windows:     windows,
+		stats:       stats,
+		reader:      reader,
+		runs:        runs,
+		maxHops:     maxHops,
+		maxFailures: maxFailures,
+		logger:      logger,
+		errors:      make(chan error),
 	}
 }
 
@@ -130,36 +174,135 @@ func (re *re) ListRules(ctx context.Context, session authn.Session, pm PageMeta)
 }
 
 func (re *re) RemoveRule(ctx context.Context, session authn.Session, id string) error {
-	return re.repo.RemoveRule(ctx, id)
+	if err := re.repo.RemoveRule(ctx, id); err != nil {
+		return err
+	}
+
+	return re.stats.Remove(ctx, id)
+}
+
+func (re *re) EnableRule(ctx context.Context, session authn.Session, id string) (Rule, error) {
+	return re.changeRuleStatus(ctx, session, id, EnabledStatus)
+}
+
+func (re *re) DisableRule(ctx context.Context, session authn.Session, id string) (Rule, error) {
+	return re.changeRuleStatus(ctx, session, id, DisabledStatus)
+}
+
+func (re *re) RuleStats(ctx context.Context, session authn.Session, id string) (Stats, error) {
+	if _, err := re.repo.ViewRule(ctx, id); err != nil {
+		return Stats{}, err
+	}
+	stats, err := re.stats.Retrieve(ctx, id)
+	if err != nil {
+		return Stats{}, err
+	}
+	stats.Failing = stats.ConsecutiveFailures >= uint64(re.maxFailures)
+
+	return stats, nil
+}
+
+func (re *re) changeRuleStatus(ctx context.Context, session authn.Session, id string, status Status) (Rule, error) {
+	r, err := re.repo.ViewRule(ctx, id)
+	if err != nil {
+		return Rule{}, err
+	}
+	if r.Status == status {
+		return Rule{}, errors.ErrStatusAlreadyAssigned
+	}
+	r.Status = status
+	r.UpdatedAt = time.Now()
+	r.UpdatedBy = session.UserID
+
+	return re.repo.UpdateRuleStatus(ctx, r)
 }
 
 func (re *re) ConsumeAsync(ctx context.Context, msgs interface{}) {
 	switch m := msgs.(type) {
 	case *messaging.Message:
-		pm := PageMeta{
-			InputChannel: m.Channel,
-			Status:       EnabledStatus,
-		}
-		page, err := re.repo.ListRules(ctx, pm)
-		if err != nil {
-			re.errors <- err
+		// Messages published by Rules are dispatched to the chained Rules
+		// on publish, so they're not consumed again.
+		if m.Publisher == publisher {
 			return
 		}
-		for _, r := range page.Rules {
-			go func(ctx context.Context) {
-				re.errors <- re.process(ctx, r, m)
-			}(ctx)
+		if err := re.dispatch(ctx, m, nil); err != nil {
+			re.errors <- err
 		}
 	case mgjson.Message:
 	default:
 	}
 }
 
+// dispatch runs the enabled Rules consuming the message channel. The chain
+// holds the Rules which produced the message, and it's empty for the messages
+// published by clients.
+func (re *re) dispatch(ctx context.Context, msg *messaging.Message, chain Chain) error {
+	pm := PageMeta{
+		InputChannel: msg.Channel,
+		Status:       EnabledStatus,
+	}
+	page, err := re.repo.ListRules(ctx, pm)
+	if err != nil {
+		return err
+	}
+	for _, r := range page.Rules {
+		go func(ctx context.Context) {
+			re.errors <- re.run(ctx, r, msg, chain)
+		}(ctx)
+	}
+
+	return nil
+}
+
 func (re *re) Errors() <-chan error {
 	return re.errors
 }
 
-func (re *re) process(ctx context.Context, r Rule, msg *messaging.Message) error {
+// run processes the message and records the result of the Rule run.
+// Messages which don't close the Rule window are not evaluated, so they are
+// not recorded.
+func (re *re) run(ctx context.Context, r Rule, msg *messaging.Message, chain Chain) error {
+	res, err := re.process(ctx, r, msg, chain)
+	if res == "" {
+		return err
+	}
+	run := Run{
+		Time:   time.Now().UTC(),
+		Result: res,
+	}
+	if err != nil {
+		run.Error = err.Error()
+	}
+	re.runs.With("rule_id", r.ID, "result", string(res)).Add(1)
+	stats, serr := re.stats.Record(ctx, r.ID, run)
+	if serr != nil {
+		re.logger.Warn("failed to record rule run", slog.String("rule_id", r.ID), slog.Any("error", serr))
+		return err
+	}
+	if stats.ConsecutiveFailures == uint64(re.maxFailures) {
+		re.logger.Warn("rule is failing",
+			slog.String("rule_id", r.ID),
+			slog.Uint64("consecutive_failures", stats.ConsecutiveFailures),
+			slog.String("last_error", stats.LastError),
+		)
+	}
+
+	return err
+}
+
+func (re *re) process(ctx context.Context, r Rule, msg *messaging.Message, chain Chain) (Result, error) {
+	var agg Aggregate
+	if r.Window != nil {
+		var ok bool
+		var err error
+		if agg, ok, err = re.window(ctx, r, msg); err != nil {
+			return ErrorResult, err
+		}
+		if !ok {
+			return "", nil
+		}
+	}
+
 	l := lua.NewState()
 	defer l.Close()
 
@@ -180,23 +323,69 @@ func (re *re) process(ctx context.Context, r Rule, msg *messaging.Message) error
 	// Set the message object as a Lua global variable.
 	l.SetGlobal("message", message)
 
+	if r.Window != nil {
+		aggregate := l.NewTable()
+		l.RawSet(aggregate, lua.LString("value"), lua.LNumber(agg.Value(r.Window.Aggregation)))
+		l.RawSet(aggregate, lua.LString("count"), lua.LNumber(agg.Count))
+		l.RawSet(aggregate, lua.LString("sum"), lua.LNumber(agg.Sum))
+		l.RawSet(aggregate, lua.LString("min"), lua.LNumber(agg.Min))
+		l.RawSet(aggregate, lua.LString("max"), lua.LNumber(agg.Max))
+		l.RawSet(aggregate, lua.LString("avg"), lua.LNumber(agg.Avg))
+		l.RawSet(aggregate, lua.LString("start"), lua.LNumber(agg.Start))
+		l.RawSet(aggregate, lua.LString("end"), lua.LNumber(agg.End))
+		l.SetGlobal("aggregate", aggregate)
+	}
+
 	if err := l.DoString(string(r.Logic.Value)); err != nil {
-		return err
+		return ErrorResult, err
 	}
 
 	result := l.Get(-1) // Get the last result
 	switch result {
 	case lua.LNil:
-		return nil
+		return NoMatchResult, nil
 	default:
 		if len(r.OutputChannel) == 0 {
-			return nil
+			return SuccessResult, nil
 		}
-		m := &messaging.Message{
-			Publisher: "magistrala.re",
-			Created:   time.Now().Unix(),
-			Payload:   []byte(result.String()),
+		if err := re.publish(ctx, r, msg, chain, []byte(result.String())); err != nil {
+			return FailureResult, err
 		}
-		return re.pubSub.Publish(ctx, m.Channel, m)
+		return SuccessResult, nil
+	}
+}
+
+// publish sends the Rule result to the Rule output channel, and dispatches it
+// to the Rules consuming that channel. The chain of Rules which produced the
+// result is kept in process, so the published message keeps the protocol of
+// the original message. Results are dropped once the chain exceeds max hops,
+// and results of dry run Rules are only logged.
+func (re *re) publish(ctx context.Context, r Rule, msg *messaging.Message, chain Chain, payload []byte) error {
+	chain = append(slices.Clone(chain), r.ID)
+	if len(chain) > re.maxHops {
+		return errors.Wrap(ErrMaxHops, fmt.Errorf("dropped message of rule chain %s", chain))
 	}
+
+	m := &messaging.Message{
+		Channel:   r.OutputChannel,
+		Subtopic:  r.OutputTopic,
+		Publisher: publisher,
+		Protocol:  msg.Protocol,
+		Created:   time.Now().UnixNano(),
+		Payload:   payload,
+	}
+	if r.DryRun {
+		re.logger.Info("dry run rule would publish message",
+			slog.String("rule_id", r.ID),
+			slog.String("channel", m.Channel),
+			slog.String("subtopic", m.Subtopic),
+			slog.String("payload", string(m.Payload)),
+		)
+		return nil
+	}
+	if err := re.pubSub.Publish(ctx, m.Channel, m); err != nil {
+		return err
+	}
+
+	return re.dispatch(ctx, m, chain)
 }
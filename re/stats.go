@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"time"
+)
+
+// DefMaxFailures is the default number of consecutive failed runs after
+// which a Rule is flagged as failing.
+const DefMaxFailures = 5
+
+// Result represents the outcome of a single Rule run.
+type Result string
+
+const (
+	// NoMatchResult indicates that the Rule logic returned no result.
+	NoMatchResult Result = "no_match"
+	// SuccessResult indicates that the Rule logic returned a result which
+	// was handled, i.e. published to the output channel.
+	SuccessResult Result = "success"
+	// FailureResult indicates that the Rule logic returned a result which
+	// could not be published.
+	FailureResult Result = "failure"
+	// ErrorResult indicates that the Rule logic could not be evaluated.
+	ErrorResult Result = "error"
+)
+
+// Run represents a single evaluation of the Rule logic.
+type Run struct {
+	Time   time.Time
+	Result Result
+	Error  string
+}
+
+// Stats represents Rule execution statistics. Matches count the runs which
+// returned a result, and Failures count both failed and errored runs.
+type Stats struct {
+	RuleID              string    `json:"rule_id"`
+	Evaluations         uint64    `json:"evaluations"`
+	Matches             uint64    `json:"matches"`
+	Successes           uint64    `json:"successes"`
+	Failures            uint64    `json:"failures"`
+	ConsecutiveFailures uint64    `json:"consecutive_failures"`
+	LastRunAt           time.Time `json:"last_run_at,omitempty"`
+	LastResult          Result    `json:"last_result,omitempty"`
+	LastError           string    `json:"last_error,omitempty"`
+	// Failing is set once the Rule failed on its configured number of
+	// last runs, and it's reset by the first run which doesn't fail.
+	Failing bool `json:"failing"`
+}
+
+// StatsStore keeps Rule execution statistics.
+type StatsStore interface {
+	// Record adds the run to the statistics of the Rule.
+	Record(ctx context.Context, ruleID string, run Run) (Stats, error)
+
+	// Retrieve returns the statistics of the Rule.
+	Retrieve(ctx context.Context, ruleID string) (Stats, error)
+
+	// Remove removes the statistics of the Rule.
+	Remove(ctx context.Context, ruleID string) error
+}
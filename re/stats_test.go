@@ -0,0 +1,137 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var errPublish = errors.New("failed to publish")
+
+type statsRepository struct {
+	Repository
+}
+
+func (repo statsRepository) ViewRule(_ context.Context, id string) (Rule, error) {
+	return Rule{ID: id}, nil
+}
+
+func (repo statsRepository) ListRules(_ context.Context, _ PageMeta) (Page, error) {
+	return Page{}, nil
+}
+
+type memoryStats map[string]Stats
+
+func (ms memoryStats) Record(_ context.Context, ruleID string, run Run) (Stats, error) {
+	stats := ms[ruleID]
+	stats.RuleID = ruleID
+	stats.Evaluations++
+	switch run.Result {
+	case SuccessResult:
+		stats.Matches++
+		stats.Successes++
+		stats.ConsecutiveFailures = 0
+	case FailureResult:
+		stats.Matches++
+		stats.Failures++
+		stats.ConsecutiveFailures++
+	case ErrorResult:
+		stats.Failures++
+		stats.ConsecutiveFailures++
+	default:
+		stats.ConsecutiveFailures = 0
+	}
+	stats.LastRunAt = run.Time
+	stats.LastResult = run.Result
+	stats.LastError = run.Error
+	ms[ruleID] = stats
+
+	return stats, nil
+}
+
+func (ms memoryStats) Retrieve(_ context.Context, ruleID string) (Stats, error) {
+	return ms[ruleID], nil
+}
+
+func (ms memoryStats) Remove(_ context.Context, ruleID string) error {
+	delete(ms, ruleID)
+	return nil
+}
+
+func TestRunStats(t *testing.T) {
+	pubSub := new(mocks.PubSub)
+	pubSub.On("Publish", context.Background(), "output", mock.Anything).Return(errPublish)
+	stats := memoryStats{}
+	svc := NewService(statsRepository{}, nil, pubSub, nil, stats, nil, discard.NewCounter(), 0, 2, smqlog.NewMock()).(*re)
+
+	cases := []struct {
+		desc    string
+		rule    Rule
+		err     error
+		stats   Stats
+		failing bool
+	}{
+		{
+			desc:  "run rule which doesn't match",
+			rule:  Rule{ID: "rule1", Logic: Script{Value: "return nil"}},
+			stats: Stats{Evaluations: 1, LastResult: NoMatchResult},
+		},
+		{
+			desc:  "run rule which matches",
+			rule:  Rule{ID: "rule1", Logic: Script{Value: "return 1"}},
+			stats: Stats{Evaluations: 2, Matches: 1, Successes: 1, LastResult: SuccessResult},
+		},
+		{
+			desc:  "run rule which fails to publish",
+			rule:  Rule{ID: "rule1", Logic: Script{Value: "return 1"}, OutputChannel: "output"},
+			err:   errPublish,
+			stats: Stats{Evaluations: 3, Matches: 2, Successes: 1, Failures: 1, ConsecutiveFailures: 1, LastResult: FailureResult, LastError: errPublish.Error()},
+		},
+		{
+			desc:    "run rule with invalid logic",
+			rule:    Rule{ID: "rule1", Logic: Script{Value: "return 1 +"}},
+			stats:   Stats{Evaluations: 4, Matches: 2, Successes: 1, Failures: 2, ConsecutiveFailures: 2, LastResult: ErrorResult},
+			failing: true,
+		},
+		{
+			desc:  "run rule which recovers",
+			rule:  Rule{ID: "rule1", Logic: Script{Value: "return nil"}},
+			stats: Stats{Evaluations: 5, Matches: 2, Successes: 1, Failures: 2, LastResult: NoMatchResult},
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.run(context.Background(), tc.rule, &messaging.Message{Channel: "input"}, nil)
+		if tc.err != nil {
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		}
+		if tc.stats.LastResult == ErrorResult {
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error got nil\n", tc.desc))
+		}
+		s, err := svc.RuleStats(context.Background(), authn.Session{}, tc.rule.ID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.stats.Evaluations, s.Evaluations, fmt.Sprintf("%s: expected evaluations %d got %d\n", tc.desc, tc.stats.Evaluations, s.Evaluations))
+		assert.Equal(t, tc.stats.Matches, s.Matches, fmt.Sprintf("%s: expected matches %d got %d\n", tc.desc, tc.stats.Matches, s.Matches))
+		assert.Equal(t, tc.stats.Successes, s.Successes, fmt.Sprintf("%s: expected successes %d got %d\n", tc.desc, tc.stats.Successes, s.Successes))
+		assert.Equal(t, tc.stats.Failures, s.Failures, fmt.Sprintf("%s: expected failures %d got %d\n", tc.desc, tc.stats.Failures, s.Failures))
+		assert.Equal(t, tc.stats.ConsecutiveFailures, s.ConsecutiveFailures, fmt.Sprintf("%s: expected consecutive failures %d got %d\n", tc.desc, tc.stats.ConsecutiveFailures, s.ConsecutiveFailures))
+		assert.Equal(t, tc.stats.LastResult, s.LastResult, fmt.Sprintf("%s: expected last result %s got %s\n", tc.desc, tc.stats.LastResult, s.LastResult))
+		if tc.stats.LastError != "" {
+			assert.Equal(t, tc.stats.LastError, s.LastError, fmt.Sprintf("%s: expected last error %s got %s\n", tc.desc, tc.stats.LastError, s.LastError))
+		}
+		assert.False(t, s.LastRunAt.IsZero(), fmt.Sprintf("%s: expected last run time to be set\n", tc.desc))
+		assert.Equal(t, tc.failing, s.Failing, fmt.Sprintf("%s: expected failing %t got %t\n", tc.desc, tc.failing, s.Failing))
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+// WindowType represents the type of the Rule window.
+type WindowType string
+
+const (
+	// TumblingWindow splits time into fixed, non-overlapping windows. Rule
+	// logic is evaluated once per window, when the first message of the
+	// next window arrives.
+	TumblingWindow WindowType = "tumbling"
+	// SlidingWindow evaluates Rule logic on every message, over messages
+	// received within the window duration before it.
+	SlidingWindow WindowType = "sliding"
+)
+
+// Aggregation represents the function applied to the values of a window.
+type Aggregation string
+
+const (
+	AvgAggregation   Aggregation = "avg"
+	MinAggregation   Aggregation = "min"
+	MaxAggregation   Aggregation = "max"
+	CountAggregation Aggregation = "count"
+	SumAggregation   Aggregation = "sum"
+)
+
+const (
+	// PublisherGroup keeps a separate window per message publisher.
+	PublisherGroup = "publisher"
+	// SubtopicGroup keeps a separate window per message subtopic.
+	SubtopicGroup = "subtopic"
+)
+
+// ErrInvalidWindow indicates malformed Rule window.
+var ErrInvalidWindow = errors.New("invalid rule window")
+
+// Window aggregates message values over time, so that Rule logic is
+// evaluated against the aggregate instead of a single message.
+type Window struct {
+	Type        WindowType  `json:"type"`
+	Aggregation Aggregation `json:"aggregation"`
+	// Duration of the window, e.g. "5m".
+	Duration string `json:"duration"`
+	// Field is the payload field holding the value. For JSON objects, it
+	// is the name of the numeric field and for SenML packs it is the name
+	// of the record.
+	Field string `json:"field"`
+	// GroupBy optionally keeps a separate window per publisher or subtopic.
+	// By default, a single window is kept per Rule.
+	GroupBy string `json:"group_by,omitempty"`
+}
+
+// Validate checks if the window is well-formed.
+func (w Window) Validate() error {
+	switch w.Type {
+	case TumblingWindow, SlidingWindow:
+	default:
+		return ErrInvalidWindow
+	}
+	switch w.Aggregation {
+	case AvgAggregation, MinAggregation, MaxAggregation, CountAggregation, SumAggregation:
+	default:
+		return ErrInvalidWindow
+	}
+	if d, err := time.ParseDuration(w.Duration); err != nil || d <= 0 {
+		return ErrInvalidWindow
+	}
+	if w.Field == "" {
+		return ErrInvalidWindow
+	}
+	switch w.GroupBy {
+	case "", PublisherGroup, SubtopicGroup:
+		return nil
+	default:
+		return ErrInvalidWindow
+	}
+}
+
+// Sample represents a single value added to the window.
+type Sample struct {
+	// Time is the message creation time in nanoseconds.
+	Time  int64   `json:"t"`
+	Value float64 `json:"v"`
+}
+
+// Aggregate represents the aggregated values of a window.
+type Aggregate struct {
+	Count uint64
+	Sum   float64
+	Min   float64
+	Max   float64
+	Avg   float64
+	// Start and End are window bounds in nanoseconds.
+	Start int64
+	End   int64
+}
+
+// Value returns the aggregate value for the given aggregation.
+func (a Aggregate) Value(agg Aggregation) float64 {
+	switch agg {
+	case MinAggregation:
+		return a.Min
+	case MaxAggregation:
+		return a.Max
+	case CountAggregation:
+		return float64(a.Count)
+	case SumAggregation:
+		return a.Sum
+	default:
+		return a.Avg
+	}
+}
+
+// WindowStore keeps the samples of Rule windows.
+type WindowStore interface {
+	// Add stores the sample in the window identified by the key and removes
+	// the samples older than the given time in nanoseconds. It returns the
+	// removed and the remaining samples. Windows which don't receive any
+	// samples are evicted after the TTL.
+	Add(ctx context.Context, key string, s Sample, before int64, ttl time.Duration) (removed, remaining []Sample, err error)
+}
+
+// Method window adds the message value to the Rule window and returns the
+// aggregate that the Rule logic is evaluated against. It reports false if
+// the message has no value or there is nothing to evaluate yet.
+func (re *re) window(ctx context.Context, r Rule, msg *messaging.Message) (Aggregate, bool, error) {
+	w := *r.Window
+	value, ok := sampleValue(msg.Payload, w.Field)
+	if !ok {
+		return Aggregate{}, false, nil
+	}
+	d, err := time.ParseDuration(w.Duration)
+	if err != nil || d <= 0 {
+		return Aggregate{}, false, ErrInvalidWindow
+	}
+	created := msg.Created
+	if created == 0 {
+		created = time.Now().UnixNano()
+	}
+	s := Sample{Time: created, Value: value}
+	key := windowKey(r, msg)
+
+	switch w.Type {
+	case SlidingWindow:
+		_, remaining, err := re.windows.Add(ctx, key, s, created-d.Nanoseconds(), d)
+		if err != nil {
+			return Aggregate{}, false, err
+		}
+		agg := aggregate(remaining)
+		agg.Start, agg.End = created-d.Nanoseconds(), created
+		return agg, true, nil
+	case TumblingWindow:
+		// Samples of the previous windows are removed once the sample of the
+		// current window arrives, which closes the previous window.
+		start := created - created%d.Nanoseconds()
+		removed, _, err := re.windows.Add(ctx, key, s, start, 2*d)
+		if err != nil {
+			return Aggregate{}, false, err
+		}
+		if len(removed) == 0 {
+			return Aggregate{}, false, nil
+		}
+		agg := aggregate(removed)
+		agg.Start = removed[0].Time - removed[0].Time%d.Nanoseconds()
+		agg.End = agg.Start + d.Nanoseconds()
+		return agg, true, nil
+	default:
+		return Aggregate{}, false, ErrInvalidWindow
+	}
+}
+
+func windowKey(r Rule, msg *messaging.Message) string {
+	switch r.Window.GroupBy {
+	case PublisherGroup:
+		return r.ID + ":" + msg.Publisher
+	case SubtopicGroup:
+		return r.ID + ":" + msg.Subtopic
+	default:
+		return r.ID
+	}
+}
+
+func aggregate(samples []Sample) Aggregate {
+	agg := Aggregate{
+		Min: math.Inf(1),
+		Max: math.Inf(-1),
+	}
+	for _, s := range samples {
+		agg.Count++
+		agg.Sum += s.Value
+		agg.Min = math.Min(agg.Min, s.Value)
+		agg.Max = math.Max(agg.Max, s.Value)
+	}
+	if agg.Count == 0 {
+		return Aggregate{}
+	}
+	agg.Avg = agg.Sum / float64(agg.Count)
+
+	return agg
+}
+
+// sampleValue extracts the value of the field from the JSON object or
+// SenML pack payload.
+func sampleValue(payload []byte, field string) (float64, bool) {
+	var pld interface{}
+	if err := json.Unmarshal(payload, &pld); err != nil {
+		return 0, false
+	}
+
+	switch p := pld.(type) {
+	case map[string]interface{}:
+		v, ok := p[field].(float64)
+		return v, ok
+	case []interface{}:
+		// Use the last matching record of the SenML pack.
+		var value float64
+		var found bool
+		for _, r := range p {
+			rec, ok := r.(map[string]interface{})
+			if !ok || rec["n"] != field {
+				continue
+			}
+			if v, ok := rec["v"].(float64); ok {
+				value, found = v, true
+			}
+		}
+		return value, found
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+type windowStore struct {
+	mu      sync.Mutex
+	windows map[string][]Sample
+}
+
+func (ws *windowStore) Add(_ context.Context, key string, s Sample, before int64, _ time.Duration) ([]Sample, []Sample, error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	var removed, remaining []Sample
+	for _, sample := range append(ws.windows[key], s) {
+		if sample.Time < before {
+			removed = append(removed, sample)
+			continue
+		}
+		remaining = append(remaining, sample)
+	}
+	ws.windows[key] = remaining
+
+	return removed, remaining, nil
+}
+
+func TestWindowValidate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		window Window
+		err    error
+	}{
+		{
+			desc:   "valid tumbling window",
+			window: Window{Type: TumblingWindow, Aggregation: AvgAggregation, Duration: "5m", Field: "temperature"},
+			err:    nil,
+		},
+		{
+			desc:   "valid sliding window grouped by publisher",
+			window: Window{Type: SlidingWindow, Aggregation: MaxAggregation, Duration: "30s", Field: "temperature", GroupBy: PublisherGroup},
+			err:    nil,
+		},
+		{
+			desc:   "invalid window type",
+			window: Window{Type: "hopping", Aggregation: AvgAggregation, Duration: "5m", Field: "temperature"},
+			err:    ErrInvalidWindow,
+		},
+		{
+			desc:   "invalid aggregation",
+			window: Window{Type: TumblingWindow, Aggregation: "median", Duration: "5m", Field: "temperature"},
+			err:    ErrInvalidWindow,
+		},
+		{
+			desc:   "invalid duration",
+			window: Window{Type: TumblingWindow, Aggregation: AvgAggregation, Duration: "five minutes", Field: "temperature"},
+			err:    ErrInvalidWindow,
+		},
+		{
+			desc:   "negative duration",
+			window: Window{Type: TumblingWindow, Aggregation: AvgAggregation, Duration: "-5m", Field: "temperature"},
+			err:    ErrInvalidWindow,
+		},
+		{
+			desc:   "empty field",
+			window: Window{Type: TumblingWindow, Aggregation: AvgAggregation, Duration: "5m"},
+			err:    ErrInvalidWindow,
+		},
+		{
+			desc:   "invalid group",
+			window: Window{Type: TumblingWindow, Aggregation: AvgAggregation, Duration: "5m", Field: "temperature", GroupBy: "protocol"},
+			err:    ErrInvalidWindow,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.window.Validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSampleValue(t *testing.T) {
+	cases := []struct {
+		desc    string
+		payload string
+		value   float64
+		ok      bool
+	}{
+		{
+			desc:    "value of JSON object field",
+			payload: `{"temperature": 21.5, "humidity": 40}`,
+			value:   21.5,
+			ok:      true,
+		},
+		{
+			desc:    "value of SenML record",
+			payload: `[{"bn": "sensor:", "n": "humidity", "v": 40}, {"n": "temperature", "v": 21.5}]`,
+			value:   21.5,
+			ok:      true,
+		},
+		{
+			desc:    "missing field",
+			payload: `{"humidity": 40}`,
+			ok:      false,
+		},
+		{
+			desc:    "non-numeric field",
+			payload: `{"temperature": "hot"}`,
+			ok:      false,
+		},
+		{
+			desc:    "malformed payload",
+			payload: `temperature=21.5`,
+			ok:      false,
+		},
+	}
+
+	for _, tc := range cases {
+		value, ok := sampleValue([]byte(tc.payload), "temperature")
+		assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.ok, ok))
+		assert.Equal(t, tc.value, value, fmt.Sprintf("%s: expected %f got %f\n", tc.desc, tc.value, value))
+	}
+}
+
+func TestWindow(t *testing.T) {
+	svc := &re{windows: &windowStore{windows: map[string][]Sample{}}}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	second := time.Second.Nanoseconds()
+
+	tumbling := Rule{ID: "tumbling", Window: &Window{Type: TumblingWindow, Aggregation: AvgAggregation, Duration: "10s", Field: "temperature"}}
+	sliding := Rule{ID: "sliding", Window: &Window{Type: SlidingWindow, Aggregation: MaxAggregation, Duration: "10s", Field: "temperature", GroupBy: PublisherGroup}}
+
+	cases := []struct {
+		desc      string
+		rule      Rule
+		publisher string
+		created   int64
+		payload   string
+		ok        bool
+		aggregate Aggregate
+	}{
+		{
+			desc:    "add first value to tumbling window",
+			rule:    tumbling,
+			created: start,
+			payload: `{"temperature": 10}`,
+			ok:      false,
+		},
+		{
+			desc:    "add value without field to tumbling window",
+			rule:    tumbling,
+			created: start + second,
+			payload: `{"humidity": 40}`,
+			ok:      false,
+		},
+		{
+			desc:    "add second value to tumbling window",
+			rule:    tumbling,
+			created: start + 5*second,
+			payload: `{"temperature": 20}`,
+			ok:      false,
+		},
+		{
+			desc:      "close tumbling window",
+			rule:      tumbling,
+			created:   start + 12*second,
+			payload:   `{"temperature": 100}`,
+			ok:        true,
+			aggregate: Aggregate{Count: 2, Sum: 30, Min: 10, Max: 20, Avg: 15, Start: start, End: start + 10*second},
+		},
+		{
+			desc:      "add first value to sliding window",
+			rule:      sliding,
+			publisher: "publisher1",
+			created:   start,
+			payload:   `{"temperature": 10}`,
+			ok:        true,
+			aggregate: Aggregate{Count: 1, Sum: 10, Min: 10, Max: 10, Avg: 10, Start: start - 10*second, End: start},
+		},
+		{
+			desc:      "add value of another publisher to sliding window",
+			rule:      sliding,
+			publisher: "publisher2",
+			created:   start + second,
+			payload:   `{"temperature": 50}`,
+			ok:        true,
+			aggregate: Aggregate{Count: 1, Sum: 50, Min: 50, Max: 50, Avg: 50, Start: start - 9*second, End: start + second},
+		},
+		{
+			desc:      "add second value to sliding window",
+			rule:      sliding,
+			publisher: "publisher1",
+			created:   start + 5*second,
+			payload:   `{"temperature": 20}`,
+			ok:        true,
+			aggregate: Aggregate{Count: 2, Sum: 30, Min: 10, Max: 20, Avg: 15, Start: start - 5*second, End: start + 5*second},
+		},
+		{
+			desc:      "slide window past the first value",
+			rule:      sliding,
+			publisher: "publisher1",
+			created:   start + 12*second,
+			payload:   `{"temperature": 5}`,
+			ok:        true,
+			aggregate: Aggregate{Count: 2, Sum: 25, Min: 5, Max: 20, Avg: 12.5, Start: start + 2*second, End: start + 12*second},
+		},
+	}
+
+	for _, tc := range cases {
+		msg := &messaging.Message{
+			Publisher: tc.publisher,
+			Created:   tc.created,
+			Payload:   []byte(tc.payload),
+		}
+		agg, ok, err := svc.window(context.Background(), tc.rule, msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.ok, ok))
+		assert.Equal(t, tc.aggregate, agg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.aggregate, agg))
+	}
+}
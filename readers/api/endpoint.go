@@ -6,13 +6,13 @@ package api
 import (
 	"context"
 
+	"github.com/absmach/magistrala/readers"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
-	"github.com/absmach/supermq/readers"
 	"github.com/go-kit/kit/endpoint"
 )
 
@@ -29,6 +29,10 @@ func listMessagesEndpoint(svc readers.MessageRepository, authn smqauthn.Authenti
 
 		page, err := svc.ReadAll(req.chanID, req.pageMeta)
 		if err != nil {
+			// Cursors issued by other readers are detected only by the repository.
+			if errors.Contains(err, readers.ErrInvalidCursor) {
+				return nil, errors.Wrap(apiutil.ErrValidation, err)
+			}
 			return nil, err
 		}
 
@@ -36,6 +40,46 @@ func listMessagesEndpoint(svc readers.MessageRepository, authn smqauthn.Authenti
 			PageMetadata: page.PageMetadata,
 			Total:        page.Total,
 			Messages:     page.Messages,
+			NextCursor:   page.NextCursor,
+		}, nil
+	}
+}
+
+func countMessagesEndpoint(svc readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(countMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := authnAuthz(ctx, req.listMessagesReq, authn, clients, channels); err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+
+		count, err := svc.CountMessages(req.chanID, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return countRes{MessagesCount: count}, nil
+	}
+}
+
+func exportMessagesEndpoint(authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := authnAuthz(ctx, req.listMessagesReq, authn, clients, channels); err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+
+		return exportRes{
+			chanID:   req.chanID,
+			pageMeta: req.pageMeta,
+			output:   req.output,
 		}, nil
 	}
 }
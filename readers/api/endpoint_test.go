@@ -6,24 +6,29 @@ package api_test
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/ratelimit"
+	rlmocks "github.com/absmach/magistrala/pkg/ratelimit/mocks"
+	"github.com/absmach/magistrala/readers"
+	"github.com/absmach/magistrala/readers/api"
+	"github.com/absmach/magistrala/readers/mocks"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	climocks "github.com/absmach/supermq/clients/mocks"
+	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/transformers/senml"
-	"github.com/absmach/supermq/readers"
-	"github.com/absmach/supermq/readers/api"
-	"github.com/absmach/supermq/readers/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -54,7 +59,7 @@ var (
 )
 
 func newServer(repo *mocks.MessageRepository, authn *authnmocks.Authentication, clients *climocks.ClientsServiceClient, channels *chmocks.ChannelsServiceClient) *httptest.Server {
-	mux := api.MakeHandler(repo, authn, clients, channels, svcName, instanceID)
+	mux := api.MakeHandler(repo, authn, clients, channels, ratelimit.Middleware(nil, ratelimit.Config{}, smqlog.NewMock()), svcName, instanceID)
 	return httptest.NewServer(mux)
 }
 
@@ -138,6 +143,9 @@ func TestReadAll(t *testing.T) {
 	ts := newServer(repo, authn, clients, channels)
 	defer ts.Close()
 
+	cursor := readers.Cursor{Time: messages[9].Time, Keys: []string{testsutil.GenerateUUID(t)}}.Encode()
+	otherCursor := readers.Cursor{Time: messages[9].Time, Keys: []string{pubID, "subtopic", "name"}}.Encode()
+
 	cases := []struct {
 		desc         string
 		req          string
@@ -149,6 +157,7 @@ func TestReadAll(t *testing.T) {
 		res          pageRes
 		authnErr     error
 		err          error
+		repoErr      error
 	}{
 		{
 			desc:         "read page with valid offset and limit",
@@ -542,6 +551,13 @@ func TestReadAll(t *testing.T) {
 			authResponse: true,
 			status:       http.StatusBadRequest,
 		},
+		{
+			desc:         "read page with aggregation of JSON messages as client",
+			url:          fmt.Sprintf("%s/channels/%s/messages?aggregation=MAX&interval=10h&from=%f&to=%f&format=json", ts.URL, chanID, messages[19].Time, messages[4].Time),
+			key:          clientToken,
+			authResponse: true,
+			status:       http.StatusBadRequest,
+		},
 		{
 			desc:         "read page with aggregation, interval and to with missing from as client",
 			url:          fmt.Sprintf("%s/channels/%s/messages?aggregation=MAX&interval=10h&to=%f", ts.URL, chanID, messages[4].Time),
@@ -936,6 +952,32 @@ func TestReadAll(t *testing.T) {
 				Messages:     messages[5:15],
 			},
 		},
+		{
+			desc:         "read page with aggregation grouped by publisher as user",
+			url:          fmt.Sprintf("%s/channels/%s/messages?aggregation=MAX&interval=10h&from=%f&to=%f&group_by=publisher", ts.URL, chanID, messages[19].Time, messages[4].Time),
+			key:          userToken,
+			authResponse: true,
+			status:       http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 10, Format: "messages", Aggregation: "MAX", Interval: "10h", From: messages[19].Time, To: messages[4].Time, GroupBy: "publisher"},
+				Total:        uint64(len(messages[5:20])),
+				Messages:     messages[5:15],
+			},
+		},
+		{
+			desc:         "read page with aggregation grouped by invalid group as user",
+			url:          fmt.Sprintf("%s/channels/%s/messages?aggregation=MAX&interval=10h&from=%f&to=%f&group_by=invalid", ts.URL, chanID, messages[19].Time, messages[4].Time),
+			key:          userToken,
+			authResponse: true,
+			status:       http.StatusBadRequest,
+		},
+		{
+			desc:         "read page grouped by subtopic without aggregation as user",
+			url:          fmt.Sprintf("%s/channels/%s/messages?group_by=subtopic", ts.URL, chanID),
+			key:          userToken,
+			authResponse: true,
+			status:       http.StatusBadRequest,
+		},
 		{
 			desc:         "read page with invalid aggregation and valid interval, to and from as user",
 			url:          fmt.Sprintf("%s/channels/%s/messages?aggregation=invalid&interval=10h&from=%f&to=%f", ts.URL, chanID, messages[19].Time, messages[4].Time),
@@ -971,6 +1013,50 @@ func TestReadAll(t *testing.T) {
 			authResponse: true,
 			status:       http.StatusBadRequest,
 		},
+		{
+			desc:         "read page with cursor",
+			url:          fmt.Sprintf("%s/channels/%s/messages?limit=10&cursor=%s", ts.URL, chanID, cursor),
+			token:        userToken,
+			authResponse: true,
+			status:       http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 10, Format: "messages", Cursor: cursor},
+				Total:        uint64(len(messages)),
+				Messages:     messages[10:20],
+			},
+		},
+		{
+			desc:         "read page with invalid cursor",
+			url:          fmt.Sprintf("%s/channels/%s/messages?limit=10&cursor=invalid", ts.URL, chanID),
+			token:        userToken,
+			authResponse: true,
+			status:       http.StatusBadRequest,
+		},
+		{
+			desc:         "read page with cursor of other reader",
+			url:          fmt.Sprintf("%s/channels/%s/messages?limit=10&cursor=%s", ts.URL, chanID, otherCursor),
+			token:        userToken,
+			authResponse: true,
+			status:       http.StatusBadRequest,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 10, Format: "messages", Cursor: otherCursor},
+			},
+			repoErr: readers.ErrInvalidCursor,
+		},
+		{
+			desc:         "read page with cursor and offset",
+			url:          fmt.Sprintf("%s/channels/%s/messages?offset=10&limit=10&cursor=%s", ts.URL, chanID, cursor),
+			token:        userToken,
+			authResponse: true,
+			status:       http.StatusBadRequest,
+		},
+		{
+			desc:         "read page with cursor and aggregation",
+			url:          fmt.Sprintf("%s/channels/%s/messages?limit=10&aggregation=MAX&interval=10h&from=%f&to=%f&cursor=%s", ts.URL, chanID, messages[19].Time, messages[4].Time, cursor),
+			token:        userToken,
+			authResponse: true,
+			status:       http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range cases {
@@ -981,7 +1067,7 @@ func TestReadAll(t *testing.T) {
 			}).Return(&grpcClientsV1.AuthnRes{Id: testsutil.GenerateUUID(t), Authenticated: true}, tc.authnErr)
 		}
 		authzCall := channels.On("Authorize", mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, tc.err)
-		repoCall := repo.On("ReadAll", chanID, tc.res.PageMetadata).Return(readers.MessagesPage{Total: tc.res.Total, Messages: fromSenml(tc.res.Messages)}, nil)
+		repoCall := repo.On("ReadAll", chanID, tc.res.PageMetadata).Return(readers.MessagesPage{Total: tc.res.Total, Messages: fromSenml(tc.res.Messages)}, tc.repoErr)
 		req := testRequest{
 			client: ts.Client(),
 			method: http.MethodGet,
@@ -1005,6 +1091,352 @@ func TestReadAll(t *testing.T) {
 	}
 }
 
+func TestExport(t *testing.T) {
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	now := time.Now().Unix()
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      float64(now - int64(i)),
+			Name:      msgName,
+			Value:     &v,
+		})
+	}
+
+	repo := new(mocks.MessageRepository)
+	authn := new(authnmocks.Authentication)
+	clients := new(climocks.ClientsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	ts := newServer(repo, authn, clients, channels)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		url         string
+		token       string
+		key         string
+		pageMeta    readers.PageMetadata
+		status      int
+		contentType string
+		lines       int
+		streamed    int
+		authnErr    error
+		err         error
+	}{
+		{
+			desc:        "export messages as ndjson by default",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:       userToken,
+			pageMeta:    readers.PageMetadata{Limit: 10, Format: "messages"},
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			lines:       numOfMessages,
+		},
+		{
+			desc:        "export messages as csv",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?output=csv", ts.URL, chanID),
+			key:         clientToken,
+			pageMeta:    readers.PageMetadata{Limit: 10, Format: "messages"},
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			lines:       numOfMessages + 1,
+		},
+		{
+			desc:        "export messages with publisher filter",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?publisher=%s", ts.URL, chanID, pubID),
+			token:       userToken,
+			pageMeta:    readers.PageMetadata{Limit: 10, Format: "messages", Publisher: pubID},
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			lines:       numOfMessages,
+		},
+		{
+			desc:     "export messages with failed read",
+			url:      fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:    userToken,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages"},
+			status:   http.StatusInternalServerError,
+			err:      readers.ErrReadMessages,
+		},
+		{
+			desc:        "export messages with failed read during streaming",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:       userToken,
+			pageMeta:    readers.PageMetadata{Limit: 10, Format: "messages"},
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			lines:       numOfMessages / 2,
+			streamed:    numOfMessages / 2,
+			err:         readers.ErrReadMessages,
+		},
+		{
+			desc:   "export messages with invalid output",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?output=xml", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid comparator",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?comparator=invalid", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:     "export messages with invalid token",
+			url:      fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:   "export messages with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(validSession, tc.authnErr)
+		if tc.key != "" {
+			authnCall = clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{
+				ClientSecret: tc.key,
+			}).Return(&grpcClientsV1.AuthnRes{Id: testsutil.GenerateUUID(t), Authenticated: true}, nil)
+		}
+		authzCall := channels.On("Authorize", mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+		streamed := messages
+		if tc.err != nil {
+			streamed = messages[:tc.streamed]
+		}
+		repoCall := repo.On("StreamAll", chanID, tc.pageMeta, mock.Anything).Return(func(_ string, _ readers.PageMetadata, handle func(readers.Message) error) error {
+			for _, msg := range streamed {
+				if err := handle(msg); err != nil {
+					return err
+				}
+			}
+			return tc.err
+		})
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			body, err := io.ReadAll(res.Body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while reading response body: %s", tc.desc, err))
+			lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+			assert.Equal(t, tc.contentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: got incorrect content type", tc.desc))
+			assert.Equal(t, tc.lines, len(lines), fmt.Sprintf("%s: expected %d lines got %d", tc.desc, tc.lines, len(lines)))
+			partial := res.Trailer.Get("X-Export-Error") != ""
+			assert.Equal(t, tc.err != nil, partial, fmt.Sprintf("%s: expected partial export %t got %t", tc.desc, tc.err != nil, partial))
+		}
+		authzCall.Unset()
+		authnCall.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestCount(t *testing.T) {
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	repo := new(mocks.MessageRepository)
+	authn := new(authnmocks.Authentication)
+	clients := new(climocks.ClientsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	ts := newServer(repo, authn, clients, channels)
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		url      string
+		token    string
+		key      string
+		pageMeta readers.PageMetadata
+		count    readers.MessagesCount
+		authnErr error
+		status   int
+	}{
+		{
+			desc:     "count messages",
+			url:      fmt.Sprintf("%s/channels/%s/messages/count", ts.URL, chanID),
+			token:    userToken,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages"},
+			count:    readers.MessagesCount{Total: numOfMessages},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "count messages with client key",
+			url:      fmt.Sprintf("%s/channels/%s/messages/count", ts.URL, chanID),
+			key:      clientToken,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages"},
+			count:    readers.MessagesCount{Total: numOfMessages},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "count messages with publisher and time range",
+			url:      fmt.Sprintf("%s/channels/%s/messages/count?publisher=%s&from=1&to=2", ts.URL, chanID, pubID),
+			token:    userToken,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages", Publisher: pubID, From: 1, To: 2},
+			count:    readers.MessagesCount{Total: 1},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "count messages grouped by publisher",
+			url:      fmt.Sprintf("%s/channels/%s/messages/count?group_by=publisher", ts.URL, chanID),
+			token:    userToken,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages", GroupBy: readers.PublisherGroup},
+			count:    readers.MessagesCount{Total: numOfMessages, Groups: map[string]uint64{pubID: numOfMessages}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:   "count messages with invalid group",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count?group_by=invalid", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "count messages with invalid comparator",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count?comparator=invalid", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "count messages with invalid limit",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count?limit=%d", ts.URL, chanID, 1001),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "count messages with invalid aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count?aggregation=invalid&interval=10s&from=1&to=2", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:     "count messages with invalid token",
+			url:      fmt.Sprintf("%s/channels/%s/messages/count", ts.URL, chanID),
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:   "count messages with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/count", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(validSession, tc.authnErr)
+		if tc.key != "" {
+			authnCall = clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{
+				ClientSecret: tc.key,
+			}).Return(&grpcClientsV1.AuthnRes{Id: testsutil.GenerateUUID(t), Authenticated: true}, nil)
+		}
+		authzCall := channels.On("Authorize", mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+		repoCall := repo.On("CountMessages", chanID, tc.pageMeta).Return(tc.count, nil)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var count readers.MessagesCount
+			err := json.NewDecoder(res.Body).Decode(&count)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.count, count))
+		}
+		authzCall.Unset()
+		authnCall.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	chanID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	session := smqauthn.Session{UserID: testsutil.GenerateUUID(t), DomainID: domainID}
+
+	repo := new(mocks.MessageRepository)
+	authn := new(authnmocks.Authentication)
+	clients := new(climocks.ClientsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	store := new(rlmocks.Store)
+	cfg := ratelimit.Config{Requests: 1, Period: time.Second}
+	mux := api.MakeHandler(repo, authn, clients, channels, ratelimit.Middleware(store, cfg, smqlog.NewMock()), svcName, instanceID)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cases := []struct {
+		desc       string
+		token      string
+		key        string
+		wait       time.Duration
+		status     int
+		retryAfter string
+	}{
+		{
+			desc:   "read messages of user within limit",
+			token:  userToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:       "read messages of user exceeding limit",
+			token:      userToken,
+			wait:       1500 * time.Millisecond,
+			status:     http.StatusTooManyRequests,
+			retryAfter: "2",
+		},
+		{
+			desc:   "read messages of client",
+			key:    clientToken,
+			wait:   time.Second,
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		authnCall := authn.On("Authenticate", mock.Anything, userToken).Return(session, nil)
+		clientsCall := clients.On("Authenticate", mock.Anything, mock.Anything).Return(&grpcClientsV1.AuthnRes{Id: testsutil.GenerateUUID(t), Authenticated: true}, nil)
+		authzCall := channels.On("Authorize", mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+		storeCall := store.On("Take", mock.Anything, domainID, cfg.Requests, cfg.Period).Return(tc.wait, nil)
+		repoCall := repo.On("ReadAll", chanID, mock.Anything).Return(readers.MessagesPage{}, nil)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.retryAfter, res.Header.Get("Retry-After"), fmt.Sprintf("%s: expected Retry-After %s got %s", tc.desc, tc.retryAfter, res.Header.Get("Retry-After")))
+		authnCall.Unset()
+		clientsCall.Unset()
+		authzCall.Unset()
+		storeCall.Unset()
+		repoCall.Unset()
+	}
+	authn.AssertNumberOfCalls(t, "Authenticate", 2)
+	store.AssertNumberOfCalls(t, "Take", 2)
+}
+
 type pageRes struct {
 	readers.PageMetadata
 	Total    uint64          `json:"total"`
@@ -0,0 +1,203 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/absmach/magistrala/readers"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+const (
+	ndjsonOutput      = "ndjson"
+	csvOutput         = "csv"
+	ndjsonContentType = "application/x-ndjson"
+	csvContentType    = "text/csv"
+
+	// exportErrorTrailer is set when the export fails after the response
+	// status was already sent to the client.
+	exportErrorTrailer = "X-Export-Error"
+
+	// flushInterval is the number of records written before the
+	// response is flushed to the client.
+	flushInterval = 100
+)
+
+var (
+	senmlColumns = []string{"channel", "subtopic", "publisher", "protocol", "name", "unit", "time", "update_time", "value", "string_value", "data_value", "bool_value", "sum"}
+	jsonColumns  = []string{"channel", "created", "subtopic", "publisher", "protocol", "payload"}
+)
+
+// recordWriter writes a single message in the export output format.
+type recordWriter interface {
+	Write(msg readers.Message) error
+	Flush() error
+}
+
+func newRecordWriter(w io.Writer, output, format string) (recordWriter, error) {
+	switch output {
+	case csvOutput:
+		columns := jsonColumns
+		if format == defFormat {
+			columns = senmlColumns
+		}
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw, columns: columns}, nil
+	default:
+		return &ndjsonWriter{enc: json.NewEncoder(w)}, nil
+	}
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (nw *ndjsonWriter) Write(msg readers.Message) error {
+	return nw.enc.Encode(msg)
+}
+
+func (nw *ndjsonWriter) Flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (cw *csvWriter) Write(msg readers.Message) error {
+	fields, err := toFields(msg)
+	if err != nil {
+		return err
+	}
+
+	record := make([]string, len(cw.columns))
+	for i, col := range cw.columns {
+		if record[i], err = fmtField(fields[col]); err != nil {
+			return err
+		}
+	}
+
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func toFields(msg readers.Message) (map[string]interface{}, error) {
+	if m, ok := msg.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func fmtField(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// encodeExport streams the messages to the client. The status and headers
+// are written once the first message is read, so errors that occur before
+// any data is sent are reported with the regular error response. Errors
+// that occur afterwards are reported in the X-Export-Error trailer.
+func encodeExport(svc readers.MessageRepository) kithttp.EncodeResponseFunc {
+	return func(_ context.Context, w http.ResponseWriter, response interface{}) error {
+		res := response.(exportRes)
+
+		contentType := ndjsonContentType
+		if res.output == csvOutput {
+			contentType = csvContentType
+		}
+
+		var rw recordWriter
+		start := func() (err error) {
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set("Trailer", exportErrorTrailer)
+			w.WriteHeader(http.StatusOK)
+			rw, err = newRecordWriter(w, res.output, res.pageMeta.Format)
+			return err
+		}
+
+		flusher, _ := w.(http.Flusher)
+		count := 0
+		started := false
+		err := svc.StreamAll(res.chanID, res.pageMeta, func(msg readers.Message) error {
+			if !started {
+				started = true
+				if err := start(); err != nil {
+					return err
+				}
+			}
+			if err := rw.Write(msg); err != nil {
+				return err
+			}
+			count++
+			if count%flushInterval == 0 {
+				if err := rw.Flush(); err != nil {
+					return err
+				}
+				if flusher != nil {
+					flusher.Flush()
+				}
+			}
+			return nil
+		})
+		switch {
+		case err != nil && !started:
+			return err
+		case !started:
+			err = start()
+		}
+		if err == nil && rw != nil {
+			err = rw.Flush()
+		}
+		if err != nil {
+			// The status is already sent, so the partial export is marked
+			// using the trailer instead.
+			w.Header().Set(exportErrorTrailer, err.Error())
+		}
+
+		return nil
+	}
+}
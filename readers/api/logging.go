@@ -9,7 +9,7 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/absmach/supermq/readers"
+	"github.com/absmach/magistrala/readers"
 )
 
 var _ readers.MessageRepository = (*loggingMiddleware)(nil)
@@ -54,3 +54,53 @@ func (lm *loggingMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (p
 
 	return lm.svc.ReadAll(chanID, rpm)
 }
+
+func (lm *loggingMiddleware) StreamAll(chanID string, rpm readers.PageMetadata, handle func(readers.Message) error) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", chanID),
+		}
+		if rpm.Subtopic != "" {
+			args = append(args, slog.String("subtopic", rpm.Subtopic))
+		}
+		if rpm.Publisher != "" {
+			args = append(args, slog.String("publisher", rpm.Publisher))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Stream all failed", args...)
+			return
+		}
+		lm.logger.Info("Stream all completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.StreamAll(chanID, rpm, handle)
+}
+
+func (lm *loggingMiddleware) CountMessages(chanID string, rpm readers.PageMetadata) (count readers.MessagesCount, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", chanID),
+			slog.Uint64("total", count.Total),
+		}
+		if rpm.Subtopic != "" {
+			args = append(args, slog.String("subtopic", rpm.Subtopic))
+		}
+		if rpm.Publisher != "" {
+			args = append(args, slog.String("publisher", rpm.Publisher))
+		}
+		if rpm.GroupBy != "" {
+			args = append(args, slog.String("group_by", rpm.GroupBy))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Count messages failed", args...)
+			return
+		}
+		lm.logger.Info("Count messages completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CountMessages(chanID, rpm)
+}
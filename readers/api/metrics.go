@@ -8,7 +8,7 @@ package api
 import (
 	"time"
 
-	"github.com/absmach/supermq/readers"
+	"github.com/absmach/magistrala/readers"
 	"github.com/go-kit/kit/metrics"
 )
 
@@ -37,3 +37,21 @@ func (mm *metricsMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (r
 
 	return mm.svc.ReadAll(chanID, rpm)
 }
+
+func (mm *metricsMiddleware) StreamAll(chanID string, rpm readers.PageMetadata, handle func(readers.Message) error) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "stream_all").Add(1)
+		mm.latency.With("method", "stream_all").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.StreamAll(chanID, rpm, handle)
+}
+
+func (mm *metricsMiddleware) CountMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesCount, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "count_messages").Add(1)
+		mm.latency.With("method", "count_messages").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CountMessages(chanID, rpm)
+}
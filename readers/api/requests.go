@@ -8,13 +8,23 @@ import (
 	"strings"
 	"time"
 
+	"github.com/absmach/magistrala/readers"
 	apiutil "github.com/absmach/supermq/api/http/util"
-	"github.com/absmach/supermq/readers"
+	"github.com/absmach/supermq/pkg/errors"
 )
 
 const maxLimitSize = 1000
 
-var validAggregations = []string{"MAX", "MIN", "AVG", "SUM", "COUNT"}
+var (
+	validAggregations = []string{"MAX", "MIN", "AVG", "SUM", "COUNT"}
+	validGroups       = []string{readers.PublisherGroup, readers.SubtopicGroup}
+
+	errInvalidOutput  = errors.New("invalid export output format")
+	errInvalidGroupBy = errors.New("invalid aggregation group")
+	errCursorOffset   = errors.New("cursor can't be combined with offset")
+	errCursorAgg      = errors.New("cursor can't be combined with aggregation")
+	errAggFormat      = errors.New("aggregation is supported only for SenML messages")
+)
 
 type listMessagesReq struct {
 	chanID   string
@@ -36,31 +46,119 @@ func (req listMessagesReq) validate() error {
 		return apiutil.ErrLimitSize
 	}
 
-	if req.pageMeta.Comparator != "" &&
-		req.pageMeta.Comparator != readers.EqualKey &&
-		req.pageMeta.Comparator != readers.LowerThanKey &&
-		req.pageMeta.Comparator != readers.LowerThanEqualKey &&
-		req.pageMeta.Comparator != readers.GreaterThanKey &&
-		req.pageMeta.Comparator != readers.GreaterThanEqualKey {
-		return apiutil.ErrInvalidComparator
+	if req.pageMeta.Cursor != "" {
+		if req.pageMeta.Offset > 0 {
+			return errCursorOffset
+		}
+		if req.pageMeta.Aggregation != "" {
+			return errCursorAgg
+		}
+		if _, err := readers.DecodeCursor(req.pageMeta.Cursor); err != nil {
+			return err
+		}
+	}
+
+	if err := validatePageMeta(req.pageMeta); err != nil {
+		return err
 	}
 
-	if req.pageMeta.Aggregation != "" {
-		if req.pageMeta.From == 0 {
+	return validateAggregationGroup(req.pageMeta)
+}
+
+type exportMessagesReq struct {
+	listMessagesReq
+	output string
+}
+
+func (req exportMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.output != ndjsonOutput && req.output != csvOutput {
+		return errInvalidOutput
+	}
+
+	if err := validatePageMeta(req.pageMeta); err != nil {
+		return err
+	}
+
+	return validateAggregationGroup(req.pageMeta)
+}
+
+type countMessagesReq struct {
+	listMessagesReq
+}
+
+func (req countMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.pageMeta.Limit < 1 || req.pageMeta.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	// Unlike the listed messages, counts can be grouped without aggregation.
+	return validatePageMeta(req.pageMeta)
+}
+
+func validatePageMeta(pm readers.PageMetadata) error {
+	if err := validateComparator(pm.Comparator); err != nil {
+		return err
+	}
+
+	if pm.Aggregation != "" {
+		if pm.From == 0 {
 			return apiutil.ErrMissingFrom
 		}
 
-		if req.pageMeta.To == 0 {
+		if pm.To == 0 {
 			return apiutil.ErrMissingTo
 		}
 
-		if !slices.Contains(validAggregations, strings.ToUpper(req.pageMeta.Aggregation)) {
+		if !slices.Contains(validAggregations, strings.ToUpper(pm.Aggregation)) {
 			return apiutil.ErrInvalidAggregation
 		}
 
-		if _, err := time.ParseDuration(req.pageMeta.Interval); err != nil {
+		if _, err := time.ParseDuration(pm.Interval); err != nil {
 			return apiutil.ErrInvalidInterval
 		}
+
+		if pm.Format != "" && pm.Format != defFormat {
+			return errAggFormat
+		}
+	}
+
+	if pm.GroupBy != "" && !slices.Contains(validGroups, pm.GroupBy) {
+		return errInvalidGroupBy
+	}
+
+	return nil
+}
+
+func validateComparator(comparator string) error {
+	switch comparator {
+	case "", readers.EqualKey, readers.LowerThanKey, readers.LowerThanEqualKey, readers.GreaterThanKey, readers.GreaterThanEqualKey:
+		return nil
+	default:
+		return apiutil.ErrInvalidComparator
+	}
+}
+
+// validateAggregationGroup checks that messages are grouped only within
+// aggregation buckets.
+func validateAggregationGroup(pm readers.PageMetadata) error {
+	if pm.GroupBy != "" && pm.Aggregation == "" {
+		return errInvalidGroupBy
 	}
 
 	return nil
@@ -6,16 +6,20 @@ package api
 import (
 	"net/http"
 
+	"github.com/absmach/magistrala/readers"
 	"github.com/absmach/supermq"
-	"github.com/absmach/supermq/readers"
 )
 
-var _ supermq.Response = (*pageRes)(nil)
+var (
+	_ supermq.Response = (*pageRes)(nil)
+	_ supermq.Response = (*countRes)(nil)
+)
 
 type pageRes struct {
 	readers.PageMetadata
-	Total    uint64            `json:"total"`
-	Messages []readers.Message `json:"messages,omitempty"`
+	Total      uint64            `json:"total"`
+	Messages   []readers.Message `json:"messages,omitempty"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
 
 func (res pageRes) Headers() map[string]string {
@@ -29,3 +33,27 @@ func (res pageRes) Code() int {
 func (res pageRes) Empty() bool {
 	return false
 }
+
+type countRes struct {
+	readers.MessagesCount
+}
+
+func (res countRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res countRes) Code() int {
+	return http.StatusOK
+}
+
+func (res countRes) Empty() bool {
+	return false
+}
+
+// exportRes carries the validated export request to the response encoder,
+// which streams the messages directly to the client.
+type exportRes struct {
+	chanID   string
+	pageMeta readers.PageMetadata
+	output   string
+}
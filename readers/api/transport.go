@@ -8,16 +8,17 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/absmach/magistrala/readers"
 	"github.com/absmach/supermq"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/policies"
-	"github.com/absmach/supermq/readers"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -41,25 +42,49 @@ const (
 	toKey          = "to"
 	aggregationKey = "aggregation"
 	intervalKey    = "interval"
+	outputKey      = "output"
+	groupByKey     = "group_by"
+	cursorKey      = "cursor"
 	defInterval    = "1s"
 	defLimit       = 10
 	defOffset      = 0
 	defFormat      = "messages"
+	defOutput      = ndjsonOutput
 )
 
-// MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, svcName, instanceID string) http.Handler {
+// MakeHandler returns a HTTP handler for API endpoints. Requests of the
+// users are limited by the rate limit middleware.
+func MakeHandler(svc readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, rateLimit func(http.Handler) http.Handler, svcName, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
 	}
 
 	mux := chi.NewRouter()
-	mux.Get("/channels/{chanID}/messages", kithttp.NewServer(
-		listMessagesEndpoint(svc, authn, clients, channels),
-		decodeList,
-		encodeResponse,
-		opts...,
-	).ServeHTTP)
+	mux.Group(func(r chi.Router) {
+		r.Use(authenticateMiddleware(authn))
+		r.Use(rateLimit)
+
+		r.Get("/channels/{chanID}/messages", kithttp.NewServer(
+			listMessagesEndpoint(svc, authn, clients, channels),
+			decodeList,
+			encodeResponse,
+			opts...,
+		).ServeHTTP)
+
+		r.Get("/channels/{chanID}/messages/export", kithttp.NewServer(
+			exportMessagesEndpoint(authn, clients, channels),
+			decodeExport,
+			encodeExport(svc),
+			opts...,
+		).ServeHTTP)
+
+		r.Get("/channels/{chanID}/messages/count", kithttp.NewServer(
+			countMessagesEndpoint(svc, authn, clients, channels),
+			decodeCount,
+			encodeResponse,
+			opts...,
+		).ServeHTTP)
+	})
 
 	mux.Get("/health", supermq.Health(svcName, instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
@@ -151,6 +176,16 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		}
 	}
 
+	groupBy, err := apiutil.ReadStringQuery(r, groupByKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	cursor, err := apiutil.ReadStringQuery(r, cursorKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	req := listMessagesReq{
 		chanID: chi.URLParam(r, "chanID"),
 		token:  apiutil.ExtractBearerToken(r),
@@ -172,11 +207,41 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 			To:          to,
 			Aggregation: aggregation,
 			Interval:    interval,
+			GroupBy:     groupBy,
+			Cursor:      cursor,
 		},
 	}
 	return req, nil
 }
 
+func decodeExport(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeList(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := apiutil.ReadStringQuery(r, outputKey, defOutput)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return exportMessagesReq{
+		listMessagesReq: req.(listMessagesReq),
+		output:          output,
+	}, nil
+}
+
+func decodeCount(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeList(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return countMessagesReq{
+		listMessagesReq: req.(listMessagesReq),
+	}, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -213,6 +278,12 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrInvalidInterval),
 		errors.Contains(err, apiutil.ErrMissingFrom),
 		errors.Contains(err, apiutil.ErrMissingTo),
+		errors.Contains(err, errInvalidOutput),
+		errors.Contains(err, errInvalidGroupBy),
+		errors.Contains(err, errCursorOffset),
+		errors.Contains(err, errCursorAgg),
+		errors.Contains(err, errAggFormat),
+		errors.Contains(err, readers.ErrInvalidCursor),
 		errors.Contains(err, apiutil.ErrMissingDomainID):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, svcerr.ErrAuthentication),
@@ -236,10 +307,33 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	}
 }
 
+// authenticateMiddleware stores the session of the user token in the request
+// context, so the rate limit middleware can limit the requests of the user's
+// domain. Requests with client secrets and requests which fail the
+// authentication are passed on, and the endpoints authenticate them.
+func authenticateMiddleware(authn smqauthn.Authentication) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := apiutil.ExtractBearerToken(r)
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			session, err := authn.Authenticate(r.Context(), token)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), api.SessionKey, session)))
+		})
+	}
+}
+
 func authnAuthz(ctx context.Context, req listMessagesReq, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) error {
 	clientID, clientType, err := authenticate(ctx, req, authn, clients)
 	if err != nil {
-		return nil
+		return errors.Wrap(svcerr.ErrAuthentication, err)
 	}
 	if err := authorize(ctx, clientID, clientType, req.chanID, channels); err != nil {
 		return err
@@ -250,6 +344,9 @@ func authnAuthz(ctx context.Context, req listMessagesReq, authn smqauthn.Authent
 func authenticate(ctx context.Context, req listMessagesReq, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient) (clientID string, clientType string, err error) {
 	switch {
 	case req.token != "":
+		if session, ok := ctx.Value(api.SessionKey).(smqauthn.Session); ok {
+			return session.DomainUserID, policies.UserType, nil
+		}
 		session, err := authn.Authenticate(ctx, req.token)
 		if err != nil {
 			return "", "", err
@@ -0,0 +1,51 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor indicates that the page cursor is malformed.
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// Cursor represents the position of the last message of the page in the
+// ordering of the message repository. Time holds the time of SenML messages,
+// Created holds the creation time of JSON messages, and Keys hold the values
+// which order the messages of the same time. Cursor is passed to clients as
+// an opaque string, so the next page continues right after the last message
+// regardless of the messages written in the meantime.
+type Cursor struct {
+	Time    float64  `json:"t,omitempty"`
+	Created int64    `json:"c,omitempty"`
+	Keys    []string `json:"k,omitempty"`
+}
+
+// Encode returns the opaque cursor string.
+func (c Cursor) Encode() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes the opaque cursor string returned by Encode. Cursors
+// which don't hold the ordering keys are rejected, while the number of keys
+// is checked by the message repository which issued the cursor.
+func DecodeCursor(cursor string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || len(c.Keys) == 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/readers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	cases := []struct {
+		desc   string
+		cursor string
+		res    readers.Cursor
+		err    error
+	}{
+		{
+			desc:   "decode SenML message cursor",
+			cursor: readers.Cursor{Time: 1700000000.5, Keys: []string{"id"}}.Encode(),
+			res:    readers.Cursor{Time: 1700000000.5, Keys: []string{"id"}},
+			err:    nil,
+		},
+		{
+			desc:   "decode JSON message cursor",
+			cursor: readers.Cursor{Created: 1700000000123456789, Keys: []string{"publisher", "subtopic"}}.Encode(),
+			res:    readers.Cursor{Created: 1700000000123456789, Keys: []string{"publisher", "subtopic"}},
+			err:    nil,
+		},
+		{
+			desc:   "decode cursor with invalid encoding",
+			cursor: "invalid cursor",
+			err:    readers.ErrInvalidCursor,
+		},
+		{
+			desc:   "decode cursor with invalid content",
+			cursor: "aW52YWxpZA",
+			err:    readers.ErrInvalidCursor,
+		},
+		{
+			desc:   "decode cursor without keys",
+			cursor: readers.Cursor{Time: 1700000000.5}.Encode(),
+			err:    readers.ErrInvalidCursor,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := readers.DecodeCursor(tc.cursor)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+	}
+}
@@ -18,6 +18,13 @@ const (
 	GreaterThanEqualKey = "ge"
 )
 
+const (
+	// PublisherGroup groups aggregated messages by publisher.
+	PublisherGroup = "publisher"
+	// SubtopicGroup groups aggregated messages by subtopic.
+	SubtopicGroup = "subtopic"
+)
+
 // ErrReadMessages indicates failure occurred while reading messages from database.
 var ErrReadMessages = errors.New("failed to read messages from database")
 
@@ -26,8 +33,21 @@ var ErrReadMessages = errors.New("failed to read messages from database")
 //go:generate mockery --name MessageRepository --output=./mocks --filename messages.go --quiet --note "Copyright (c) Abstract Machines"
 type MessageRepository interface {
 	// ReadAll skips given number of messages for given channel and returns next
-	// limited number of messages.
+	// limited number of messages. If the page metadata contains a cursor, the
+	// messages following the cursor are returned instead and offset is ignored.
+	// Full pages of non-aggregated messages contain the cursor of the next page.
 	ReadAll(chanID string, pm PageMetadata) (MessagesPage, error)
+
+	// StreamAll reads all messages for given channel that match the page
+	// metadata filters and passes them to the handler one by one, as they
+	// are read from the database. Offset and limit are ignored.
+	StreamAll(chanID string, pm PageMetadata, handle func(Message) error) error
+
+	// CountMessages returns the number of messages for given channel that
+	// match the page metadata filters, without reading the messages. If
+	// grouping is requested, counts are also returned per publisher or
+	// subtopic. Offset, limit and aggregation are ignored.
+	CountMessages(chanID string, pm PageMetadata) (MessagesCount, error)
 }
 
 // Message represents any message format.
@@ -37,8 +57,16 @@ type Message interface{}
 // belong to this page.
 type MessagesPage struct {
 	PageMetadata
-	Total    uint64
-	Messages []Message
+	Total      uint64
+	Messages   []Message
+	NextCursor string
+}
+
+// MessagesCount contains the number of messages that match the filters and,
+// if grouping is requested, the number of messages per group.
+type MessagesCount struct {
+	Total  uint64            `json:"total"`
+	Groups map[string]uint64 `json:"groups,omitempty"`
 }
 
 // PageMetadata represents the parameters used to create database queries.
@@ -59,6 +87,8 @@ type PageMetadata struct {
 	Format      string  `json:"format,omitempty"`
 	Aggregation string  `json:"aggregation,omitempty"`
 	Interval    string  `json:"interval,omitempty"`
+	GroupBy     string  `json:"group_by,omitempty"`
+	Cursor      string  `json:"cursor,omitempty"`
 }
 
 // ParseValueComparator convert comparison operator keys into mathematic anotation.
@@ -5,7 +5,7 @@
 package mocks
 
 import (
-	readers "github.com/absmach/supermq/readers"
+	readers "github.com/absmach/magistrala/readers"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +14,34 @@ type MessageRepository struct {
 	mock.Mock
 }
 
+// CountMessages provides a mock function with given fields: chanID, pm
+func (_m *MessageRepository) CountMessages(chanID string, pm readers.PageMetadata) (readers.MessagesCount, error) {
+	ret := _m.Called(chanID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountMessages")
+	}
+
+	var r0 readers.MessagesCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, readers.PageMetadata) (readers.MessagesCount, error)); ok {
+		return rf(chanID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(string, readers.PageMetadata) readers.MessagesCount); ok {
+		r0 = rf(chanID, pm)
+	} else {
+		r0 = ret.Get(0).(readers.MessagesCount)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, readers.PageMetadata) error); ok {
+		r1 = rf(chanID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReadAll provides a mock function with given fields: chanID, pm
 func (_m *MessageRepository) ReadAll(chanID string, pm readers.PageMetadata) (readers.MessagesPage, error) {
 	ret := _m.Called(chanID, pm)
@@ -42,6 +70,24 @@ func (_m *MessageRepository) ReadAll(chanID string, pm readers.PageMetadata) (re
 	return r0, r1
 }
 
+// StreamAll provides a mock function with given fields: chanID, pm, handle
+func (_m *MessageRepository) StreamAll(chanID string, pm readers.PageMetadata, handle func(readers.Message) error) error {
+	ret := _m.Called(chanID, pm, handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, readers.PageMetadata, func(readers.Message) error) error); ok {
+		r0 = rf(chanID, pm, handle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMessageRepository creates a new instance of MessageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMessageRepository(t interface {
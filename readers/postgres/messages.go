@@ -6,25 +6,34 @@ package postgres
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/absmach/magistrala/consumers/writers"
+	"github.com/absmach/magistrala/readers"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/transformers/senml"
-	"github.com/absmach/supermq/readers"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 )
 
+// timeDivisor converts message time in nanoseconds to seconds.
+const timeDivisor = 1000000000
+
 var _ readers.MessageRepository = (*postgresRepository)(nil)
 
 type postgresRepository struct {
-	db *sqlx.DB
+	db     *sqlx.DB
+	naming writers.Naming
 }
 
 // New returns new PostgreSQL writer.
-func New(db *sqlx.DB) readers.MessageRepository {
+// Messages are read from the tables determined by the naming strategy of
+// the writer.
+func New(db *sqlx.DB, naming writers.Naming) readers.MessageRepository {
 	return &postgresRepository{
-		db: db,
+		db:     db,
+		naming: naming,
 	}
 }
 
@@ -36,27 +45,40 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 		order = "created"
 		format = rpm.Format
 	}
+	table := tr.naming.Table(format, chanID, rpm.Subtopic)
 	cond := fmtCondition(chanID, rpm)
+	params := queryParams(chanID, rpm)
+
+	// Messages of the same time are ordered by ID, so that the cursor
+	// identifies the position of the message.
+	page := fmt.Sprintf(`%s AND (%s, id) < (:cursor_time, :cursor_id)`, cond, order)
+	pagination := "LIMIT :limit"
+	if rpm.Cursor == "" {
+		page = cond
+		pagination = "LIMIT :limit OFFSET :offset"
+	} else {
+		cursor, err := readers.DecodeCursor(rpm.Cursor)
+		if err != nil || len(cursor.Keys) != 1 {
+			return readers.MessagesPage{}, readers.ErrInvalidCursor
+		}
+		params["cursor_time"] = cursor.Time
+		if order == "created" {
+			params["cursor_time"] = cursor.Created
+		}
+		params["cursor_id"] = cursor.Keys[0]
+	}
 
 	q := fmt.Sprintf(`SELECT * FROM %s
-    WHERE %s ORDER BY %s DESC
-	LIMIT :limit OFFSET :offset;`, format, cond, order)
+    WHERE %s ORDER BY %s DESC, id DESC
+	%s;`, table, page, order, pagination)
+	totalQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, table, cond)
 
-	params := map[string]interface{}{
-		"channel":      chanID,
-		"limit":        rpm.Limit,
-		"offset":       rpm.Offset,
-		"subtopic":     rpm.Subtopic,
-		"publisher":    rpm.Publisher,
-		"name":         rpm.Name,
-		"protocol":     rpm.Protocol,
-		"value":        rpm.Value,
-		"bool_value":   rpm.BoolValue,
-		"string_value": rpm.StringValue,
-		"data_value":   rpm.DataValue,
-		"from":         rpm.From,
-		"to":           rpm.To,
+	if rpm.Aggregation != "" {
+		agg := fmtAggregation(chanID, rpm, table)
+		q = fmt.Sprintf(`%s ORDER BY time DESC LIMIT :limit OFFSET :offset;`, agg)
+		totalQuery = fmt.Sprintf(`SELECT COUNT(*) FROM (%s) AS subquery;`, agg)
 	}
+
 	rows, err := tr.db.NamedQuery(q, params)
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
@@ -68,36 +90,24 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 	}
 	defer rows.Close()
 
-	page := readers.MessagesPage{
+	ret := readers.MessagesPage{
 		PageMetadata: rpm,
 		Messages:     []readers.Message{},
 	}
-	switch format {
-	case defTable:
-		for rows.Next() {
-			msg := senmlMessage{Message: senml.Message{}}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
-
-			page.Messages = append(page.Messages, msg.Message)
-		}
-	default:
-		for rows.Next() {
-			msg := jsonMessage{}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
-			m, err := msg.toMap()
-			if err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
-			page.Messages = append(page.Messages, m)
+	var last readers.Cursor
+	for rows.Next() {
+		msg, cursor, err := scanMessage(rows, format)
+		if err != nil {
+			return readers.MessagesPage{}, err
 		}
+		ret.Messages = append(ret.Messages, msg)
+		last = cursor
+	}
+	if rpm.Aggregation == "" && rpm.Limit > 0 && uint64(len(ret.Messages)) == rpm.Limit {
+		ret.NextCursor = last.Encode()
 	}
 
-	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, cond)
-	rows, err = tr.db.NamedQuery(q, params)
+	rows, err = tr.db.NamedQuery(totalQuery, params)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
@@ -106,12 +116,178 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 	total := uint64(0)
 	if rows.Next() {
 		if err := rows.Scan(&total); err != nil {
-			return page, err
+			return ret, err
+		}
+	}
+	ret.Total = total
+
+	return ret, nil
+}
+
+func (tr postgresRepository) StreamAll(chanID string, rpm readers.PageMetadata, handle func(readers.Message) error) error {
+	order := "time"
+	format := defTable
+
+	if rpm.Format != "" && rpm.Format != defTable {
+		order = "created"
+		format = rpm.Format
+	}
+	table := tr.naming.Table(format, chanID, rpm.Subtopic)
+
+	q := fmt.Sprintf(`SELECT * FROM %s
+    WHERE %s ORDER BY %s DESC;`, table, fmtCondition(chanID, rpm), order)
+	if rpm.Aggregation != "" {
+		q = fmt.Sprintf(`%s ORDER BY time DESC;`, fmtAggregation(chanID, rpm, table))
+	}
+
+	rows, err := tr.db.NamedQuery(q, queryParams(chanID, rpm))
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return nil
+			}
+		}
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		msg, _, err := scanMessage(rows, format)
+		if err != nil {
+			return err
+		}
+		if err := handle(msg); err != nil {
+			return err
 		}
 	}
-	page.Total = total
 
-	return page, nil
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return nil
+}
+
+func (tr postgresRepository) CountMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesCount, error) {
+	format := defTable
+	if rpm.Format != "" && rpm.Format != defTable {
+		format = rpm.Format
+	}
+	table := tr.naming.Table(format, chanID, rpm.Subtopic)
+
+	group := "''"
+	switch rpm.GroupBy {
+	case readers.PublisherGroup:
+		group = "COALESCE(publisher, '')"
+	case readers.SubtopicGroup:
+		group = "COALESCE(subtopic, '')"
+	}
+
+	q := fmt.Sprintf(`SELECT %s AS grp, COUNT(*) AS total FROM %s WHERE %s GROUP BY 1;`, group, table, fmtCondition(chanID, rpm))
+
+	count := readers.MessagesCount{}
+	rows, err := tr.db.NamedQuery(q, queryParams(chanID, rpm))
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return count, nil
+			}
+		}
+		return readers.MessagesCount{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	if rpm.GroupBy != "" {
+		count.Groups = map[string]uint64{}
+	}
+	for rows.Next() {
+		var grp string
+		var total uint64
+		if err := rows.Scan(&grp, &total); err != nil {
+			return readers.MessagesCount{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		count.Total += total
+		if count.Groups != nil {
+			count.Groups[grp] = total
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return readers.MessagesCount{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return count, nil
+}
+
+// fmtAggregation returns the query that buckets messages by the page
+// metadata interval and applies the aggregation function to the values
+// of each bucket. Buckets are additionally split by publisher or subtopic
+// if grouping is requested.
+func fmtAggregation(chanID string, rpm readers.PageMetadata, table string) string {
+	bucket := fmt.Sprintf(`(EXTRACT(epoch FROM INTERVAL '%s') * %d)`, fmtInterval(rpm.Interval), timeDivisor)
+	publisher := "(ARRAY_AGG(publisher ORDER BY time))[1]"
+	subtopic := "(ARRAY_AGG(subtopic ORDER BY time))[1]"
+	groupBy := "1"
+	switch rpm.GroupBy {
+	case readers.PublisherGroup:
+		publisher = "publisher"
+		groupBy = "1, publisher"
+	case readers.SubtopicGroup:
+		subtopic = "subtopic"
+		groupBy = "1, subtopic"
+	}
+
+	return fmt.Sprintf(`SELECT FLOOR(time / %s) * %s AS time, %s(value) AS value, %s AS publisher, (ARRAY_AGG(protocol ORDER BY time))[1] AS protocol, %s AS subtopic, (ARRAY_AGG(name ORDER BY time))[1] AS name, (ARRAY_AGG(unit ORDER BY time))[1] AS unit FROM %s WHERE %s GROUP BY %s`, bucket, bucket, rpm.Aggregation, publisher, subtopic, table, fmtCondition(chanID, rpm), groupBy)
+}
+
+// fmtInterval converts the page interval, which is in the Go duration
+// format, to the interval format accepted by PostgreSQL.
+func fmtInterval(interval string) string {
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return interval
+	}
+
+	return fmt.Sprintf("%d microseconds", d.Microseconds())
+}
+
+func queryParams(chanID string, rpm readers.PageMetadata) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+}
+
+// scanMessage returns the message and its position in the messages ordering.
+func scanMessage(rows *sqlx.Rows, format string) (readers.Message, readers.Cursor, error) {
+	if format == defTable {
+		msg := senmlMessage{Message: senml.Message{}}
+		if err := rows.StructScan(&msg); err != nil {
+			return nil, readers.Cursor{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		return msg.Message, readers.Cursor{Time: msg.Time, Keys: []string{msg.ID}}, nil
+	}
+
+	msg := jsonMessage{}
+	if err := rows.StructScan(&msg); err != nil {
+		return nil, readers.Cursor{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	m, err := msg.toMap()
+	if err != nil {
+		return nil, readers.Cursor{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	return m, readers.Cursor{Created: msg.Created, Keys: []string{msg.ID}}, nil
 }
 
 func fmtCondition(chanID string, rpm readers.PageMetadata) string {
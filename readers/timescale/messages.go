@@ -6,25 +6,35 @@ package timescale
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/absmach/magistrala/consumers/writers"
+	"github.com/absmach/magistrala/readers"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/transformers/senml"
-	"github.com/absmach/supermq/readers"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx" // required for DB access
 )
 
+// timeDivisor converts message time in nanoseconds to seconds.
+const timeDivisor = 1000000000
+
 var _ readers.MessageRepository = (*timescaleRepository)(nil)
 
 type timescaleRepository struct {
-	db *sqlx.DB
+	db     *sqlx.DB
+	naming writers.Naming
 }
 
 // New returns new TimescaleSQL writer.
-func New(db *sqlx.DB) readers.MessageRepository {
+// Messages are read from the tables determined by the naming strategy of
+// the writer.
+func New(db *sqlx.DB, naming writers.Naming) readers.MessageRepository {
 	return &timescaleRepository{
-		db: db,
+		db:     db,
+		naming: naming,
 	}
 }
 
@@ -36,33 +46,43 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		order = "created"
 		format = rpm.Format
 	}
+	table := tr.naming.Table(format, chanID, rpm.Subtopic)
+	params := queryParams(chanID, rpm)
+
+	// Messages of the same time are ordered by the rest of the primary key,
+	// so that the cursor identifies the position of the message.
+	keys := []string{"publisher", "subtopic", "name"}
+	if order == "created" {
+		keys = keys[:2]
+	}
+	cond := fmtCondition(rpm)
+	pagination := "LIMIT :limit OFFSET :offset"
+	if rpm.Cursor != "" {
+		cursor, err := readers.DecodeCursor(rpm.Cursor)
+		if err != nil || len(cursor.Keys) != len(keys) {
+			return readers.MessagesPage{}, readers.ErrInvalidCursor
+		}
+		params["cursor_time"] = cursor.Time
+		if order == "created" {
+			params["cursor_time"] = cursor.Created
+		}
+		values := []string{":cursor_time"}
+		for i, k := range cursor.Keys {
+			params[fmt.Sprintf("cursor_key%d", i)] = k
+			values = append(values, fmt.Sprintf(":cursor_key%d", i))
+		}
+		cond = fmt.Sprintf(`%s AND (%s, %s) < (%s)`, cond, order, strings.Join(keys, ", "), strings.Join(values, ", "))
+		pagination = "LIMIT :limit"
+	}
 
-	q := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY %s DESC LIMIT :limit OFFSET :offset;`, format, fmtCondition(rpm), order)
-	totalQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, fmtCondition(rpm))
+	q := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY %s DESC, %s DESC %s;`, table, cond, order, strings.Join(keys, " DESC, "), pagination)
+	totalQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, table, fmtCondition(rpm))
 
 	// If aggregation is provided, add time_bucket and aggregation to the query
-	const timeDivisor = 1000000000
-
 	if rpm.Aggregation != "" {
-		q = fmt.Sprintf(`SELECT EXTRACT(epoch FROM time_bucket('%s', to_timestamp(time/%d))) *%d AS time, %s(value) AS value, FIRST(publisher, time) AS publisher, FIRST(protocol, time) AS protocol, FIRST(subtopic, time) AS subtopic, FIRST(name,time) AS name, FIRST(unit, time) AS unit FROM %s WHERE %s GROUP BY 1 ORDER BY time DESC LIMIT :limit OFFSET :offset;`, rpm.Interval, timeDivisor, timeDivisor, rpm.Aggregation, format, fmtCondition(rpm))
-
-		totalQuery = fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT EXTRACT(epoch FROM time_bucket('%s', to_timestamp(time/%d))) AS time, %s(value) AS value FROM %s WHERE %s GROUP BY 1) AS subquery;`, rpm.Interval, timeDivisor, rpm.Aggregation, format, fmtCondition(rpm))
-	}
-
-	params := map[string]interface{}{
-		"channel":      chanID,
-		"limit":        rpm.Limit,
-		"offset":       rpm.Offset,
-		"subtopic":     rpm.Subtopic,
-		"publisher":    rpm.Publisher,
-		"name":         rpm.Name,
-		"protocol":     rpm.Protocol,
-		"value":        rpm.Value,
-		"bool_value":   rpm.BoolValue,
-		"string_value": rpm.StringValue,
-		"data_value":   rpm.DataValue,
-		"from":         rpm.From,
-		"to":           rpm.To,
+		agg := fmtAggregation(rpm, table)
+		q = fmt.Sprintf(`%s ORDER BY time DESC LIMIT :limit OFFSET :offset;`, agg)
+		totalQuery = fmt.Sprintf(`SELECT COUNT(*) FROM (%s) AS subquery;`, agg)
 	}
 
 	rows, err := tr.db.NamedQuery(q, params)
@@ -80,28 +100,17 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		PageMetadata: rpm,
 		Messages:     []readers.Message{},
 	}
-	switch format {
-	case defTable:
-		for rows.Next() {
-			msg := senmlMessage{Message: senml.Message{}}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
-
-			page.Messages = append(page.Messages, msg.Message)
-		}
-	default:
-		for rows.Next() {
-			msg := jsonMessage{}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
-			m, err := msg.toMap()
-			if err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
-			page.Messages = append(page.Messages, m)
+	var last readers.Cursor
+	for rows.Next() {
+		msg, cursor, err := scanMessage(rows, format)
+		if err != nil {
+			return readers.MessagesPage{}, err
 		}
+		page.Messages = append(page.Messages, msg)
+		last = cursor
+	}
+	if rpm.Aggregation == "" && rpm.Limit > 0 && uint64(len(page.Messages)) == rpm.Limit {
+		page.NextCursor = last.Encode()
 	}
 
 	rows, err = tr.db.NamedQuery(totalQuery, params)
@@ -121,6 +130,170 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 	return page, nil
 }
 
+func (tr timescaleRepository) StreamAll(chanID string, rpm readers.PageMetadata, handle func(readers.Message) error) error {
+	order := "time"
+	format := defTable
+
+	if rpm.Format != "" && rpm.Format != defTable {
+		order = "created"
+		format = rpm.Format
+	}
+	table := tr.naming.Table(format, chanID, rpm.Subtopic)
+
+	q := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY %s DESC;`, table, fmtCondition(rpm), order)
+	if rpm.Aggregation != "" {
+		q = fmt.Sprintf(`%s ORDER BY time DESC;`, fmtAggregation(rpm, table))
+	}
+
+	rows, err := tr.db.NamedQuery(q, queryParams(chanID, rpm))
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return nil
+			}
+		}
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		msg, _, err := scanMessage(rows, format)
+		if err != nil {
+			return err
+		}
+		if err := handle(msg); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return nil
+}
+
+func (tr timescaleRepository) CountMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesCount, error) {
+	format := defTable
+	if rpm.Format != "" && rpm.Format != defTable {
+		format = rpm.Format
+	}
+	table := tr.naming.Table(format, chanID, rpm.Subtopic)
+
+	group := "''"
+	switch rpm.GroupBy {
+	case readers.PublisherGroup:
+		group = "COALESCE(publisher, '')"
+	case readers.SubtopicGroup:
+		group = "COALESCE(subtopic, '')"
+	}
+
+	q := fmt.Sprintf(`SELECT %s AS grp, COUNT(*) AS total FROM %s WHERE %s GROUP BY 1;`, group, table, fmtCondition(rpm))
+
+	count := readers.MessagesCount{}
+	rows, err := tr.db.NamedQuery(q, queryParams(chanID, rpm))
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return count, nil
+			}
+		}
+		return readers.MessagesCount{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	if rpm.GroupBy != "" {
+		count.Groups = map[string]uint64{}
+	}
+	for rows.Next() {
+		var grp string
+		var total uint64
+		if err := rows.Scan(&grp, &total); err != nil {
+			return readers.MessagesCount{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		count.Total += total
+		if count.Groups != nil {
+			count.Groups[grp] = total
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return readers.MessagesCount{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return count, nil
+}
+
+// fmtAggregation returns the query that buckets messages by the page
+// metadata interval and applies the aggregation function to the values
+// of each bucket. Buckets are additionally split by publisher or subtopic
+// if grouping is requested.
+func fmtAggregation(rpm readers.PageMetadata, table string) string {
+	publisher := "FIRST(publisher, time)"
+	subtopic := "FIRST(subtopic, time)"
+	groupBy := "1"
+	switch rpm.GroupBy {
+	case readers.PublisherGroup:
+		publisher = "publisher"
+		groupBy = "1, publisher"
+	case readers.SubtopicGroup:
+		subtopic = "subtopic"
+		groupBy = "1, subtopic"
+	}
+
+	return fmt.Sprintf(`SELECT EXTRACT(epoch FROM time_bucket('%s', to_timestamp(time/%d))) *%d AS time, %s(value) AS value, %s AS publisher, FIRST(protocol, time) AS protocol, %s AS subtopic, FIRST(name,time) AS name, FIRST(unit, time) AS unit FROM %s WHERE %s GROUP BY %s`, fmtInterval(rpm.Interval), timeDivisor, timeDivisor, rpm.Aggregation, publisher, subtopic, table, fmtCondition(rpm), groupBy)
+}
+
+// fmtInterval converts the page interval, which is in the Go duration
+// format, to the interval format accepted by PostgreSQL.
+func fmtInterval(interval string) string {
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return interval
+	}
+
+	return fmt.Sprintf("%d microseconds", d.Microseconds())
+}
+
+func queryParams(chanID string, rpm readers.PageMetadata) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+}
+
+// scanMessage returns the message and its position in the messages ordering.
+func scanMessage(rows *sqlx.Rows, format string) (readers.Message, readers.Cursor, error) {
+	if format == defTable {
+		msg := senmlMessage{Message: senml.Message{}}
+		if err := rows.StructScan(&msg); err != nil {
+			return nil, readers.Cursor{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		return msg.Message, readers.Cursor{Time: msg.Time, Keys: []string{msg.Publisher, msg.Subtopic, msg.Name}}, nil
+	}
+
+	msg := jsonMessage{}
+	if err := rows.StructScan(&msg); err != nil {
+		return nil, readers.Cursor{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	m, err := msg.toMap()
+	if err != nil {
+		return nil, readers.Cursor{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	return m, readers.Cursor{Created: msg.Created, Keys: []string{msg.Publisher, msg.Subtopic}}, nil
+}
+
 func fmtCondition(rpm readers.PageMetadata) string {
 	condition := `channel = :channel`
 
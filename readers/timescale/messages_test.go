@@ -9,12 +9,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/absmach/magistrala/consumers/writers"
 	twriter "github.com/absmach/magistrala/consumers/writers/timescale"
 	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/readers"
 	treader "github.com/absmach/magistrala/readers/timescale"
+	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/transformers/json"
 	"github.com/absmach/supermq/pkg/transformers/senml"
-	"github.com/absmach/supermq/readers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -41,7 +43,7 @@ var (
 )
 
 func TestReadSenml(t *testing.T) {
-	writer := twriter.New(db)
+	writer := twriter.New(db, writers.SingleNaming)
 
 	chanID := testsutil.GenerateUUID(t)
 	pubID := testsutil.GenerateUUID(t)
@@ -96,7 +98,7 @@ func TestReadSenml(t *testing.T) {
 	err := writer.ConsumeBlocking(context.TODO(), messages)
 	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
 
-	reader := treader.New(db)
+	reader := treader.New(db, writers.SingleNaming)
 
 	// Since messages are not saved in natural order,
 	// cases that return subset of messages are only
@@ -519,7 +521,7 @@ func TestReadSenml(t *testing.T) {
 }
 
 func TestReadMessagesWithAggregation(t *testing.T) {
-	writer := twriter.New(db)
+	writer := twriter.New(db, writers.SingleNaming)
 
 	chanID := testsutil.GenerateUUID(t)
 	pubID := testsutil.GenerateUUID(t)
@@ -545,7 +547,7 @@ func TestReadMessagesWithAggregation(t *testing.T) {
 	err := writer.ConsumeBlocking(context.TODO(), messages)
 	require.Nil(t, err, "expected no error got %s\n", err)
 
-	reader := treader.New(db)
+	reader := treader.New(db, writers.SingleNaming)
 
 	// Set up cases for aggregation readAll
 	cases := []struct {
@@ -629,6 +631,22 @@ func TestReadMessagesWithAggregation(t *testing.T) {
 				Messages: fromSenml(messages),
 			},
 		},
+		{
+			desc:   "read message page for existing channel with MAX aggregation grouped by publisher",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Limit:       100,
+				Offset:      0,
+				Aggregation: "MAX",
+				Interval:    "1 hour",
+				GroupBy:     readers.PublisherGroup,
+				From:        now - float64(100000000000),
+				To:          now,
+			},
+			page: readers.MessagesPage{
+				Messages: fromSenml(messages),
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -644,8 +662,326 @@ func TestReadMessagesWithAggregation(t *testing.T) {
 	}
 }
 
+func TestReadSenmlCursor(t *testing.T) {
+	writer := twriter.New(db, writers.SingleNaming)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+	pubID2 := testsutil.GenerateUUID(t)
+
+	// Pairs of messages share the same time to check ordering within the time.
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      now - float64(i/2),
+			Value:     &v,
+		}
+		if i%2 == 0 {
+			msg.Publisher = pubID2
+		}
+		messages = append(messages, msg)
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db, writers.SingleNaming)
+
+	read := []readers.Message{}
+	pm := readers.PageMetadata{Limit: limit}
+	for i := 0; i <= msgsNum/limit; i++ {
+		page, err := reader.ReadAll(chanID, pm)
+		require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+		assert.Equal(t, uint64(msgsNum), page.Total, fmt.Sprintf("expected total %d got %d\n", msgsNum, page.Total))
+		read = append(read, page.Messages...)
+		if page.NextCursor == "" {
+			break
+		}
+		pm.Cursor = page.NextCursor
+	}
+	assert.ElementsMatch(t, fromSenml(messages), read, "got incorrect list of senml Messages from ReadAll() with cursor")
+
+	_, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: limit, Cursor: "invalid"})
+	assert.True(t, errors.Contains(err, readers.ErrInvalidCursor), fmt.Sprintf("expected %s got %s\n", readers.ErrInvalidCursor, err))
+}
+
+func TestReadSenmlByChannel(t *testing.T) {
+	writer := twriter.New(db, writers.ChannelNaming)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      now - float64(i),
+			Value:     &v,
+		})
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	cases := []struct {
+		desc     string
+		naming   writers.Naming
+		chanID   string
+		messages []readers.Message
+	}{
+		{
+			desc:     "read messages of channel table",
+			naming:   writers.ChannelNaming,
+			chanID:   chanID,
+			messages: fromSenml(messages),
+		},
+		{
+			desc:     "read messages of non-existent channel table",
+			naming:   writers.ChannelNaming,
+			chanID:   wrongID,
+			messages: []readers.Message{},
+		},
+		{
+			desc:     "read messages of channel table from single table",
+			naming:   writers.SingleNaming,
+			chanID:   chanID,
+			messages: []readers.Message{},
+		},
+	}
+
+	for _, tc := range cases {
+		reader := treader.New(db, tc.naming)
+		page, err := reader.ReadAll(tc.chanID, readers.PageMetadata{Limit: msgsNum})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, uint64(len(tc.messages)), page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, len(tc.messages), page.Total))
+		assert.ElementsMatch(t, tc.messages, page.Messages, fmt.Sprintf("%s: got incorrect list of senml Messages from ReadAll()", tc.desc))
+	}
+}
+
+func TestReadSenmlBySubtopic(t *testing.T) {
+	writer := twriter.New(db, writers.SubtopicNaming)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Subtopic:  subtopic,
+			Protocol:  mqttProt,
+			Time:      now - float64(i),
+			Value:     &v,
+		})
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	cases := []struct {
+		desc     string
+		naming   writers.Naming
+		chanID   string
+		subtopic string
+		messages []readers.Message
+	}{
+		{
+			desc:     "read messages of subtopic table",
+			naming:   writers.SubtopicNaming,
+			chanID:   chanID,
+			subtopic: subtopic,
+			messages: fromSenml(messages),
+		},
+		{
+			desc:     "read messages of other channel from subtopic table",
+			naming:   writers.SubtopicNaming,
+			chanID:   wrongID,
+			subtopic: subtopic,
+			messages: []readers.Message{},
+		},
+		{
+			desc:     "read messages of subtopic table without subtopic",
+			naming:   writers.SubtopicNaming,
+			chanID:   chanID,
+			messages: []readers.Message{},
+		},
+	}
+
+	for _, tc := range cases {
+		reader := treader.New(db, tc.naming)
+		page, err := reader.ReadAll(tc.chanID, readers.PageMetadata{Limit: msgsNum, Subtopic: tc.subtopic})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, uint64(len(tc.messages)), page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, len(tc.messages), page.Total))
+		assert.ElementsMatch(t, tc.messages, page.Messages, fmt.Sprintf("%s: got incorrect list of senml Messages from ReadAll()", tc.desc))
+	}
+}
+
+func TestStreamSenml(t *testing.T) {
+	writer := twriter.New(db, writers.SingleNaming)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+	pubID2 := testsutil.GenerateUUID(t)
+
+	messages := []senml.Message{}
+	pubMsgs := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      now - float64(i),
+			Value:     &v,
+		}
+		if i%2 == 0 {
+			msg.Publisher = pubID2
+			pubMsgs = append(pubMsgs, msg)
+		}
+		messages = append(messages, msg)
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db, writers.SingleNaming)
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		pageMeta readers.PageMetadata
+		messages []readers.Message
+	}{
+		{
+			desc:     "stream all messages ignoring limit",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Limit: limit},
+			messages: fromSenml(messages),
+		},
+		{
+			desc:     "stream messages with publisher filter",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Publisher: pubID2},
+			messages: fromSenml(pubMsgs),
+		},
+		{
+			desc:     "stream messages for non-existent channel",
+			chanID:   wrongID,
+			pageMeta: readers.PageMetadata{},
+			messages: []readers.Message{},
+		},
+	}
+
+	for _, tc := range cases {
+		streamed := []readers.Message{}
+		err := reader.StreamAll(tc.chanID, tc.pageMeta, func(msg readers.Message) error {
+			streamed = append(streamed, msg)
+			return nil
+		})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.messages, streamed, fmt.Sprintf("%s: got incorrect list of senml Messages from StreamAll()", tc.desc))
+	}
+}
+
+func TestCountSenml(t *testing.T) {
+	writer := twriter.New(db, writers.SingleNaming)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+	pubID2 := testsutil.GenerateUUID(t)
+
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      now - float64(i),
+			Value:     &v,
+		}
+		if i%2 == 0 {
+			msg.Publisher = pubID2
+		}
+		if i%4 == 0 {
+			msg.Subtopic = subtopic
+		}
+		messages = append(messages, msg)
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db, writers.SingleNaming)
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		pageMeta readers.PageMetadata
+		count    readers.MessagesCount
+	}{
+		{
+			desc:     "count all messages ignoring limit",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Limit: limit},
+			count:    readers.MessagesCount{Total: msgsNum},
+		},
+		{
+			desc:     "count messages with publisher filter",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{Publisher: pubID2},
+			count:    readers.MessagesCount{Total: msgsNum / 2},
+		},
+		{
+			desc:     "count messages with time range",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{From: now - 9, To: now + 1},
+			count:    readers.MessagesCount{Total: 10},
+		},
+		{
+			desc:     "count messages grouped by publisher",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{GroupBy: readers.PublisherGroup},
+			count: readers.MessagesCount{
+				Total:  msgsNum,
+				Groups: map[string]uint64{pubID: msgsNum / 2, pubID2: msgsNum / 2},
+			},
+		},
+		{
+			desc:     "count messages grouped by subtopic",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{GroupBy: readers.SubtopicGroup},
+			count: readers.MessagesCount{
+				Total:  msgsNum,
+				Groups: map[string]uint64{"": msgsNum * 3 / 4, subtopic: msgsNum / 4},
+			},
+		},
+		{
+			desc:     "count messages for non-existent channel",
+			chanID:   wrongID,
+			pageMeta: readers.PageMetadata{},
+			count:    readers.MessagesCount{},
+		},
+	}
+
+	for _, tc := range cases {
+		count, err := reader.CountMessages(tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.count, count))
+	}
+}
+
 func TestReadJSON(t *testing.T) {
-	writer := twriter.New(db)
+	writer := twriter.New(db, writers.SingleNaming)
 
 	id1 := testsutil.GenerateUUID(t)
 	messages1 := json.Messages{
@@ -717,7 +1053,7 @@ func TestReadJSON(t *testing.T) {
 		httpMsgs = append(httpMsgs, msgs2[i])
 	}
 
-	reader := treader.New(db)
+	reader := treader.New(db, writers.SingleNaming)
 
 	cases := map[string]struct {
 		chanID   string